@@ -24,8 +24,15 @@ import (
 	"scanoss.com/licenses/pkg/app"
 )
 
-// main starts the gRPC License Service.
+// main starts the gRPC License Service, or runs the "stats" subcommand when given as the first argument.
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "stats" {
+		if err := app.Stats(os.Args[2:]); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "ERROR: License statistics error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 	// Launch the License Server Service
 	if err := app.Boostrap(); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "ERROR: Server launch error: %v\n", err)
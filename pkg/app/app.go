@@ -23,7 +23,9 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/golobby/config/v3"
@@ -35,11 +37,17 @@ import (
 	"github.com/scanoss/go-models/pkg/scanoss"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
 	_ "modernc.org/sqlite"
+	"scanoss.com/licenses/pkg/audit"
 	"scanoss.com/licenses/pkg/cache"
 	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/curation"
+	"scanoss.com/licenses/pkg/handler"
+	models "scanoss.com/licenses/pkg/model"
+	"scanoss.com/licenses/pkg/policy"
 	"scanoss.com/licenses/pkg/protocol/grpc"
 	"scanoss.com/licenses/pkg/protocol/rest"
 	"scanoss.com/licenses/pkg/server"
+	"scanoss.com/licenses/pkg/watchlist"
 )
 
 //go:generate bash ../../get_version.sh
@@ -47,14 +55,17 @@ import (
 var version string
 
 // getConfig checks command line args for option to feed into the config parser.
-func getConfig() (*myconfig.ServerConfig, error) {
+// Subcommands pass their own flag set, holding any extra options they need, and their arguments.
+func getConfig(flags *flag.FlagSet, args []string) (*myconfig.ServerConfig, error) {
 	var jsonConfig, envConfig, loggingConfig string
-	flag.StringVar(&jsonConfig, "json-config", "", "Application JSON config")
-	flag.StringVar(&envConfig, "env-config", "", "Application dot-ENV config")
-	flag.StringVar(&loggingConfig, "logging-config", "", "Logging config file")
-	debug := flag.Bool("debug", false, "Enable debug")
-	ver := flag.Bool("version", false, "Display current version")
-	flag.Parse()
+	flags.StringVar(&jsonConfig, "json-config", "", "Application JSON config")
+	flags.StringVar(&envConfig, "env-config", "", "Application dot-ENV config")
+	flags.StringVar(&loggingConfig, "logging-config", "", "Logging config file")
+	debug := flags.Bool("debug", false, "Enable debug")
+	ver := flags.Bool("version", false, "Display current version")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
 	if *ver {
 		fmt.Printf("Version: %v", version)
 		os.Exit(1)
@@ -83,7 +94,7 @@ func getConfig() (*myconfig.ServerConfig, error) {
 // Boostrap runs the gRPC License Server.
 func Boostrap() error {
 	// Load command line options and config
-	cfg, err := getConfig()
+	cfg, err := getConfig(flag.CommandLine, os.Args[1:])
 	if err != nil {
 		return fmt.Errorf("failed to load config: %v", err)
 	}
@@ -110,10 +121,18 @@ func Boostrap() error {
 	if err != nil {
 		return err
 	}
-	if err = gd.SetDBOptionsAndPing(db); err != nil {
+	defer gd.CloseDBConnection(db)
+	// Cache snapshots let the service start, and keep answering from its caches, while the database is down
+	snapshots, err := cache.NewSnapshots(cfg.Cache.SnapshotDir)
+	if err != nil {
 		return err
 	}
-	defer gd.CloseDBConnection(db)
+	if err = gd.SetDBOptionsAndPing(db); err != nil {
+		if snapshots == nil {
+			return err
+		}
+		zlog.S.Warnf("Database unreachable, starting from the cache snapshots in %s: %v", cfg.Cache.SnapshotDir, err)
+	}
 	// Setup dynamic logging (if necessary)
 	zlog.SetupAppDynamicLogging(cfg.Logging.DynamicPort, cfg.Logging.DynamicLogging)
 
@@ -121,26 +140,147 @@ func Boostrap() error {
 	// Initialize SPDX license cache
 	sc := scanoss.New(db)
 	refreshSPDXCacheTime := time.Duration(cfg.Cache.SPDXRefreshHours) * time.Hour
-	spdxCache := cache.NewSPDXLicenseCache(sc, zlog.S, refreshSPDXCacheTime)
+	spdxCache := cache.NewSPDXLicenseCache(sc, zlog.S, refreshSPDXCacheTime, spdxRefreshPolicy(cfg))
+	spdxCache.UseSnapshots(snapshots)
 	if err = spdxCache.Start(ctx); err != nil {
 		return fmt.Errorf("failed to initialize SPDX license cache: %v", err)
 	}
 	defer spdxCache.Stop()
+	// Initialize license details cache (licenses and osadl tables), refreshed alongside the SPDX cache
+	detailsCache := cache.NewLicenseDetailsCache(models.NewLicenseDetailModel(db), models.NewOSADLModel(db), zlog.S, refreshSPDXCacheTime)
+	detailsCache.UseSnapshots(snapshots)
+	if err = detailsCache.Start(ctx); err != nil {
+		zlog.S.Warnf("Failed to load license details cache, details will be read from the database until the next refresh: %v", err)
+	}
+	defer detailsCache.Stop()
+	// Initialize the knowledge base statistics, computed in the background
+	statsCache := cache.NewLicenseStatsCache(models.NewLicenseStatsModel(db), spdxCache, zlog.S,
+		time.Duration(cfg.Cache.StatsRefreshHours)*time.Hour)
+	statsCache.UseSnapshots(snapshots)
+	if err = statsCache.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start license statistics cache: %v", err)
+	}
+	defer statsCache.Stop()
+
+	licenseHandler := handler.NewLicenseHandler(cfg, db, spdxCache, detailsCache, statsCache)
+	// Caches can be inspected and refreshed through the admin API, and are refreshed on SIGHUP. The statistics
+	// scan the whole purl_licenses table, so they are only refreshed by name
+	caches := cache.NewRegistry(spdxCache, detailsCache)
+	caches.AddByNameOnly(statsCache)
+	caches.Add(licenseHandler.Caches()...)
+	// Changes to the policy and curations are recorded in the audit log, if any
+	auditLog, err := audit.Open(cfg.Audit.File)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %v", err)
+	}
+	startup := audit.WithActor(ctx, audit.ActorStartup, "")
+	// Load the license policy, if any; it is reloaded when the file changes and along with the caches
+	if policyEngine := policy.NewEngine(cfg.Policy.File, time.Duration(cfg.Policy.ReloadSeconds)*time.Second, zlog.S); policyEngine != nil {
+		policyEngine.UseAudit(auditLog)
+		if err = policyEngine.Start(startup); err != nil {
+			return fmt.Errorf("failed to load license policy: %v", err)
+		}
+		defer policyEngine.Stop()
+		licenseHandler.UsePolicy(policyEngine)
+		caches.Add(policyEngine)
+	}
+	// Load the curation overrides, if any; they are reloaded along with the caches
+	if curations := curation.NewStore(cfg.Curation.File, zlog.S); curations != nil {
+		curations.UseAudit(auditLog)
+		if err = curations.Refresh(startup); err != nil {
+			return fmt.Errorf("failed to load license curations: %v", err)
+		}
+		licenseHandler.UseCurations(curations)
+		caches.Add(curations)
+	}
+	// Watch the licenses of the components in the watchlist, if any, re-resolving them periodically and when refreshed by name
+	watchlistStore, err := watchlist.Open(cfg.Watchlist.File)
+	if err != nil {
+		return fmt.Errorf("failed to open watchlist: %v", err)
+	}
+	if watchJob := watchlist.NewJob(watchlistStore, licenseHandler.WatchlistResolver(), watchlistNotifier(cfg),
+		time.Duration(cfg.Watchlist.IntervalMinutes)*time.Minute, zlog.S); watchJob != nil {
+		watchJob.Start()
+		defer watchJob.Stop()
+		caches.AddByNameOnly(watchJob)
+	}
+	stopRefreshOnSignal := refreshCachesOnSignal(ctx, caches)
+	defer stopRefreshOnSignal()
 
-	v2API := server.NewLicenseServer(cfg, db, spdxCache)
+	v2API := server.NewLicenseServer(cfg, db, licenseHandler)
+	restAPI := server.NewLicenseRESTServer(cfg, licenseHandler)
+	adminHandler := handler.NewAdminHandler(caches)
+	adminHandler.UseAudit(auditLog)
+	if watchlistStore != nil {
+		adminHandler.UseWatchlist(watchlistStore)
+	}
+	adminAPI := server.NewAdminRESTServer(cfg, adminHandler)
+	healthHandler := handler.NewHealthHandler(cfg, db, spdxCache)
+	healthAPI := server.NewHealthRESTServer(healthHandler)
+	metricsAPI := server.NewMetricsRESTServer(cfg)
+	grpcHealth := server.NewHealthServer(cfg, healthHandler)
+	stopHealthChecks := grpcHealth.Start(ctx)
+	defer stopHealthChecks()
 	// Start the REST grpc-gateway if requested
 	var srv *http.Server
 	if len(cfg.App.RESTPort) > 0 {
-		if srv, err = rest.RunServer(cfg, ctx, cfg.App.GRPCPort, cfg.App.RESTPort, allowedIPs, deniedIPs, startTLS); err != nil {
+		if srv, err = rest.RunServer(cfg, ctx, cfg.App.GRPCPort, cfg.App.RESTPort, allowedIPs, deniedIPs, startTLS, restAPI, adminAPI, healthAPI, metricsAPI); err != nil {
 			fmt.Printf("Failed to start REST server: %v", err)
 			return err
 		}
 	}
 	// Start the gRPC service
-	server, err := grpc.RunServer(cfg, v2API, cfg.App.GRPCPort, allowedIPs, deniedIPs, startTLS, version)
+	server, err := grpc.RunServer(cfg, v2API, grpcHealth, cfg.App.GRPCPort, allowedIPs, deniedIPs, startTLS, version)
 	if err != nil {
 		return err
 	}
 	// graceful shutdown
 	return gs.WaitServerComplete(srv, server)
 }
+
+// spdxRefreshPolicy returns the refresh timeout, retry and start up behaviour of the SPDX license cache.
+func spdxRefreshPolicy(cfg *myconfig.ServerConfig) cache.RefreshPolicy {
+	return cache.RefreshPolicy{
+		Timeout:        time.Duration(cfg.Cache.SPDXRefreshTimeoutSeconds) * time.Second,
+		InitialBackoff: time.Duration(cfg.Cache.SPDXRetryBackoffSeconds) * time.Second,
+		MaxBackoff:     time.Duration(cfg.Cache.SPDXRetryMaxBackoffSeconds) * time.Second,
+		StartDegraded:  cfg.Cache.SPDXStartDegraded,
+	}
+}
+
+// watchlistNotifier returns the webhook receiving the license changes of watched components, or nil when none is configured.
+func watchlistNotifier(cfg *myconfig.ServerConfig) watchlist.Notifier {
+	webhook := watchlist.NewWebhook(cfg.Watchlist.WebhookURL, cfg.Watchlist.WebhookSecret,
+		time.Duration(cfg.Watchlist.WebhookTimeoutSeconds)*time.Second)
+	if webhook == nil {
+		return nil
+	}
+	return webhook
+}
+
+// refreshCachesOnSignal refreshes the caches of the registry each time the process receives SIGHUP.
+// The returned function stops listening for the signal.
+func refreshCachesOnSignal(ctx context.Context, caches *cache.Registry) func() {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-hup:
+				zlog.S.Infof("SIGHUP received, refreshing caches")
+				if err := caches.RefreshAll(audit.WithActor(ctx, audit.ActorSignal, "")); err != nil {
+					zlog.S.Errorf("Failed to refresh caches: %v", err)
+				} else {
+					zlog.S.Infof("Caches refreshed")
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(hup)
+		close(done)
+	}
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	"github.com/scanoss/go-models/pkg/scanoss"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/licenses/pkg/cache"
+	"scanoss.com/licenses/pkg/dto"
+	"scanoss.com/licenses/pkg/handler"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+// Stats runs the "stats" subcommand: it computes the knowledge base statistics once, as the server does
+// on its schedule, and prints them to stdout as text tables or as the JSON served by /v2/licenses/stats.
+func Stats(args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	format := flags.String("format", "text", "Output format: text or json")
+	cfg, err := getConfig(flags, args)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unsupported output format %q, use text or json", *format)
+	}
+	if err = zlog.SetupAppLogger(cfg.App.Mode, cfg.Logging.ConfigFile, cfg.App.Debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	db, err := gd.OpenDBConnection(cfg.Database.Dsn, cfg.Database.Driver, cfg.Database.User, cfg.Database.Passwd,
+		cfg.Database.Host, cfg.Database.Schema, cfg.Database.SslMode)
+	if err != nil {
+		return err
+	}
+	if err = gd.SetDBOptionsAndPing(db); err != nil {
+		return err
+	}
+	defer gd.CloseDBConnection(db)
+
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	// The statistics are meaningless without the SPDX list, so never start degraded here
+	policy := spdxRefreshPolicy(cfg)
+	policy.StartDegraded = false
+	spdxCache := cache.NewSPDXLicenseCache(scanoss.New(db), zlog.S, time.Hour, policy)
+	if err = spdxCache.Start(ctx); err != nil {
+		return fmt.Errorf("failed to initialize SPDX license cache: %v", err)
+	}
+	defer spdxCache.Stop()
+	statsCache := cache.NewLicenseStatsCache(models.NewLicenseStatsModel(db), spdxCache, zlog.S, time.Hour)
+	if err = statsCache.Refresh(ctx); err != nil {
+		return fmt.Errorf("failed to compute license statistics: %v", err)
+	}
+	response, _ := handler.NewLicenseHandler(cfg, db, spdxCache, nil, statsCache).GetLicenseStats(ctx)
+	if response.Stats == nil {
+		return errors.New(response.Status.Message)
+	}
+	if *format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(response.Stats)
+	}
+	return writeLicenseStats(os.Stdout, response.Stats)
+}
+
+// writeLicenseStats prints the statistics as text tables.
+func writeLicenseStats(out io.Writer, stats *dto.LicenseStatsDTO) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Generated at:\t%s\n", stats.GeneratedAt)
+	_, _ = fmt.Fprintf(w, "Components:\t%d\n", stats.Components)
+	_, _ = fmt.Fprintf(w, "Without SPDX license:\t%d\t(%.2f%%)\n", stats.UnresolvedComponents, stats.UnresolvedShare*100)
+	_, _ = fmt.Fprintf(w, "\nLICENSE\tCOMPONENTS\tSHARE\n")
+	for _, l := range stats.Licenses {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%.2f%%\n", l.License, l.Components, l.Share*100)
+	}
+	_, _ = fmt.Fprintf(w, "\nPURL TYPE\tCOMPONENTS\tSHARE\n")
+	for _, t := range stats.PurlTypes {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%.2f%%\n", t.PurlType, t.Components, t.Share*100)
+	}
+	_, _ = fmt.Fprintf(w, "\nSOURCE ID\tCOMPONENTS\tSHARE\n")
+	for _, src := range stats.Sources {
+		_, _ = fmt.Fprintf(w, "%d\t%d\t%.2f%%\n", src.SourceID, src.Components, src.Share*100)
+	}
+	return w.Flush()
+}
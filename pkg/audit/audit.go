@@ -0,0 +1,249 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package audit keeps an append-only, hash-chained log of the changes made to the license policy and curations.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Actions recorded in the log.
+const (
+	// ActionCreate records the first state of a subject.
+	ActionCreate = "create"
+	// ActionUpdate records a change to a subject.
+	ActionUpdate = "update"
+)
+
+// Entry is a change recorded in the log. Hash is the SHA-256 of PrevHash followed by the entry
+// serialized as compact JSON without its hash, chaining each entry to the one before it.
+type Entry struct {
+	Seq     int64     `json:"seq"`
+	Time    time.Time `json:"time"`
+	Subject string    `json:"subject"`
+	Action  string    `json:"action"`
+	Actor   string    `json:"actor"`
+	Reason  string    `json:"reason,omitempty"`
+	// Before is the state of the subject before the change, null when it is created.
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+	// PrevHash is the hash of the previous entry, empty for the first one.
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash,omitempty"`
+}
+
+// Log is an audit log stored as JSON lines in a file, which is only ever appended to.
+// A nil *Log is valid and records nothing.
+type Log struct {
+	file string
+
+	mu      sync.RWMutex
+	entries []Entry
+	// states holds the last state recorded for each subject.
+	states map[string]json.RawMessage
+}
+
+// Open reads the audit log in file, creating it if needed, and verifies its hash chain.
+// It returns nil when file is empty (no audit log). A log whose chain is broken is an error,
+// so nothing is appended to a log that was tampered with.
+func Open(file string) (*Log, error) {
+	if len(file) == 0 {
+		return nil, nil
+	}
+	entries, err := readFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if err = Verify(entries); err != nil {
+		return nil, fmt.Errorf("audit log %s: %w", file, err)
+	}
+	l := &Log{file: file, entries: entries, states: make(map[string]json.RawMessage)}
+	for _, e := range entries {
+		l.states[e.Subject] = e.After
+	}
+	return l, nil
+}
+
+// readFile reads the entries of the audit log in file, which may not exist yet.
+func readFile(file string) ([]Entry, error) {
+	f, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var e Entry
+		if err = json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("audit log %s, line %d: %w", file, line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log %s: %w", file, err)
+	}
+	return entries, nil
+}
+
+// Record appends an entry for subject when its new state differs from the last one recorded.
+// The actor and reason are taken from ctx (see WithActor).
+func (l *Log) Record(ctx context.Context, subject string, state any) error {
+	if l == nil {
+		return nil
+	}
+	after, err := marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to serialize %s for the audit log: %w", subject, err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	before, known := l.states[subject]
+	if known && bytes.Equal(before, after) {
+		return nil
+	}
+	actor, reason := actorFromContext(ctx)
+	e := Entry{
+		Seq:     int64(len(l.entries)) + 1,
+		Time:    time.Now().UTC(),
+		Subject: subject,
+		Action:  ActionCreate,
+		Actor:   actor,
+		Reason:  reason,
+		Before:  before,
+		After:   after,
+	}
+	if known {
+		e.Action = ActionUpdate
+	} else {
+		e.Before = json.RawMessage("null")
+	}
+	if n := len(l.entries); n > 0 {
+		e.PrevHash = l.entries[n-1].Hash
+	}
+	if e.Hash, err = hash(e); err != nil {
+		return err
+	}
+	line, err := marshal(e)
+	if err != nil {
+		return err
+	}
+	if err = appendLine(l.file, line); err != nil {
+		return fmt.Errorf("failed to write audit log %s: %w", l.file, err)
+	}
+	l.entries = append(l.entries, e)
+	l.states[subject] = after
+	return nil
+}
+
+// Verify checks that each entry follows the one before it and carries the hash of its content.
+func Verify(entries []Entry) error {
+	prevHash := ""
+	for i, e := range entries {
+		if e.Seq != int64(i)+1 {
+			return fmt.Errorf("entry %d: unexpected sequence number %d", i+1, e.Seq)
+		}
+		if e.PrevHash != prevHash {
+			return fmt.Errorf("entry %d: previous hash does not match entry %d", e.Seq, e.Seq-1)
+		}
+		h, err := hash(e)
+		if err != nil {
+			return err
+		}
+		if h != e.Hash {
+			return fmt.Errorf("entry %d: hash does not match its content", e.Seq)
+		}
+		prevHash = e.Hash
+	}
+	return nil
+}
+
+// VerifyFile reads the log file back and checks its hash chain, and that it still holds every entry
+// recorded since the log was opened. It returns the entries read.
+func (l *Log) VerifyFile() ([]Entry, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	entries, err := readFile(l.file)
+	if err != nil {
+		return nil, err
+	}
+	if err = Verify(entries); err != nil {
+		return entries, err
+	}
+	if len(entries) < len(l.entries) {
+		return entries, fmt.Errorf("the log holds %d entries, %d were recorded", len(entries), len(l.entries))
+	}
+	for i, e := range l.entries {
+		if entries[i].Hash != e.Hash {
+			return entries, fmt.Errorf("entry %d: hash differs from the one recorded", e.Seq)
+		}
+	}
+	return entries, nil
+}
+
+// hash returns the hex SHA-256 of the entry's previous hash followed by the entry without its hash.
+func hash(e Entry) (string, error) {
+	e.Hash = ""
+	content, err := marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append([]byte(e.PrevHash), content...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// marshal serializes v as compact JSON, leaving HTML characters unescaped.
+func marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// appendLine appends line to file, creating it if needed, and flushes it to disk.
+func appendLine(file string, line []byte) error {
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLog(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := Open(file)
+	if err != nil {
+		t.Fatalf("failed to open the audit log: %v", err)
+	}
+	ctx := WithActor(context.Background(), "jane", "ticket LEGAL-42")
+	assert.NoError(t, log.Record(ctx, "policy", map[string]string{"default": "allow"}))
+	assert.NoError(t, log.Record(ctx, "policy", map[string]string{"default": "allow"}), "unchanged states are not recorded")
+	assert.NoError(t, log.Record(context.Background(), "curations", []string{"<pkg:npm/x>"}))
+	assert.NoError(t, log.Record(ctx, "policy", map[string]string{"default": "deny"}))
+
+	entries := log.Entries(Filter{})
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, ActionCreate, entries[0].Action)
+		assert.Equal(t, "jane", entries[0].Actor)
+		assert.Equal(t, "ticket LEGAL-42", entries[0].Reason)
+		assert.Equal(t, "null", string(entries[0].Before))
+		assert.Empty(t, entries[0].PrevHash)
+		assert.Equal(t, ActorUnknown, entries[1].Actor)
+		assert.Equal(t, `["<pkg:npm/x>"]`, string(entries[1].After), "HTML characters are not escaped")
+		assert.Equal(t, ActionUpdate, entries[2].Action)
+		assert.JSONEq(t, `{"default": "allow"}`, string(entries[2].Before))
+		assert.JSONEq(t, `{"default": "deny"}`, string(entries[2].After))
+		assert.Equal(t, entries[1].Hash, entries[2].PrevHash)
+	}
+	assert.NoError(t, Verify(entries))
+	read, err := log.VerifyFile()
+	assert.NoError(t, err)
+	assert.Len(t, read, 3)
+
+	t.Run("filters", func(t *testing.T) {
+		assert.Len(t, log.Entries(Filter{Subject: "policy"}), 2)
+		assert.Len(t, log.Entries(Filter{Actor: "jane"}), 2)
+		assert.Len(t, log.Entries(Filter{AfterSeq: 1, Limit: 1}), 1)
+		assert.Equal(t, int64(2), log.Entries(Filter{AfterSeq: 1, Limit: 1})[0].Seq)
+		assert.Empty(t, log.Entries(Filter{Since: time.Now().Add(time.Hour)}))
+		assert.Empty(t, log.Entries(Filter{Until: entries[0].Time}))
+	})
+
+	t.Run("reopening carries on the chain", func(t *testing.T) {
+		reopened, err := Open(file)
+		if err != nil {
+			t.Fatalf("failed to reopen the audit log: %v", err)
+		}
+		assert.NoError(t, reopened.Record(ctx, "policy", map[string]string{"default": "deny"}), "the last state is known")
+		assert.Len(t, reopened.Entries(Filter{}), 3)
+		assert.NoError(t, reopened.Record(ctx, "curations", []string{}))
+		all := reopened.Entries(Filter{})
+		if assert.Len(t, all, 4) {
+			assert.Equal(t, ActionUpdate, all[3].Action)
+			assert.Equal(t, all[2].Hash, all[3].PrevHash)
+		}
+	})
+
+	t.Run("tampering is detected", func(t *testing.T) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read the audit log: %v", err)
+		}
+		tampered := bytes.Replace(data, []byte(`"actor":"jane"`), []byte(`"actor":"john"`), 1)
+		assert.NoError(t, os.WriteFile(file, tampered, 0o600))
+		_, err = log.VerifyFile()
+		assert.Error(t, err)
+		_, err = Open(file)
+		assert.Error(t, err, "a tampered log is not appended to")
+
+		lines := bytes.SplitAfter(data, []byte("\n"))
+		assert.NoError(t, os.WriteFile(file, bytes.Join(lines[:2], nil), 0o600))
+		_, err = log.VerifyFile()
+		assert.Error(t, err, "truncating the log is detected")
+	})
+
+	t.Run("no audit log", func(t *testing.T) {
+		disabled, err := Open("")
+		assert.NoError(t, err)
+		assert.Nil(t, disabled)
+		assert.NoError(t, disabled.Record(ctx, "policy", nil))
+		assert.Empty(t, disabled.Entries(Filter{}))
+	})
+}
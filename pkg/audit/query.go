@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Actors recorded when the admin API caller gives no name, and for changes not requested through the admin API.
+const (
+	ActorAdminAPI  = "admin-api"
+	ActorStartup   = "startup"
+	ActorSignal    = "sighup"
+	ActorFileWatch = "file-watch"
+	// ActorUnknown is recorded when ctx names no actor.
+	ActorUnknown = "unknown"
+)
+
+type actorKey struct{}
+
+// actor is who requested a change, and why.
+type actor struct {
+	name   string
+	reason string
+}
+
+// WithActor returns a context recording that the changes made with it were requested by name, for the given reason.
+func WithActor(ctx context.Context, name, reason string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor{name: name, reason: reason})
+}
+
+// actorFromContext returns the actor and reason stored in ctx by WithActor.
+func actorFromContext(ctx context.Context) (string, string) {
+	a, ok := ctx.Value(actorKey{}).(actor)
+	if !ok || len(a.name) == 0 {
+		return ActorUnknown, a.reason
+	}
+	return a.name, a.reason
+}
+
+// Filter selects entries of the log. Zero fields match every entry.
+type Filter struct {
+	Subject string
+	Actor   string
+	Since   time.Time
+	Until   time.Time
+	// AfterSeq skips the entries up to and including this sequence number, to page through the log.
+	AfterSeq int64
+	// Limit caps the number of entries returned.
+	Limit int
+}
+
+// matches reports whether e is selected by the filter.
+func (f Filter) matches(e Entry) bool {
+	switch {
+	case len(f.Subject) > 0 && e.Subject != f.Subject:
+		return false
+	case len(f.Actor) > 0 && e.Actor != f.Actor:
+		return false
+	case !f.Since.IsZero() && e.Time.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !e.Time.Before(f.Until):
+		return false
+	}
+	return e.Seq > f.AfterSeq
+}
+
+// Entries returns the entries selected by the filter, oldest first.
+func (l *Log) Entries(f Filter) []Entry {
+	entries := []Entry{}
+	if l == nil {
+		return entries
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, e := range l.entries {
+		if f.Limit > 0 && len(entries) == f.Limit {
+			break
+		}
+		if f.matches(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,246 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+type LicenseDetailsCacheInterface interface {
+	GetLicenseByID(licenseID string) (models.LicenseDetail, bool)
+	GetOSADLByLicenseID(licenseID string) (models.OSADL, bool)
+	GetAllLicenses() []models.LicenseDetail
+	Start(ctx context.Context) error
+	Stop()
+}
+
+// LicenseDetailsCache keeps the licenses and osadl tables in memory, keyed by upper-cased license ID.
+type LicenseDetailsCache struct {
+	mu         sync.RWMutex
+	licenses   map[string]models.LicenseDetail
+	osadl      map[string]models.OSADL
+	refresh    refreshState
+	licModel   models.LicenseDetailModelInterface
+	osadlModel models.OSADLModelInterface
+	snapshots  *Snapshots
+	logger     *zap.SugaredLogger
+	ticker     *time.Ticker
+	done       chan struct{}
+	stopOnce   sync.Once
+	interval   time.Duration
+}
+
+func NewLicenseDetailsCache(licModel models.LicenseDetailModelInterface, osadlModel models.OSADLModelInterface,
+	logger *zap.SugaredLogger, interval time.Duration) *LicenseDetailsCache {
+	return &LicenseDetailsCache{
+		licModel:   licModel,
+		osadlModel: osadlModel,
+		logger:     logger,
+		licenses:   make(map[string]models.LicenseDetail),
+		osadl:      make(map[string]models.OSADL),
+		interval:   interval,
+		done:       make(chan struct{}),
+	}
+}
+
+// UseSnapshots makes the cache save a snapshot after every successful refresh,
+// and fall back to the latest snapshot when the initial load fails.
+func (c *LicenseDetailsCache) UseSnapshots(snapshots *Snapshots) {
+	c.snapshots = snapshots
+}
+
+// Start performs the initial load and starts the background refresh goroutine, unless the interval is zero.
+// The refresh goroutine is started even when the initial load fails, so the cache
+// recovers on the next refresh; until then it serves its snapshot, if any, and otherwise every lookup is a miss.
+func (c *LicenseDetailsCache) Start(ctx context.Context) error {
+	err := c.Refresh(ctx)
+	if err != nil {
+		if takenAt, snapErr := c.restoreSnapshot(); snapErr == nil {
+			c.logger.Warnf("Failed to load license details cache, serving the snapshot taken at %v: %v", takenAt, err)
+		}
+	}
+	if c.interval > 0 {
+		c.ticker = time.NewTicker(c.interval)
+		go c.refreshLoop()
+	}
+	return err
+}
+
+// Stop stops the background refresh goroutine. It is safe to call more than once.
+func (c *LicenseDetailsCache) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.done)
+		if c.ticker != nil {
+			c.ticker.Stop()
+		}
+	})
+}
+
+// GetLicenseByID returns the cached licenses row for the given license ID.
+func (c *LicenseDetailsCache) GetLicenseByID(licenseID string) (models.LicenseDetail, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	license, ok := c.licenses[strings.ToUpper(licenseID)]
+	return license, ok
+}
+
+// GetOSADLByLicenseID returns the cached osadl row for the given license ID.
+func (c *LicenseDetailsCache) GetOSADLByLicenseID(licenseID string) (models.OSADL, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	osadl, ok := c.osadl[strings.ToUpper(licenseID)]
+	return osadl, ok
+}
+
+// GetAllLicenses returns all cached licenses rows, in no particular order.
+func (c *LicenseDetailsCache) GetAllLicenses() []models.LicenseDetail {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result := make([]models.LicenseDetail, 0, len(c.licenses))
+	for _, license := range c.licenses {
+		result = append(result, license)
+	}
+	return result
+}
+
+// Name returns the name of the cache in the admin API.
+func (c *LicenseDetailsCache) Name() string {
+	return "license-details"
+}
+
+// Refresh reloads the licenses and osadl tables. On failure the current entries are kept.
+func (c *LicenseDetailsCache) Refresh(ctx context.Context) error {
+	if err := c.loadFromDB(ctx); err != nil {
+		return c.refresh.record(err)
+	}
+	if err := c.snapshots.Save(c.Name(), c.snapshot()); err != nil {
+		c.logger.Warnf("Failed to save license details cache snapshot: %v", err)
+	}
+	return c.refresh.record(nil)
+}
+
+// Status reports the number of cached licenses and the outcome of the last refresh.
+func (c *LicenseDetailsCache) Status() Status {
+	c.mu.RLock()
+	size := len(c.licenses)
+	c.mu.RUnlock()
+	return c.refresh.status(c.Name(), size)
+}
+
+// LicenseDetailsEntry is the cached data of a license, as dumped by the admin API.
+type LicenseDetailsEntry struct {
+	License *models.LicenseDetail `json:"license,omitempty"`
+	OSADL   *models.OSADL         `json:"osadl,omitempty"`
+}
+
+// Entry returns the cached licenses and osadl rows of the given license ID.
+func (c *LicenseDetailsCache) Entry(key string) (any, bool) {
+	var entry LicenseDetailsEntry
+	if license, ok := c.GetLicenseByID(key); ok {
+		entry.License = &license
+	}
+	if osadl, ok := c.GetOSADLByLicenseID(key); ok {
+		entry.OSADL = &osadl
+	}
+	return entry, entry.License != nil || entry.OSADL != nil
+}
+
+// loadFromDB reloads both tables and swaps them in together, so lookups never see
+// licenses from one refresh and OSADL data from another.
+func (c *LicenseDetailsCache) loadFromDB(ctx context.Context) error {
+	licenses, err := c.licModel.GetAllLicenses(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	osadls, err := c.osadlModel.GetAllOSADL(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	c.setDetails(licenses, osadls)
+	c.logger.Infof("License details cache loaded: %d licenses, %d OSADL entries", len(licenses), len(osadls))
+	return nil
+}
+
+// licenseDetailsSnapshot is the snapshot form of the license details cache.
+type licenseDetailsSnapshot struct {
+	Licenses []models.LicenseDetail `json:"licenses"`
+	OSADL    []models.OSADL         `json:"osadl"`
+}
+
+// snapshot returns the current content of the cache.
+func (c *LicenseDetailsCache) snapshot() licenseDetailsSnapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	snapshot := licenseDetailsSnapshot{
+		Licenses: make([]models.LicenseDetail, 0, len(c.licenses)),
+		OSADL:    make([]models.OSADL, 0, len(c.osadl)),
+	}
+	for _, l := range c.licenses {
+		snapshot.Licenses = append(snapshot.Licenses, l)
+	}
+	for _, o := range c.osadl {
+		snapshot.OSADL = append(snapshot.OSADL, o)
+	}
+	return snapshot
+}
+
+// restoreSnapshot loads the cache from its latest snapshot and returns the time the snapshot was taken.
+func (c *LicenseDetailsCache) restoreSnapshot() (time.Time, error) {
+	var snapshot licenseDetailsSnapshot
+	takenAt, err := c.snapshots.Load(c.Name(), &snapshot)
+	if err != nil {
+		return time.Time{}, err
+	}
+	c.setDetails(snapshot.Licenses, snapshot.OSADL)
+	c.refresh.restored(takenAt)
+	return takenAt, nil
+}
+
+// setDetails swaps in both tables together.
+func (c *LicenseDetailsCache) setDetails(licenses []models.LicenseDetail, osadls []models.OSADL) {
+	licenseMap := make(map[string]models.LicenseDetail, len(licenses))
+	for _, l := range licenses {
+		licenseMap[strings.ToUpper(l.LicenseID)] = l
+	}
+	osadlMap := make(map[string]models.OSADL, len(osadls))
+	for _, o := range osadls {
+		osadlMap[strings.ToUpper(o.LicenseID)] = o
+	}
+	c.mu.Lock()
+	c.licenses = licenseMap
+	c.osadl = osadlMap
+	c.mu.Unlock()
+}
+
+func (c *LicenseDetailsCache) refreshLoop() {
+	for {
+		select {
+		case <-c.ticker.C:
+			if err := c.Refresh(context.Background()); err != nil {
+				c.logger.Errorf("Failed to refresh license details cache: %v", err)
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+// fakeLicenseModel serves a fixed set of licenses rows.
+type fakeLicenseModel struct {
+	licenses []models.LicenseDetail
+	err      error
+}
+
+func (f *fakeLicenseModel) GetLicenseByID(_ context.Context, _ *zap.SugaredLogger, _ string) (models.LicenseDetail, error) {
+	return models.LicenseDetail{}, nil
+}
+
+func (f *fakeLicenseModel) GetLicensesByIDs(_ context.Context, _ *zap.SugaredLogger, _ []string) ([]models.LicenseDetail, error) {
+	return nil, nil
+}
+
+func (f *fakeLicenseModel) GetAllLicenses(_ context.Context, _ *zap.SugaredLogger) ([]models.LicenseDetail, error) {
+	return f.licenses, f.err
+}
+
+// fakeOSADLModel serves a fixed set of osadl rows.
+type fakeOSADLModel struct {
+	osadl []models.OSADL
+	err   error
+}
+
+func (f *fakeOSADLModel) GetOSADLByLicenseID(_ context.Context, _ *zap.SugaredLogger, _ string) (models.OSADL, error) {
+	return models.OSADL{}, nil
+}
+
+func (f *fakeOSADLModel) GetOSADLByLicenseIDs(_ context.Context, _ *zap.SugaredLogger, _ []string) ([]models.OSADL, error) {
+	return nil, nil
+}
+
+func (f *fakeOSADLModel) GetAllOSADL(_ context.Context, _ *zap.SugaredLogger) ([]models.OSADL, error) {
+	return f.osadl, f.err
+}
+
+func TestLicenseDetailsCache_Lookup(t *testing.T) {
+	licModel := &fakeLicenseModel{licenses: []models.LicenseDetail{
+		{ID: 1, LicenseID: "MIT", Name: "MIT License"},
+		{ID: 2, LicenseID: "Apache-2.0", Name: "Apache License 2.0"},
+	}}
+	osadlModel := &fakeOSADLModel{osadl: []models.OSADL{{ID: 1, LicenseID: "MIT", PatentHints: true}}}
+	cache := NewLicenseDetailsCache(licModel, osadlModel, zap.NewNop().Sugar(), time.Hour)
+	err := cache.Start(context.Background())
+	assert.NoError(t, err)
+	defer cache.Stop()
+
+	t.Run("case insensitive license lookup", func(t *testing.T) {
+		for _, input := range []string{"mit", "MIT", "Mit"} {
+			license, ok := cache.GetLicenseByID(input)
+			assert.True(t, ok, "expected to find license for input %q", input)
+			assert.Equal(t, "MIT License", license.Name)
+		}
+	})
+
+	t.Run("OSADL lookup", func(t *testing.T) {
+		osadl, ok := cache.GetOSADLByLicenseID("mit")
+		assert.True(t, ok)
+		assert.True(t, osadl.PatentHints)
+		_, ok = cache.GetOSADLByLicenseID("Apache-2.0")
+		assert.False(t, ok)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, ok := cache.GetLicenseByID("nonexistent")
+		assert.False(t, ok)
+	})
+}
+
+func TestLicenseDetailsCache_LoadFailure(t *testing.T) {
+	licModel := &fakeLicenseModel{licenses: []models.LicenseDetail{{ID: 1, LicenseID: "MIT"}}}
+	osadlModel := &fakeOSADLModel{err: errors.New("osadl table unavailable")}
+	cache := NewLicenseDetailsCache(licModel, osadlModel, zap.NewNop().Sugar(), time.Hour)
+
+	err := cache.Start(context.Background())
+	assert.Error(t, err)
+	defer cache.Stop()
+	assert.NotNil(t, cache.ticker, "refresh should be scheduled even when the initial load fails")
+
+	// Neither table is swapped in when one of them fails to load.
+	_, ok := cache.GetLicenseByID("MIT")
+	assert.False(t, ok)
+
+	osadlModel.err = nil
+	assert.NoError(t, cache.loadFromDB(context.Background()))
+	_, ok = cache.GetLicenseByID("MIT")
+	assert.True(t, ok)
+}
+
+func TestLicenseDetailsCache_ZeroInterval(t *testing.T) {
+	licModel := &fakeLicenseModel{licenses: []models.LicenseDetail{{ID: 1, LicenseID: "MIT"}}}
+	cache := NewLicenseDetailsCache(licModel, &fakeOSADLModel{}, zap.NewNop().Sugar(), 0)
+
+	assert.NoError(t, cache.Start(context.Background()))
+	assert.Nil(t, cache.ticker, "no refresh is scheduled at a zero interval")
+	_, ok := cache.GetLicenseByID("MIT")
+	assert.True(t, ok)
+
+	cache.Stop()
+	cache.Stop()
+}
@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/license"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+// LicenseStats summarises the purl_licenses table. A component is a distinct purl: it is counted once
+// per license, purl type and source it appears with, whatever the number of versions.
+type LicenseStats struct {
+	GeneratedAt time.Time
+	Duration    time.Duration
+	Components  int
+	// Unresolved counts the components without any license that resolves to an SPDX license.
+	Unresolved int
+	ByLicense  map[string]int
+	ByPurlType map[string]int
+	BySource   map[int16]int
+}
+
+type LicenseStatsCacheInterface interface {
+	GetStats() (*LicenseStats, bool)
+	Refresh(ctx context.Context) error
+	Start(ctx context.Context) error
+	Stop()
+}
+
+// LicenseStatsCache holds the latest LicenseStats, recomputed on a schedule.
+type LicenseStatsCache struct {
+	mu        sync.RWMutex
+	stats     *LicenseStats
+	refresh   refreshState
+	model     models.LicenseStatsModelInterface
+	spdxCache SPDXLicenseCacheInterface
+	snapshots *Snapshots
+	logger    *zap.SugaredLogger
+	done      chan struct{}
+	stopOnce  sync.Once
+	interval  time.Duration
+	// running serializes the refreshes started by the timer, the admin API and SIGHUP.
+	running sync.Mutex
+}
+
+// NewLicenseStatsCache creates a statistics cache. spdxCache decides which licenses are SPDX-resolvable;
+// when nil, the is_spdx flag of the licenses table is trusted.
+func NewLicenseStatsCache(model models.LicenseStatsModelInterface, spdxCache SPDXLicenseCacheInterface,
+	logger *zap.SugaredLogger, interval time.Duration) *LicenseStatsCache {
+	return &LicenseStatsCache{
+		model:     model,
+		spdxCache: spdxCache,
+		logger:    logger,
+		interval:  interval,
+		done:      make(chan struct{}),
+	}
+}
+
+// UseSnapshots makes the cache save a snapshot after every successful refresh, and start from the latest one.
+func (c *LicenseStatsCache) UseSnapshots(snapshots *Snapshots) {
+	c.snapshots = snapshots
+}
+
+// Start computes the statistics in the background and then every interval, unless the interval is zero.
+// Scanning purl_licenses takes a while on a full knowledge base, so Start does not wait for it: GetStats
+// serves the snapshot, if any, and otherwise reports false until the first run completes.
+func (c *LicenseStatsCache) Start(_ context.Context) error {
+	var stats LicenseStats
+	if takenAt, err := c.snapshots.Load(c.Name(), &stats); err == nil {
+		c.mu.Lock()
+		c.stats = &stats
+		c.mu.Unlock()
+		c.refresh.restored(takenAt)
+		c.logger.Infof("License statistics restored from the snapshot taken at %v", takenAt)
+	}
+	go func() {
+		if err := c.Refresh(context.Background()); err != nil {
+			c.logger.Errorf("Failed to compute license statistics: %v", err)
+		}
+		if c.interval > 0 {
+			c.refreshLoop()
+		}
+	}()
+	return nil
+}
+
+// Stop stops the background refresh goroutine. It is safe to call more than once.
+func (c *LicenseStatsCache) Stop() {
+	c.stopOnce.Do(func() { close(c.done) })
+}
+
+// GetStats returns the latest statistics, or false when they have not been computed yet.
+func (c *LicenseStatsCache) GetStats() (*LicenseStats, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.stats, c.stats != nil
+}
+
+// Name returns the name of the cache in the admin API.
+func (c *LicenseStatsCache) Name() string {
+	return "license-stats"
+}
+
+// Status reports the number of components counted and the outcome of the last refresh.
+func (c *LicenseStatsCache) Status() Status {
+	size := 0
+	if stats, ok := c.GetStats(); ok {
+		size = stats.Components
+	}
+	return c.refresh.status(c.Name(), size)
+}
+
+// Entry returns the number of components carrying the given license.
+func (c *LicenseStatsCache) Entry(key string) (any, bool) {
+	stats, ok := c.GetStats()
+	if !ok {
+		return nil, false
+	}
+	components, ok := stats.ByLicense[key]
+	return components, ok
+}
+
+// Refresh recomputes the statistics and swaps them in. On failure the previous statistics are kept.
+// Refreshes run one at a time, so concurrent requests don't scan purl_licenses in parallel.
+func (c *LicenseStatsCache) Refresh(ctx context.Context) error {
+	c.running.Lock()
+	defer c.running.Unlock()
+	if err := c.compute(ctx); err != nil {
+		return c.refresh.record(err)
+	}
+	if stats, ok := c.GetStats(); ok {
+		if err := c.snapshots.Save(c.Name(), stats); err != nil {
+			c.logger.Warnf("Failed to save license statistics snapshot: %v", err)
+		}
+	}
+	return c.refresh.record(nil)
+}
+
+// compute scans purl_licenses and stores the resulting statistics.
+func (c *LicenseStatsCache) compute(ctx context.Context) error {
+	start := time.Now()
+	records, err := c.model.GetAllLicenseRecords(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	labels := make(map[int32]string, len(records))
+	resolvable := make(map[int32]bool, len(records))
+	for _, r := range records {
+		labels[r.ID] = r.SPDX
+		if len(r.SPDX) == 0 {
+			labels[r.ID] = r.LicenseName
+		}
+		resolvable[r.ID] = c.isResolvable(r)
+	}
+	stats := &LicenseStats{
+		ByLicense:  make(map[string]int),
+		ByPurlType: make(map[string]int),
+		BySource:   make(map[int16]int),
+	}
+	// Rows arrive ordered by purl, so a component is complete once the purl changes.
+	var current string
+	var resolved bool
+	componentLicenses := make(map[string]bool)
+	componentSources := make(map[int16]bool)
+	flush := func() {
+		if len(current) == 0 {
+			return
+		}
+		stats.Components++
+		stats.ByPurlType[license.PurlType(current)]++
+		if !resolved {
+			stats.Unresolved++
+		}
+		for l := range componentLicenses {
+			stats.ByLicense[l]++
+		}
+		for s := range componentSources {
+			stats.BySource[s]++
+		}
+		clear(componentLicenses)
+		clear(componentSources)
+		resolved = false
+	}
+	err = c.model.ForEachPurlLicense(ctx, c.logger, func(pl models.PurlLicense) error {
+		if pl.Purl != current {
+			flush()
+			current = pl.Purl
+		}
+		if label, ok := labels[pl.LicenseID]; ok && len(label) > 0 {
+			componentLicenses[label] = true
+		}
+		componentSources[pl.SourceID] = true
+		resolved = resolved || resolvable[pl.LicenseID]
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	flush()
+	stats.GeneratedAt = time.Now()
+	stats.Duration = stats.GeneratedAt.Sub(start)
+	c.mu.Lock()
+	c.stats = stats
+	c.mu.Unlock()
+	c.logger.Infof("License statistics computed in %v: %d components, %d without an SPDX license", stats.Duration, stats.Components, stats.Unresolved)
+	return nil
+}
+
+// isResolvable reports whether a licenses row maps to at least one SPDX license, using the same rule as
+// the component lookups: the row must be flagged is_spdx and one of its IDs must be known to the SPDX cache.
+func (c *LicenseStatsCache) isResolvable(r models.LicenseRecord) bool {
+	if !r.IsSPDX {
+		return false
+	}
+	ids, _ := license.ParseLicenseExpression(r.SPDX)
+	for _, id := range ids {
+		if c.spdxCache == nil {
+			return true
+		}
+		if _, ok := c.spdxCache.GetLicenseByID(id); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *LicenseStatsCache) refreshLoop() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Refresh(context.Background()); err != nil {
+				c.logger.Errorf("Failed to refresh license statistics: %v", err)
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gomodels "github.com/scanoss/go-models/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+// fakeLicenseStatsModel serves fixed licenses and purl_licenses rows.
+type fakeLicenseStatsModel struct {
+	records []models.LicenseRecord
+	rows    []models.PurlLicense
+	err     error
+}
+
+func (f *fakeLicenseStatsModel) GetAllLicenseRecords(_ context.Context, _ *zap.SugaredLogger) ([]models.LicenseRecord, error) {
+	return f.records, f.err
+}
+
+func (f *fakeLicenseStatsModel) ForEachPurlLicense(_ context.Context, _ *zap.SugaredLogger, fn func(models.PurlLicense) error) error {
+	for _, row := range f.rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakeSPDXCache knows a fixed set of SPDX IDs.
+type fakeSPDXCache struct {
+	ids map[string]bool
+}
+
+func (f *fakeSPDXCache) GetLicenseByID(spdxID string) (*gomodels.SPDXLicenseDetail, bool) {
+	if f.ids[spdxID] {
+		return &gomodels.SPDXLicenseDetail{ID: spdxID}, true
+	}
+	return nil, false
+}
+
+func (f *fakeSPDXCache) GetAllLicenses() []*gomodels.SPDXLicenseDetail { return nil }
+func (f *fakeSPDXCache) Start(_ context.Context) error                 { return nil }
+func (f *fakeSPDXCache) Stop()                                         {}
+
+func TestLicenseStatsCache_Refresh(t *testing.T) {
+	model := &fakeLicenseStatsModel{
+		records: []models.LicenseRecord{
+			{ID: 1, LicenseName: "MIT", SPDX: "MIT", IsSPDX: true},
+			{ID: 2, LicenseName: "Apache 2.0", SPDX: "Apache-2.0", IsSPDX: true},
+			{ID: 3, LicenseName: "Apache License 2.0", SPDX: "Apache-2.0", IsSPDX: true},
+			{ID: 4, LicenseName: "Custom", SPDX: "", IsSPDX: false},
+			{ID: 5, LicenseName: "Retired", SPDX: "Retired-1.0", IsSPDX: true},
+		},
+		rows: []models.PurlLicense{
+			{Purl: "pkg:maven/org.example/lib", SourceID: 1, LicenseID: 2},
+			{Purl: "pkg:maven/org.example/lib", SourceID: 3, LicenseID: 3},
+			{Purl: "pkg:npm/express", SourceID: 1, LicenseID: 1},
+			{Purl: "pkg:npm/express", SourceID: 1, LicenseID: 1},
+			{Purl: "pkg:npm/private", SourceID: 2, LicenseID: 4},
+			{Purl: "pkg:pypi/retired", SourceID: 2, LicenseID: 5},
+		},
+	}
+	spdx := &fakeSPDXCache{ids: map[string]bool{"MIT": true, "Apache-2.0": true}}
+	cache := NewLicenseStatsCache(model, spdx, zap.NewNop().Sugar(), time.Hour)
+
+	_, ok := cache.GetStats()
+	assert.False(t, ok, "expected no statistics before the first refresh")
+
+	assert.NoError(t, cache.Refresh(context.Background()))
+	stats, ok := cache.GetStats()
+	assert.True(t, ok)
+	assert.Equal(t, 4, stats.Components)
+	assert.Equal(t, 2, stats.Unresolved)
+	assert.Equal(t, map[string]int{"Apache-2.0": 1, "MIT": 1, "Custom": 1, "Retired-1.0": 1}, stats.ByLicense)
+	assert.Equal(t, map[string]int{"maven": 1, "npm": 2, "pypi": 1}, stats.ByPurlType)
+	assert.Equal(t, map[int16]int{1: 2, 2: 2, 3: 1}, stats.BySource)
+
+	t.Run("failed refresh keeps the previous statistics", func(t *testing.T) {
+		model.err = errors.New("db down")
+		assert.Error(t, cache.Refresh(context.Background()))
+		current, found := cache.GetStats()
+		assert.True(t, found)
+		assert.Same(t, stats, current)
+	})
+}
+
+func TestLicenseStatsCache_StartComputesInBackground(t *testing.T) {
+	model := &fakeLicenseStatsModel{
+		records: []models.LicenseRecord{{ID: 1, LicenseName: "MIT", SPDX: "MIT", IsSPDX: true}},
+		rows:    []models.PurlLicense{{Purl: "pkg:npm/express", SourceID: 1, LicenseID: 1}},
+	}
+	cache := NewLicenseStatsCache(model, nil, zap.NewNop().Sugar(), time.Hour)
+	assert.NoError(t, cache.Start(context.Background()))
+	defer cache.Stop()
+	assert.Eventually(t, func() bool {
+		stats, ok := cache.GetStats()
+		return ok && stats.Components == 1 && stats.Unresolved == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestLicenseStatsCache_ZeroInterval(t *testing.T) {
+	model := &fakeLicenseStatsModel{
+		records: []models.LicenseRecord{{ID: 1, LicenseName: "MIT", SPDX: "MIT", IsSPDX: true}},
+		rows:    []models.PurlLicense{{Purl: "pkg:npm/express", SourceID: 1, LicenseID: 1}},
+	}
+	cache := NewLicenseStatsCache(model, nil, zap.NewNop().Sugar(), 0)
+	assert.NoError(t, cache.Start(context.Background()))
+	assert.Eventually(t, func() bool {
+		_, ok := cache.GetStats()
+		return ok
+	}, time.Second, 10*time.Millisecond, "the statistics are computed once without periodic refreshes")
+	cache.Stop()
+	cache.Stop()
+}
+
+// slowLicenseStatsModel records how many scans of purl_licenses run at the same time.
+type slowLicenseStatsModel struct {
+	fakeLicenseStatsModel
+	running atomic.Int32
+	peak    atomic.Int32
+}
+
+func (f *slowLicenseStatsModel) ForEachPurlLicense(ctx context.Context, s *zap.SugaredLogger, fn func(models.PurlLicense) error) error {
+	running := f.running.Add(1)
+	defer f.running.Add(-1)
+	for {
+		peak := f.peak.Load()
+		if running <= peak || f.peak.CompareAndSwap(peak, running) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return f.fakeLicenseStatsModel.ForEachPurlLicense(ctx, s, fn)
+}
+
+func TestLicenseStatsCache_RefreshesRunOneAtATime(t *testing.T) {
+	model := &slowLicenseStatsModel{}
+	cache := NewLicenseStatsCache(model, nil, zap.NewNop().Sugar(), time.Hour)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, cache.Refresh(context.Background()))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), model.peak.Load())
+}
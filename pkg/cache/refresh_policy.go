@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cache
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// RefreshPolicy bounds the refreshes of a cache and controls how failed ones are retried.
+type RefreshPolicy struct {
+	// Timeout limits a single refresh; zero means no limit.
+	Timeout time.Duration
+	// InitialBackoff is the delay before the first retry of a failed refresh. Each further failure doubles it,
+	// up to MaxBackoff. Zero disables retries: a failed refresh waits for the next scheduled one.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// StartDegraded lets Start succeed with an empty cache when the initial load fails.
+	// The load is then retried in the background.
+	StartDegraded bool
+}
+
+// withTimeout derives a context bounded by the policy timeout.
+func (p RefreshPolicy) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.Timeout)
+}
+
+// nextDelay returns how long to wait before the next refresh, given the number of consecutive failures
+// and the regular refresh interval, or zero when no refresh is due. Retries use exponential backoff with jitter:
+// the delay is drawn from the upper half of the backoff window, so caches failing together do not retry in lockstep.
+// An interval of zero or less disables the regular refreshes; failed ones are still retried.
+func (p RefreshPolicy) nextDelay(failures int, interval time.Duration) time.Duration {
+	if failures == 0 || p.InitialBackoff <= 0 {
+		return max(interval, 0)
+	}
+	backoff := p.InitialBackoff
+	for i := 1; i < failures && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 {
+		backoff = min(backoff, p.MaxBackoff)
+	}
+	if interval > 0 {
+		backoff = min(backoff, interval)
+	}
+	half := backoff / 2
+	return half + rand.N(backoff-half+1)
+}
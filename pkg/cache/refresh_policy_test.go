@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-models/pkg/scanoss"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	_ "modernc.org/sqlite"
+)
+
+func TestRefreshPolicy_NextDelay(t *testing.T) {
+	policy := RefreshPolicy{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	interval := time.Minute
+
+	assert.Equal(t, interval, policy.nextDelay(0, interval), "successful refreshes wait for the interval")
+	for failures, backoff := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 8 * time.Second, 5: 10 * time.Second, 50: 10 * time.Second} {
+		for i := 0; i < 20; i++ {
+			delay := policy.nextDelay(failures, interval)
+			assert.GreaterOrEqual(t, delay, backoff/2, "failures %d", failures)
+			assert.LessOrEqual(t, delay, backoff, "failures %d", failures)
+		}
+	}
+	assert.LessOrEqual(t, policy.nextDelay(5, 3*time.Second), 3*time.Second, "retries never wait longer than the interval")
+	assert.Equal(t, interval, RefreshPolicy{}.nextDelay(3, interval), "retries are disabled without an initial backoff")
+
+	// Without an interval there are no regular refreshes, but failed ones are still retried.
+	assert.Zero(t, policy.nextDelay(0, 0))
+	assert.Zero(t, policy.nextDelay(0, -time.Hour))
+	assert.Zero(t, RefreshPolicy{}.nextDelay(1, 0))
+	delay := policy.nextDelay(5, 0)
+	assert.GreaterOrEqual(t, delay, 5*time.Second)
+	assert.LessOrEqual(t, delay, 10*time.Second)
+}
+
+func TestSPDXLicenseCache_ZeroInterval(t *testing.T) {
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	cache := NewSPDXLicenseCache(scanoss.New(db), zap.NewNop().Sugar(), 0, RefreshPolicy{StartDegraded: true})
+	assert.NoError(t, cache.Start(context.Background()))
+	defer cache.Stop()
+	select {
+	case <-cache.timer.C:
+		t.Fatal("no refresh is scheduled without an interval")
+	case <-time.After(50 * time.Millisecond):
+	}
+	cache.Stop()
+}
+
+func TestRefreshPolicy_WithTimeout(t *testing.T) {
+	ctx, cancel := RefreshPolicy{Timeout: time.Second}.withTimeout(context.Background())
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+
+	ctx, cancel = RefreshPolicy{}.withTimeout(context.Background())
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+}
+
+func TestSPDXLicenseCache_StartDegraded(t *testing.T) {
+	// A closed database makes every load fail.
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	_ = db.Close()
+	logger := zap.NewNop().Sugar()
+
+	strict := NewSPDXLicenseCache(scanoss.New(db), logger, time.Hour, RefreshPolicy{})
+	assert.Error(t, strict.Start(context.Background()))
+
+	degraded := NewSPDXLicenseCache(scanoss.New(db), logger, time.Hour,
+		RefreshPolicy{InitialBackoff: time.Hour, MaxBackoff: time.Hour, StartDegraded: true})
+	assert.NoError(t, degraded.Start(context.Background()))
+	defer degraded.Stop()
+	assert.Equal(t, 1, degraded.failures)
+	assert.Empty(t, degraded.GetAllLicenses())
+	status := degraded.Status()
+	assert.NotEmpty(t, status.LastError)
+	assert.True(t, status.Stale(time.Hour), "a cache that never loaded is stale")
+	assert.Zero(t, status.Age())
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Status describes the state of a cache for the admin API.
+type Status struct {
+	Name string
+	Size int
+	// LastRefresh is the time of the last successful refresh; zero if the cache was never loaded.
+	LastRefresh time.Time
+	// LastError is the error of the most recent refresh attempt; empty when it succeeded.
+	LastError string
+}
+
+// Age returns how long ago the cache was last refreshed successfully, or zero if it never was.
+func (s Status) Age() time.Duration {
+	if s.LastRefresh.IsZero() {
+		return 0
+	}
+	return time.Since(s.LastRefresh)
+}
+
+// Stale reports whether the cache was never loaded, or was not refreshed successfully within maxAge.
+func (s Status) Stale(maxAge time.Duration) bool {
+	return s.LastRefresh.IsZero() || s.Age() > maxAge
+}
+
+// Reloadable is a cache that can be inspected and refreshed on demand, through the admin API or on SIGHUP.
+type Reloadable interface {
+	Name() string
+	Refresh(ctx context.Context) error
+	Status() Status
+	// Entry returns the cached value for key, for debugging. The key format depends on the cache.
+	Entry(key string) (any, bool)
+}
+
+// refreshState records the outcome of the refreshes of a cache.
+type refreshState struct {
+	mu          sync.Mutex
+	lastRefresh time.Time
+	lastError   string
+}
+
+// record stores the outcome of a refresh attempt and returns err unchanged.
+func (r *refreshState) record(err error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.lastError = err.Error()
+		return err
+	}
+	r.lastRefresh = time.Now()
+	r.lastError = ""
+	return nil
+}
+
+// restored records that the cache was loaded from a snapshot taken at the given time. The snapshot time
+// stands in for the last refresh, so the age of the cache reflects the age of its data.
+func (r *refreshState) restored(at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastRefresh = at
+}
+
+// status returns a Status holding the recorded refresh outcome.
+func (r *refreshState) status(name string, size int) Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Status{Name: name, Size: size, LastRefresh: r.lastRefresh, LastError: r.lastError}
+}
+
+// Registry holds the caches exposed by the admin API, in registration order.
+type Registry struct {
+	caches []Reloadable
+	// byNameOnly holds the names of the caches RefreshAll leaves out.
+	byNameOnly map[string]bool
+}
+
+// NewRegistry creates a registry of the given caches. Nil caches are skipped.
+func NewRegistry(caches ...Reloadable) *Registry {
+	r := &Registry{}
+	r.Add(caches...)
+	return r
+}
+
+// Add registers more caches. Nil caches are skipped.
+func (r *Registry) Add(caches ...Reloadable) {
+	for _, c := range caches {
+		if c != nil {
+			r.caches = append(r.caches, c)
+		}
+	}
+}
+
+// AddByNameOnly registers caches whose refresh is too slow to run as part of RefreshAll, such as a full
+// table scan; they are only refreshed on their own, by name. Nil caches are skipped.
+func (r *Registry) AddByNameOnly(caches ...Reloadable) {
+	for _, c := range caches {
+		if c != nil {
+			r.caches = append(r.caches, c)
+			if r.byNameOnly == nil {
+				r.byNameOnly = make(map[string]bool)
+			}
+			r.byNameOnly[c.Name()] = true
+		}
+	}
+}
+
+// Get returns the cache with the given name.
+func (r *Registry) Get(name string) (Reloadable, bool) {
+	for _, c := range r.caches {
+		if c.Name() == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// Statuses returns the status of every cache.
+func (r *Registry) Statuses() []Status {
+	statuses := make([]Status, 0, len(r.caches))
+	for _, c := range r.caches {
+		statuses = append(statuses, c.Status())
+	}
+	return statuses
+}
+
+// RefreshAll refreshes every cache but those added with AddByNameOnly, carrying on past failures, and returns
+// the joined errors.
+func (r *Registry) RefreshAll(ctx context.Context) error {
+	var errs []error
+	for _, c := range r.caches {
+		if r.byNameOnly[c.Name()] {
+			continue
+		}
+		if err := c.Refresh(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+// fakeReloadable is a cache whose refresh outcome is set by the test.
+type fakeReloadable struct {
+	name    string
+	err     error
+	calls   int
+	refresh refreshState
+}
+
+func (f *fakeReloadable) Name() string { return f.name }
+func (f *fakeReloadable) Refresh(_ context.Context) error {
+	f.calls++
+	return f.refresh.record(f.err)
+}
+func (f *fakeReloadable) Status() Status               { return f.refresh.status(f.name, f.calls) }
+func (f *fakeReloadable) Entry(key string) (any, bool) { return key, key == "known" }
+
+func TestRegistry(t *testing.T) {
+	ok := &fakeReloadable{name: "ok"}
+	failing := &fakeReloadable{name: "failing", err: errors.New("db down")}
+	registry := NewRegistry(ok, nil, failing)
+
+	_, found := registry.Get("missing")
+	assert.False(t, found)
+	c, found := registry.Get("failing")
+	assert.True(t, found)
+	assert.Same(t, failing, c)
+
+	err := registry.RefreshAll(context.Background())
+	assert.ErrorContains(t, err, "failing: db down")
+	assert.Equal(t, 1, ok.calls, "a failing cache must not stop the others from refreshing")
+
+	statuses := registry.Statuses()
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "ok", statuses[0].Name)
+	assert.False(t, statuses[0].LastRefresh.IsZero())
+	assert.Empty(t, statuses[0].LastError)
+	assert.True(t, statuses[1].LastRefresh.IsZero())
+	assert.Equal(t, "db down", statuses[1].LastError)
+
+	// A later successful refresh clears the error and keeps its time.
+	failing.err = nil
+	assert.NoError(t, registry.RefreshAll(context.Background()))
+	assert.Empty(t, failing.Status().LastError)
+	assert.False(t, failing.Status().LastRefresh.IsZero())
+
+	t.Run("caches refreshed by name only", func(t *testing.T) {
+		slow := &fakeReloadable{name: "slow"}
+		registry.AddByNameOnly(slow, nil)
+		assert.NoError(t, registry.RefreshAll(context.Background()))
+		assert.Equal(t, 0, slow.calls, "RefreshAll leaves it out")
+		assert.Len(t, registry.Statuses(), 3)
+		c, found := registry.Get("slow")
+		if assert.True(t, found) {
+			assert.NoError(t, c.Refresh(context.Background()))
+			assert.Equal(t, 1, slow.calls)
+		}
+	})
+}
+
+func TestLicenseDetailsCache_Admin(t *testing.T) {
+	licModel := &fakeLicenseModel{licenses: []models.LicenseDetail{{ID: 1, LicenseID: "MIT", Name: "MIT License"}}}
+	osadlModel := &fakeOSADLModel{err: errors.New("osadl table missing")}
+	cache := NewLicenseDetailsCache(licModel, osadlModel, zap.NewNop().Sugar(), time.Hour)
+	assert.Error(t, cache.Start(context.Background()))
+	defer cache.Stop()
+
+	status := cache.Status()
+	assert.Equal(t, "license-details", status.Name)
+	assert.Equal(t, 0, status.Size)
+	assert.Equal(t, "osadl table missing", status.LastError)
+
+	osadlModel.err = nil
+	assert.NoError(t, cache.Refresh(context.Background()))
+	status = cache.Status()
+	assert.Equal(t, 1, status.Size)
+	assert.Empty(t, status.LastError)
+	assert.False(t, status.LastRefresh.IsZero())
+
+	entry, ok := cache.Entry("mit")
+	assert.True(t, ok)
+	assert.Equal(t, "MIT License", entry.(LicenseDetailsEntry).License.Name)
+	_, ok = cache.Entry("unknown")
+	assert.False(t, ok)
+}
+
+func TestStatus_Staleness(t *testing.T) {
+	fresh := Status{LastRefresh: time.Now().Add(-time.Minute)}
+	assert.InDelta(t, time.Minute, fresh.Age(), float64(time.Second))
+	assert.False(t, fresh.Stale(time.Hour))
+	assert.True(t, fresh.Stale(time.Second))
+	assert.True(t, Status{}.Stale(time.Hour))
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+// ResultCacheStats is a snapshot of the counters of a ResultCache.
+type ResultCacheStats struct {
+	Hits     uint64
+	Misses   uint64
+	Size     int
+	Capacity int
+}
+
+// ResultCache is a size bounded cache of lookup results: the least recently used entry is evicted when it is
+// full, and entries expire ttl after they were added. It is safe for concurrent use.
+type ResultCache[V any] struct {
+	name     string
+	lru      *expirable.LRU[string, V]
+	refresh  refreshState
+	capacity int
+	hits     atomic.Uint64
+	misses   atomic.Uint64
+}
+
+// NewResultCache creates a cache holding up to size entries for ttl each. name identifies it in the admin API.
+func NewResultCache[V any](name string, size int, ttl time.Duration) *ResultCache[V] {
+	return &ResultCache[V]{
+		name:     name,
+		lru:      expirable.NewLRU[string, V](size, nil, ttl),
+		capacity: size,
+	}
+}
+
+// Get returns the cached value for key, counting the lookup as a hit or a miss.
+func (c *ResultCache[V]) Get(key string) (V, bool) {
+	value, ok := c.lru.Get(key)
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return value, ok
+}
+
+// Add stores value under key, evicting the least recently used entry if the cache is full.
+func (c *ResultCache[V]) Add(key string, value V) {
+	c.lru.Add(key, value)
+}
+
+// Purge removes every entry. The hit and miss counters are kept.
+func (c *ResultCache[V]) Purge() {
+	c.lru.Purge()
+}
+
+// Name returns the name of the cache in the admin API.
+func (c *ResultCache[V]) Name() string {
+	return c.name
+}
+
+// Refresh empties the cache, so the following lookups are resolved again.
+func (c *ResultCache[V]) Refresh(_ context.Context) error {
+	c.Purge()
+	return c.refresh.record(nil)
+}
+
+// Status reports the number of cached entries and when the cache was last emptied.
+func (c *ResultCache[V]) Status() Status {
+	return c.refresh.status(c.name, c.lru.Len())
+}
+
+// Entry returns the value cached under key without counting a lookup or refreshing its recency.
+func (c *ResultCache[V]) Entry(key string) (any, bool) {
+	return c.lru.Peek(key)
+}
+
+// Stats returns the current counters.
+func (c *ResultCache[V]) Stats() ResultCacheStats {
+	return ResultCacheStats{
+		Hits:     c.hits.Load(),
+		Misses:   c.misses.Load(),
+		Size:     c.lru.Len(),
+		Capacity: c.capacity,
+	}
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultCache(t *testing.T) {
+	c := NewResultCache[string]("test", 2, time.Hour)
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	c.Add("a", "A")
+	c.Add("b", "B")
+	value, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "A", value)
+
+	// "b" is now the least recently used entry and is evicted first.
+	c.Add("c", "C")
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+
+	assert.Equal(t, ResultCacheStats{Hits: 2, Misses: 2, Size: 2, Capacity: 2}, c.Stats())
+
+	c.Purge()
+	assert.Equal(t, 0, c.Stats().Size)
+	assert.Equal(t, uint64(2), c.Stats().Hits)
+}
+
+func TestResultCache_Expiry(t *testing.T) {
+	c := NewResultCache[string]("test", 10, 20*time.Millisecond)
+	c.Add("a", "A")
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+	time.Sleep(40 * time.Millisecond)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+}
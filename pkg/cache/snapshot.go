@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// snapshotVersion is the version of the snapshot file format. Snapshots of another version are ignored.
+const snapshotVersion = 1
+
+// ErrNoSnapshot is returned when no usable snapshot can be loaded.
+var ErrNoSnapshot = errors.New("no cache snapshot")
+
+// snapshotFile is the on-disk form of a cache snapshot. Checksum is the hex SHA-256 of Data.
+type snapshotFile struct {
+	Version   int             `json:"version"`
+	Cache     string          `json:"cache"`
+	CreatedAt time.Time       `json:"created_at"`
+	Checksum  string          `json:"checksum"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Snapshots saves and restores cache contents to files in a local directory, one per cache,
+// so a cache can be served from its last known state when the database is unreachable.
+// A nil *Snapshots is valid and disables snapshotting.
+type Snapshots struct {
+	dir string
+}
+
+// NewSnapshots creates a snapshot store in dir. It returns nil, disabling snapshots, when dir is empty.
+func NewSnapshots(dir string) (*Snapshots, error) {
+	if len(dir) == 0 {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create cache snapshot directory %s: %w", dir, err)
+	}
+	return &Snapshots{dir: dir}, nil
+}
+
+// path returns the snapshot file of the named cache.
+func (s *Snapshots) path(name string) string {
+	return filepath.Join(s.dir, name+".snapshot.json")
+}
+
+// Save writes the JSON encoding of data as the snapshot of the named cache. The file is replaced
+// atomically, so a crash mid-write never leaves a truncated snapshot behind.
+func (s *Snapshots) Save(name string, data any) error {
+	if s == nil {
+		return nil
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s snapshot: %w", name, err)
+	}
+	sum := sha256.Sum256(payload)
+	content, err := json.Marshal(snapshotFile{
+		Version:   snapshotVersion,
+		Cache:     name,
+		CreatedAt: time.Now().UTC(),
+		Checksum:  hex.EncodeToString(sum[:]),
+		Data:      payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s snapshot: %w", name, err)
+	}
+	tmp, err := os.CreateTemp(s.dir, name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write %s snapshot: %w", name, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(content); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path(name))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s snapshot: %w", name, err)
+	}
+	return nil
+}
+
+// Load decodes the snapshot of the named cache into data and returns the time it was taken.
+// Missing, corrupted and other-version snapshots are reported as errors wrapping ErrNoSnapshot.
+func (s *Snapshots) Load(name string, data any) (time.Time, error) {
+	if s == nil {
+		return time.Time{}, ErrNoSnapshot
+	}
+	content, err := os.ReadFile(s.path(name))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrNoSnapshot, err)
+	}
+	var file snapshotFile
+	if err = json.Unmarshal(content, &file); err != nil {
+		return time.Time{}, fmt.Errorf("%w: malformed %s snapshot: %w", ErrNoSnapshot, name, err)
+	}
+	if file.Version != snapshotVersion || file.Cache != name {
+		return time.Time{}, fmt.Errorf("%w: %s snapshot has version %d for cache %q, want version %d",
+			ErrNoSnapshot, name, file.Version, file.Cache, snapshotVersion)
+	}
+	sum := sha256.Sum256(file.Data)
+	if hex.EncodeToString(sum[:]) != file.Checksum {
+		return time.Time{}, fmt.Errorf("%w: %s snapshot checksum mismatch", ErrNoSnapshot, name)
+	}
+	if err = json.Unmarshal(file.Data, data); err != nil {
+		return time.Time{}, fmt.Errorf("%w: malformed %s snapshot data: %w", ErrNoSnapshot, name, err)
+	}
+	return file.CreatedAt, nil
+}
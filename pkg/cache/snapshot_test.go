@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	gomodels "github.com/scanoss/go-models/pkg/models"
+	"github.com/scanoss/go-models/pkg/scanoss"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+func TestSnapshots(t *testing.T) {
+	snapshots, err := NewSnapshots(t.TempDir())
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the snapshot store", err)
+	}
+	saved := map[string]int{"MIT": 4, "Apache-2.0": 2}
+	assert.NoError(t, snapshots.Save("test", saved))
+
+	var loaded map[string]int
+	takenAt, err := snapshots.Load("test", &loaded)
+	assert.NoError(t, err)
+	assert.Equal(t, saved, loaded)
+	assert.WithinDuration(t, time.Now(), takenAt, time.Minute)
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := snapshots.Load("other", &loaded)
+		assert.ErrorIs(t, err, ErrNoSnapshot)
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		content, _ := os.ReadFile(snapshots.path("test"))
+		tampered := strings.Replace(string(content), `"MIT":4`, `"MIT":5`, 1)
+		assert.NoError(t, os.WriteFile(snapshots.path("tampered"), []byte(strings.Replace(tampered, `"cache":"test"`, `"cache":"tampered"`, 1)), 0o600))
+		_, err := snapshots.Load("tampered", &loaded)
+		assert.ErrorIs(t, err, ErrNoSnapshot)
+		assert.ErrorContains(t, err, "checksum mismatch")
+	})
+
+	t.Run("other version", func(t *testing.T) {
+		content, _ := os.ReadFile(snapshots.path("test"))
+		old := strings.Replace(string(content), `"version":1`, `"version":0`, 1)
+		assert.NoError(t, os.WriteFile(snapshots.path("test"), []byte(old), 0o600))
+		_, err := snapshots.Load("test", &loaded)
+		assert.ErrorIs(t, err, ErrNoSnapshot)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		disabled, err := NewSnapshots("")
+		assert.NoError(t, err)
+		assert.Nil(t, disabled)
+		assert.NoError(t, disabled.Save("test", saved))
+		_, err = disabled.Load("test", &loaded)
+		assert.ErrorIs(t, err, ErrNoSnapshot)
+	})
+}
+
+func TestSPDXLicenseCache_RestoresSnapshot(t *testing.T) {
+	snapshots, err := NewSnapshots(t.TempDir())
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the snapshot store", err)
+	}
+	assert.NoError(t, snapshots.Save("spdx-licenses", []gomodels.SPDXLicenseDetail{{ID: "MIT", Name: "MIT License", IsOsiApproved: boolPtr(true)}}))
+	// A closed database makes every load fail.
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	_ = db.Close()
+
+	cache := NewSPDXLicenseCache(scanoss.New(db), zap.NewNop().Sugar(), time.Hour, RefreshPolicy{})
+	cache.UseSnapshots(snapshots)
+	assert.NoError(t, cache.Start(context.Background()), "a snapshot lets the cache start without the database")
+	defer cache.Stop()
+	detail, ok := cache.GetLicenseByID("mit")
+	if assert.True(t, ok) {
+		assert.Equal(t, "MIT License", detail.Name)
+		assert.True(t, *detail.IsOsiApproved)
+	}
+	status := cache.Status()
+	assert.NotEmpty(t, status.LastError)
+	assert.WithinDuration(t, time.Now(), status.LastRefresh, time.Minute, "the snapshot time stands in for the last refresh")
+}
+
+func TestLicenseDetailsCache_Snapshots(t *testing.T) {
+	snapshots, err := NewSnapshots(t.TempDir())
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the snapshot store", err)
+	}
+	logger := zap.NewNop().Sugar()
+	licModel := &fakeLicenseModel{licenses: []models.LicenseDetail{{ID: 1, LicenseID: "MIT", Name: "MIT License", SeeAlso: models.SeeAlso{"https://opensource.org/license/mit"}}}}
+	osadlModel := &fakeOSADLModel{osadl: []models.OSADL{{ID: 1, LicenseID: "MIT", UseCases: models.JSONStringSlice{"Binary delivery"}}}}
+
+	// A successful load writes the snapshot...
+	first := NewLicenseDetailsCache(licModel, osadlModel, logger, time.Hour)
+	first.UseSnapshots(snapshots)
+	assert.NoError(t, first.Start(context.Background()))
+	first.Stop()
+
+	// ...which the next start falls back to when the database is down.
+	licModel.err = errors.New("connection refused")
+	second := NewLicenseDetailsCache(licModel, osadlModel, logger, time.Hour)
+	second.UseSnapshots(snapshots)
+	assert.Error(t, second.Start(context.Background()))
+	defer second.Stop()
+	license, ok := second.GetLicenseByID("mit")
+	assert.True(t, ok)
+	assert.Equal(t, first.GetAllLicenses(), []models.LicenseDetail{license})
+	osadl, ok := second.GetOSADLByLicenseID("MIT")
+	assert.True(t, ok)
+	assert.Equal(t, models.JSONStringSlice{"Binary delivery"}, osadl.UseCases)
+	assert.False(t, second.Status().LastRefresh.IsZero())
+}
+
+func TestLicenseStatsCache_Snapshots(t *testing.T) {
+	snapshots, err := NewSnapshots(t.TempDir())
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the snapshot store", err)
+	}
+	logger := zap.NewNop().Sugar()
+	model := &fakeLicenseStatsModel{
+		records: []models.LicenseRecord{{ID: 1, LicenseName: "MIT", SPDX: "MIT", IsSPDX: true}},
+		rows:    []models.PurlLicense{{Purl: "pkg:npm/a", SourceID: 5, LicenseID: 1}},
+	}
+	first := NewLicenseStatsCache(model, nil, logger, time.Hour)
+	first.UseSnapshots(snapshots)
+	assert.NoError(t, first.Refresh(context.Background()))
+	computed, _ := first.GetStats()
+
+	// The snapshot is served as soon as the cache starts, before any scan completes.
+	model.err = errors.New("connection refused")
+	second := NewLicenseStatsCache(model, nil, logger, time.Hour)
+	second.UseSnapshots(snapshots)
+	assert.NoError(t, second.Start(context.Background()))
+	defer second.Stop()
+	restored, ok := second.GetStats()
+	if assert.True(t, ok) {
+		assert.Equal(t, computed.Components, restored.Components)
+		assert.Equal(t, computed.ByLicense, restored.ByLicense)
+		assert.Equal(t, computed.BySource, restored.BySource)
+		assert.True(t, computed.GeneratedAt.Equal(restored.GeneratedAt))
+	}
+}
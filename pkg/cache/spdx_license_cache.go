@@ -29,46 +29,78 @@ import (
 
 type SPDXLicenseCacheInterface interface {
 	GetLicenseByID(spdxID string) (*gomodels.SPDXLicenseDetail, bool)
+	GetAllLicenses() []*gomodels.SPDXLicenseDetail
 	Start(ctx context.Context) error
 	Stop()
 }
 
 type SPDXLicenseCache struct {
-	mu       sync.RWMutex
-	licenses map[string]*gomodels.SPDXLicenseDetail
-	sc       *scanoss.Client
-	logger   *zap.SugaredLogger
-	ticker   *time.Ticker
-	done     chan struct{}
-	interval time.Duration
+	mu        sync.RWMutex
+	licenses  map[string]*gomodels.SPDXLicenseDetail
+	refresh   refreshState
+	policy    RefreshPolicy
+	snapshots *Snapshots
+	sc        *scanoss.Client
+	logger    *zap.SugaredLogger
+	timer     *time.Timer
+	done      chan struct{}
+	stopOnce  sync.Once
+	interval  time.Duration
+	// failures counts consecutive failed refreshes; only used by Start and the refresh goroutine.
+	failures int
 }
 
-func NewSPDXLicenseCache(sc *scanoss.Client, logger *zap.SugaredLogger, interval time.Duration) *SPDXLicenseCache {
+func NewSPDXLicenseCache(sc *scanoss.Client, logger *zap.SugaredLogger, interval time.Duration, policy RefreshPolicy) *SPDXLicenseCache {
 	return &SPDXLicenseCache{
 		sc:       sc,
 		logger:   logger,
 		licenses: make(map[string]*gomodels.SPDXLicenseDetail),
+		policy:   policy,
 		interval: interval,
 		done:     make(chan struct{}),
 	}
 }
 
+// UseSnapshots makes the cache save a snapshot after every successful refresh,
+// and fall back to the latest snapshot when the initial load fails.
+func (c *SPDXLicenseCache) UseSnapshots(snapshots *Snapshots) {
+	c.snapshots = snapshots
+}
+
 // Start performs the initial load and starts the background refresh goroutine.
+// If the initial load fails, the cache is restored from its snapshot when there is one. Otherwise Start
+// returns the error unless the policy allows starting degraded, in which case the cache starts empty.
+// Either way the load is retried in the background.
 func (c *SPDXLicenseCache) Start(ctx context.Context) error {
-	if err := c.loadFromDB(ctx); err != nil {
-		return err
+	err := c.Refresh(ctx)
+	if err != nil {
+		takenAt, snapErr := c.restoreSnapshot()
+		switch {
+		case snapErr == nil:
+			c.logger.Warnf("Failed to load SPDX license cache, serving the snapshot taken at %v: %v", takenAt, err)
+		case !c.policy.StartDegraded:
+			return err
+		default:
+			c.logger.Warnf("Failed to load SPDX license cache, starting degraded: %v (%v)", err, snapErr)
+		}
+	}
+	delay := c.nextDelay(err)
+	c.timer = time.NewTimer(delay)
+	if delay <= 0 {
+		c.timer.Stop()
 	}
-	c.ticker = time.NewTicker(c.interval)
 	go c.refreshLoop()
 	return nil
 }
 
-// Stop stops the background refresh goroutine.
+// Stop stops the background refresh goroutine. It is safe to call more than once.
 func (c *SPDXLicenseCache) Stop() {
-	close(c.done)
-	if c.ticker != nil {
-		c.ticker.Stop()
-	}
+	c.stopOnce.Do(func() {
+		close(c.done)
+		if c.timer != nil {
+			c.timer.Stop()
+		}
+	})
 }
 
 // GetLicenseByID returns the cached SPDX license detail for the given ID.
@@ -79,11 +111,73 @@ func (c *SPDXLicenseCache) GetLicenseByID(spdxID string) (*gomodels.SPDXLicenseD
 	return detail, ok
 }
 
+// GetAllLicenses returns all cached SPDX license details, in no particular order.
+func (c *SPDXLicenseCache) GetAllLicenses() []*gomodels.SPDXLicenseDetail {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result := make([]*gomodels.SPDXLicenseDetail, 0, len(c.licenses))
+	for _, detail := range c.licenses {
+		result = append(result, detail)
+	}
+	return result
+}
+
+// Name returns the name of the cache in the admin API.
+func (c *SPDXLicenseCache) Name() string {
+	return "spdx-licenses"
+}
+
+// Refresh reloads the SPDX license list from the database, within the policy timeout.
+// On failure the current entries are kept.
+func (c *SPDXLicenseCache) Refresh(ctx context.Context) error {
+	ctx, cancel := c.policy.withTimeout(ctx)
+	defer cancel()
+	if err := c.loadFromDB(ctx); err != nil {
+		return c.refresh.record(err)
+	}
+	if err := c.snapshots.Save(c.Name(), c.GetAllLicenses()); err != nil {
+		c.logger.Warnf("Failed to save SPDX license cache snapshot: %v", err)
+	}
+	return c.refresh.record(nil)
+}
+
+// Status reports the number of cached licenses and the outcome of the last refresh.
+func (c *SPDXLicenseCache) Status() Status {
+	c.mu.RLock()
+	size := len(c.licenses)
+	c.mu.RUnlock()
+	return c.refresh.status(c.Name(), size)
+}
+
+// Entry returns the cached SPDX license detail for the given ID.
+func (c *SPDXLicenseCache) Entry(key string) (any, bool) {
+	return c.GetLicenseByID(key)
+}
+
 func (c *SPDXLicenseCache) loadFromDB(ctx context.Context) error {
 	details, err := c.sc.Models.Licenses.GetAllSPDXLicensesDetails(ctx)
 	if err != nil {
 		return err
 	}
+	c.setLicenses(details)
+	c.logger.Infof("SPDX license cache loaded: %d licenses", len(details))
+	return nil
+}
+
+// restoreSnapshot loads the cache from its latest snapshot and returns the time the snapshot was taken.
+func (c *SPDXLicenseCache) restoreSnapshot() (time.Time, error) {
+	var details []gomodels.SPDXLicenseDetail
+	takenAt, err := c.snapshots.Load(c.Name(), &details)
+	if err != nil {
+		return time.Time{}, err
+	}
+	c.setLicenses(details)
+	c.refresh.restored(takenAt)
+	return takenAt, nil
+}
+
+// setLicenses replaces the cached licenses.
+func (c *SPDXLicenseCache) setLicenses(details []gomodels.SPDXLicenseDetail) {
 	newMap := make(map[string]*gomodels.SPDXLicenseDetail, len(details))
 	for i := range details {
 		key := strings.ToLower(details[i].ID)
@@ -92,16 +186,33 @@ func (c *SPDXLicenseCache) loadFromDB(ctx context.Context) error {
 	c.mu.Lock()
 	c.licenses = newMap
 	c.mu.Unlock()
-	c.logger.Infof("SPDX license cache loaded: %d licenses", len(newMap))
-	return nil
+}
+
+// nextDelay returns when to refresh next: after the regular interval following a success,
+// or after a growing backoff following consecutive failures. Zero means no refresh is due.
+func (c *SPDXLicenseCache) nextDelay(err error) time.Duration {
+	if err == nil {
+		c.failures = 0
+	} else {
+		c.failures++
+	}
+	return c.policy.nextDelay(c.failures, c.interval)
 }
 
 func (c *SPDXLicenseCache) refreshLoop() {
 	for {
 		select {
-		case <-c.ticker.C:
-			if err := c.loadFromDB(context.Background()); err != nil {
-				c.logger.Errorf("Failed to refresh SPDX license cache: %v", err)
+		case <-c.timer.C:
+			err := c.Refresh(context.Background())
+			delay := c.nextDelay(err)
+			switch {
+			case err != nil && delay > 0:
+				c.logger.Errorf("Failed to refresh SPDX license cache (attempt %d), retrying in %v: %v", c.failures, delay, err)
+			case err != nil:
+				c.logger.Errorf("Failed to refresh SPDX license cache (attempt %d): %v", c.failures, err)
+			}
+			if delay > 0 {
+				c.timer.Reset(delay)
 			}
 		case <-c.done:
 			return
@@ -103,23 +103,23 @@ func TestGetLicenseByID_EmptyCache(t *testing.T) {
 }
 
 func TestRefreshInterval(t *testing.T) {
-	t.Run("ticker is created on start with configured interval", func(t *testing.T) {
+	t.Run("timer is armed with the configured interval after a successful load", func(t *testing.T) {
 		cache := newTestCache(500 * time.Millisecond)
-		cache.ticker = time.NewTicker(cache.interval)
-		defer cache.ticker.Stop()
+		cache.timer = time.NewTimer(cache.nextDelay(nil))
+		defer cache.timer.Stop()
 
-		// Verify ticker fires within the expected interval
+		// Verify timer fires within the expected interval
 		select {
-		case <-cache.ticker.C:
-			// Ticker fired as expected
+		case <-cache.timer.C:
+			// Timer fired as expected
 		case <-time.After(2 * time.Second):
-			t.Fatal("ticker did not fire within expected interval")
+			t.Fatal("timer did not fire within expected interval")
 		}
 	})
 
-	t.Run("stop closes done channel and stops ticker", func(t *testing.T) {
+	t.Run("stop closes done channel and stops timer", func(t *testing.T) {
 		cache := newTestCache(100 * time.Millisecond)
-		cache.ticker = time.NewTicker(cache.interval)
+		cache.timer = time.NewTimer(cache.interval)
 
 		cache.Stop()
 
@@ -132,8 +132,8 @@ func TestRefreshInterval(t *testing.T) {
 	})
 
 	t.Run("refresh loop exits on stop", func(t *testing.T) {
-		cache := newTestCache(50 * time.Millisecond)
-		cache.ticker = time.NewTicker(cache.interval)
+		cache := newTestCache(time.Hour)
+		cache.timer = time.NewTimer(cache.interval)
 
 		done := make(chan struct{})
 		go func() {
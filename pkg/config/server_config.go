@@ -73,11 +73,54 @@ type ServerConfig struct {
 		TrustProxy     bool   `env:"DEPS_TRUST_PROXY"`      // Trust the interim proxy or not (causes the source IP to be validated instead of the proxy)
 	}
 	Cache struct {
-		SPDXRefreshHours int `env:"CACHE_SPDX_REFRESH_HOURS"` // SPDX license cache refresh interval in hours (default 24)
+		SPDXRefreshHours           int    `env:"CACHE_SPDX_REFRESH_HOURS"`             // SPDX license cache refresh interval in hours (default 24)
+		StatsRefreshHours          int    `env:"CACHE_STATS_REFRESH_HOURS"`            // License statistics recompute interval in hours (default 24)
+		ResultSize                 int    `env:"CACHE_RESULT_SIZE"`                    // Max component license lookups kept in the result cache, 0 disables it (default 10000)
+		ResultTTLMinutes           int    `env:"CACHE_RESULT_TTL_MINUTES"`             // How long a component license lookup stays in the result cache (default 60)
+		NegativeTTLMinutes         int    `env:"CACHE_NEGATIVE_TTL_MINUTES"`           // How long a "not found" lookup stays in the result cache, 0 disables it (default 5)
+		SPDXRefreshTimeoutSeconds  int    `env:"CACHE_SPDX_REFRESH_TIMEOUT_SECONDS"`   // Max duration of one SPDX cache load, 0 for no limit (default 30)
+		SPDXRetryBackoffSeconds    int    `env:"CACHE_SPDX_RETRY_BACKOFF_SECONDS"`     // First retry delay after a failed SPDX cache load, 0 waits for the next refresh (default 5)
+		SPDXRetryMaxBackoffSeconds int    `env:"CACHE_SPDX_RETRY_MAX_BACKOFF_SECONDS"` // Upper bound of the exponential retry delay (default 300)
+		SPDXStartDegraded          bool   `env:"CACHE_SPDX_START_DEGRADED"`            // Start the service with an empty SPDX cache if the first load fails (default false)
+		SnapshotDir                string `env:"CACHE_SNAPSHOT_DIR"`                   // Directory holding cache snapshots used when the database is unreachable, empty disables them
 	}
 	Lookup struct {
-		SourcePriority []int16 `env:"LOOKUP_SOURCE_PRIORITY"`
-		MaxWorkers     int     `env:"LOOKUP_MAX_WORKERS"`
+		SourcePriority       []int16  `env:"LOOKUP_SOURCE_PRIORITY"`
+		MaxWorkers           int      `env:"LOOKUP_MAX_WORKERS"`
+		ElectionPreferences  []string `env:"LOOKUP_ELECTION_PREFERENCES"`   // Ranked licenses used to elect one option of an OR expression
+		SharedTimeoutSeconds int      `env:"LOOKUP_SHARED_TIMEOUT_SECONDS"` // Longest a coalesced component resolution may run (0 = no limit)
+	}
+	LicenseText struct {
+		Dir   string `env:"LICENSE_TEXT_DIR"`   // SPDX license-list-data directory holding license texts
+		Table bool   `env:"LICENSE_TEXT_TABLE"` // Read license texts from the license_texts DB table when no directory is set
+	}
+	Metrics struct {
+		Enabled bool `env:"METRICS_ENABLED"` // Expose Prometheus metrics on the REST port at /metrics, without authentication (default false)
+	}
+	Health struct {
+		PingTimeoutSeconds   int `env:"HEALTH_PING_TIMEOUT_SECONDS"`   // Max duration of the readiness database ping (default 2)
+		MaxCacheAgeHours     int `env:"HEALTH_MAX_CACHE_AGE_HOURS"`    // Age past which the SPDX license cache makes the service not ready (default 48)
+		CheckIntervalSeconds int `env:"HEALTH_CHECK_INTERVAL_SECONDS"` // How often the gRPC health service re-evaluates readiness (default 10)
+	}
+	Admin struct {
+		Token string `env:"ADMIN_TOKEN"` // Bearer token required by the admin REST endpoints, which are disabled when empty
+	}
+	Policy struct {
+		File          string `env:"POLICY_FILE"`           // YAML or JSON license policy evaluated for each component, empty disables policies
+		ReloadSeconds int    `env:"POLICY_RELOAD_SECONDS"` // How often the policy file is checked for changes, 0 disables hot reload (default 30)
+	}
+	Curation struct {
+		File string `env:"CURATION_FILE"` // YAML or JSON license overrides taking precedence over the knowledge base, empty disables curations
+	}
+	Audit struct {
+		File string `env:"AUDIT_FILE"` // Append-only log of policy and curation changes, empty disables auditing
+	}
+	Watchlist struct {
+		File                  string `env:"WATCHLIST_FILE"`                    // JSON file keeping the watched components and their last known licenses, empty disables watchlists
+		IntervalMinutes       int    `env:"WATCHLIST_INTERVAL_MINUTES"`        // How often the watched components are re-resolved, 0 leaves checks to the admin API and SIGHUP (default 60)
+		WebhookURL            string `env:"WATCHLIST_WEBHOOK_URL"`             // URL receiving a POST for each license change of a watched component, empty only logs changes
+		WebhookSecret         string `env:"WATCHLIST_WEBHOOK_SECRET"`          // Secret signing the webhook bodies with HMAC-SHA256, empty disables signing
+		WebhookTimeoutSeconds int    `env:"WATCHLIST_WEBHOOK_TIMEOUT_SECONDS"` // Max duration of a webhook POST (default 10)
 	}
 }
 
@@ -121,6 +164,20 @@ func setServerConfigDefaults(cfg *ServerConfig) {
 	cfg.Telemetry.Enabled = false
 	cfg.Telemetry.OltpExporter = "0.0.0.0:4317" // Default OTEL OLTP gRPC Exporter endpoint
 	cfg.Cache.SPDXRefreshHours = 24
+	cfg.Cache.StatsRefreshHours = 24
+	cfg.Cache.ResultSize = 10000
+	cfg.Cache.ResultTTLMinutes = 60
+	cfg.Cache.NegativeTTLMinutes = 5
+	cfg.Cache.SPDXRefreshTimeoutSeconds = 30
+	cfg.Cache.SPDXRetryBackoffSeconds = 5
+	cfg.Cache.SPDXRetryMaxBackoffSeconds = 300
+	cfg.Health.PingTimeoutSeconds = 2
+	cfg.Health.MaxCacheAgeHours = 48
+	cfg.Health.CheckIntervalSeconds = 10
+	cfg.Policy.ReloadSeconds = 30
+	cfg.Watchlist.IntervalMinutes = 60
+	cfg.Watchlist.WebhookTimeoutSeconds = 10
 	cfg.Lookup.SourcePriority = []int16{0, 31, 32, 33, 34, 35, 3, 5}
 	cfg.Lookup.MaxWorkers = 5
+	cfg.Lookup.SharedTimeoutSeconds = 60
 }
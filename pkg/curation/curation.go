@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package curation holds local license overrides for components whose knowledge base licenses are wrong,
+// loaded from a YAML or JSON curation file.
+package curation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
+	"scanoss.com/licenses/pkg/license"
+)
+
+// File is the content of a curation file.
+type File struct {
+	// Overrides are tried in order; the first one matching a component sets its licenses.
+	Overrides []Override `json:"overrides" yaml:"overrides"`
+}
+
+// Override sets the license expression of a component, for all its versions or a range of them.
+type Override struct {
+	// Purl is the component purl, without a version (e.g. "pkg:github/acme/openssl-fork").
+	Purl string `json:"purl" yaml:"purl"`
+	// Versions is a semver constraint (e.g. ">=1.2.0 <2.0.0") on the component version. Empty matches any version,
+	// including components whose version could not be resolved.
+	Versions string `json:"versions,omitempty" yaml:"versions,omitempty"`
+	// License is the SPDX expression replacing the licenses of the knowledge base.
+	License string `json:"license" yaml:"license"`
+	// Comment records why the override exists.
+	Comment string `json:"comment,omitempty" yaml:"comment,omitempty"`
+
+	constraints *semver.Constraints
+	expression  *license.Expression
+}
+
+// Load reads and validates a curation file. Files ending in .json are read as JSON, anything else as YAML.
+func Load(file string) (*File, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var f File
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&f)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse curation file %s: %w", file, err)
+	}
+	var errs []error
+	for i := range f.Overrides {
+		if err = f.Overrides[i].validate(); err != nil {
+			errs = append(errs, fmt.Errorf("override %d: %w", i+1, err))
+		}
+	}
+	if err = errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid curation file %s: %w", file, err)
+	}
+	return &f, nil
+}
+
+// validate checks the override and parses its version constraint and license expression.
+func (o *Override) validate() error {
+	var errs []error
+	if len(o.Purl) == 0 {
+		errs = append(errs, errors.New("missing purl"))
+	} else if purlHasVersion(o.Purl) {
+		errs = append(errs, fmt.Errorf("purl %q must not carry a version, use versions instead", o.Purl))
+	}
+	if len(o.Versions) > 0 {
+		c, err := semver.NewConstraint(o.Versions)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("bad version range %q: %w", o.Versions, err))
+		}
+		o.constraints = c
+	}
+	if len(o.License) == 0 {
+		errs = append(errs, errors.New("missing license"))
+	} else {
+		expr, err := license.ParseExpression(o.License)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("bad license expression %q: %w", o.License, err))
+		}
+		o.expression = expr
+	}
+	return errors.Join(errs...)
+}
+
+// purlHasVersion reports whether purl carries a version: an "@" in its name, the segment after the last "/".
+// An "@" before it is part of a namespace, such as the scope of pkg:npm/@angular/core.
+func purlHasVersion(purl string) bool {
+	purl, _, _ = strings.Cut(purl, "#")
+	purl, _, _ = strings.Cut(purl, "?")
+	return strings.Contains(purl[strings.LastIndex(purl, "/")+1:], "@")
+}
+
+// Expression returns the parsed license expression of the override.
+func (o *Override) Expression() *license.Expression {
+	return o.expression
+}
+
+// matches reports whether the override applies to version of its purl.
+func (o *Override) matches(version string) bool {
+	if o.constraints == nil {
+		return true
+	}
+	v, err := semver.NewVersion(version)
+	return err == nil && o.constraints.Check(v)
+}
+
+// Lookup returns the first override for the component purl at version, which may be empty when unknown.
+func (f *File) Lookup(purl, version string) (*Override, bool) {
+	for i := range f.Overrides {
+		o := &f.Overrides[i]
+		if o.Purl == purl && o.matches(version) {
+			return o, true
+		}
+	}
+	return nil, false
+}
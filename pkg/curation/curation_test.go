@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package curation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+const testCurations = `
+overrides:
+  - purl: pkg:github/acme/openssl-fork
+    versions: ">=3.0.0"
+    license: Apache-2.0
+    comment: Rebased on OpenSSL 3
+  - purl: pkg:github/acme/openssl-fork
+    license: OpenSSL
+  - purl: pkg:npm/dual
+    license: MIT OR Apache-2.0
+`
+
+// writeCurations writes content to a curation file with the given name in a temporary directory.
+func writeCurations(t *testing.T, name, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write curation file: %v", err)
+	}
+	return file
+}
+
+func TestLoad(t *testing.T) {
+	f, err := Load(writeCurations(t, "curations.yaml", testCurations))
+	if assert.NoError(t, err) {
+		assert.Len(t, f.Overrides, 3)
+		assert.Equal(t, []string{"MIT", "Apache-2.0"}, f.Overrides[2].Expression().Licenses())
+	}
+	_, err = Load(writeCurations(t, "curations.json", `{"overrides": [{"purl": "pkg:npm/x", "license": "MIT"}]}`))
+	assert.NoError(t, err)
+	_, err = Load(writeCurations(t, "curations.yaml", "overrides:\n  - purl: pkg:npm/@scope/name\n    license: MIT\n"))
+	assert.NoError(t, err, "the scope of an npm purl is not a version")
+
+	invalid := map[string]string{
+		"unknown field":       "overrides:\n  - purl: pkg:npm/x\n    licence: MIT\n",
+		"missing purl":        "overrides:\n  - license: MIT\n",
+		"versioned purl":      "overrides:\n  - purl: pkg:npm/x@1.0.0\n    license: MIT\n",
+		"versioned scoped":    "overrides:\n  - purl: pkg:npm/@scope/name@1.0.0\n    license: MIT\n",
+		"bad version range":   "overrides:\n  - purl: pkg:npm/x\n    versions: nope\n    license: MIT\n",
+		"missing license":     "overrides:\n  - purl: pkg:npm/x\n",
+		"bad expression":      "overrides:\n  - purl: pkg:npm/x\n    license: MIT AND (\n",
+		"not a curation file": "- just\n- a list\n",
+	}
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(writeCurations(t, "curations.yml", content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFile_Lookup(t *testing.T) {
+	f, err := Load(writeCurations(t, "curations.yaml", testCurations))
+	if err != nil {
+		t.Fatalf("failed to load the test curations: %v", err)
+	}
+	tests := []struct {
+		name    string
+		purl    string
+		version string
+		license string
+	}{
+		{name: "version in range", purl: "pkg:github/acme/openssl-fork", version: "3.1.0", license: "Apache-2.0"},
+		{name: "falls through to any version", purl: "pkg:github/acme/openssl-fork", version: "1.1.1", license: "OpenSSL"},
+		{name: "unknown version", purl: "pkg:github/acme/openssl-fork", license: "OpenSSL"},
+		{name: "other purl", purl: "pkg:github/openssl/openssl", version: "3.1.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, ok := f.Lookup(tt.purl, tt.version)
+			assert.Equal(t, len(tt.license) > 0, ok)
+			if ok {
+				assert.Equal(t, tt.license, o.License)
+			}
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	file := writeCurations(t, "curations.yaml", testCurations)
+	store := NewStore(file, zap.NewNop().Sugar())
+	assert.Equal(t, 0, store.Generation())
+	assert.NoError(t, store.Refresh(ctx))
+	assert.Equal(t, 1, store.Generation())
+	assert.Equal(t, "curations", store.Name())
+	assert.Equal(t, 3, store.Status().Size)
+	overrides, ok := store.Entry("pkg:github/acme/openssl-fork")
+	if assert.True(t, ok) {
+		assert.Len(t, overrides, 2)
+	}
+	o, ok := store.Lookup("pkg:npm/dual", "1.0.0")
+	if assert.True(t, ok) {
+		assert.Equal(t, "MIT OR Apache-2.0", o.License)
+	}
+
+	t.Run("an invalid file keeps the previous curations", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(file, []byte("overrides: [oops"), 0o600))
+		assert.Error(t, store.Refresh(ctx))
+		assert.NotEmpty(t, store.Status().LastError)
+		assert.Equal(t, 1, store.Generation())
+		_, ok := store.Lookup("pkg:npm/dual", "1.0.0")
+		assert.True(t, ok)
+	})
+
+	t.Run("no curations", func(t *testing.T) {
+		var disabled *Store
+		assert.Nil(t, NewStore("", zap.NewNop().Sugar()))
+		_, ok := disabled.Lookup("pkg:npm/dual", "1.0.0")
+		assert.False(t, ok)
+		assert.Equal(t, 0, disabled.Generation())
+	})
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package curation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/audit"
+	"scanoss.com/licenses/pkg/cache"
+)
+
+// Store holds the curation overrides loaded from a file. They are reloaded along with the caches
+// (on SIGHUP or through the admin API). A nil *Store is valid and means no curations are configured.
+type Store struct {
+	file   string
+	logger *zap.SugaredLogger
+	audit  *audit.Log
+
+	mu          sync.RWMutex
+	curations   *File
+	generation  int
+	lastRefresh time.Time
+	lastError   string
+}
+
+// NewStore creates a store for the curations in file. It returns nil when file is empty (no curations).
+func NewStore(file string, logger *zap.SugaredLogger) *Store {
+	if len(file) == 0 {
+		return nil
+	}
+	return &Store{file: file, logger: logger}
+}
+
+// Name returns the name of the store in the admin API.
+func (s *Store) Name() string {
+	return "curations"
+}
+
+// UseAudit records each change of the curations in the given audit log.
+func (s *Store) UseAudit(log *audit.Log) {
+	s.audit = log
+}
+
+// Refresh reloads the curation file. When the file is invalid, or its changes can't be recorded in the audit log,
+// the curations loaded before are kept.
+func (s *Store) Refresh(ctx context.Context) error {
+	f, err := Load(s.file)
+	// Record and swap under the same lock, so concurrent refreshes log their changes in the order they apply them.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		err = s.audit.Record(ctx, s.Name(), f)
+	}
+	if err != nil {
+		s.lastError = err.Error()
+		return err
+	}
+	s.curations = f
+	s.generation++
+	s.lastRefresh = time.Now()
+	s.lastError = ""
+	s.logger.Infof("Loaded %d curation overrides from %s", len(f.Overrides), s.file)
+	return nil
+}
+
+// Status returns the number of overrides loaded and the outcome of the last reload.
+func (s *Store) Status() cache.Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	status := cache.Status{Name: s.Name(), LastRefresh: s.lastRefresh, LastError: s.lastError}
+	if s.curations != nil {
+		status.Size = len(s.curations.Overrides)
+	}
+	return status
+}
+
+// Entry returns the overrides for the given purl.
+func (s *Store) Entry(purl string) (any, bool) {
+	f, _ := s.current()
+	if f == nil {
+		return nil, false
+	}
+	var overrides []Override
+	for _, o := range f.Overrides {
+		if o.Purl == purl {
+			overrides = append(overrides, o)
+		}
+	}
+	return overrides, len(overrides) > 0
+}
+
+// Lookup returns the override for the component purl at version, which may be empty when unknown.
+func (s *Store) Lookup(purl, version string) (*Override, bool) {
+	f, _ := s.current()
+	if f == nil {
+		return nil, false
+	}
+	return f.Lookup(purl, version)
+}
+
+// Generation counts the successful loads of the curation file, so results resolved with
+// older curations can be told apart. It is zero when no curations are configured.
+func (s *Store) Generation() int {
+	_, generation := s.current()
+	return generation
+}
+
+// current returns the loaded curations and their generation, or nil when there are none.
+func (s *Store) current() (*File, int) {
+	if s == nil {
+		return nil, 0
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.curations, s.generation
+}
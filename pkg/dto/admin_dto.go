@@ -0,0 +1,25 @@
+package dto
+
+// CacheStatusDTO describes a cache. LastRefresh and AgeSeconds are omitted when the cache was never loaded,
+// LastError when its last refresh succeeded.
+type CacheStatusDTO struct {
+	Name        string `json:"name"`
+	Size        int    `json:"size"`
+	LastRefresh string `json:"last_refresh,omitempty"`
+	AgeSeconds  int64  `json:"age_seconds,omitempty"`
+	LastError   string `json:"last_error,omitempty"`
+}
+
+// CacheStatusResponseDTO is the response of the admin endpoints listing and refreshing caches.
+type CacheStatusResponseDTO struct {
+	Caches []CacheStatusDTO `json:"caches"`
+	Status StatusDTO        `json:"status"`
+}
+
+// CacheEntryResponseDTO is the response of the admin endpoint dumping a cache entry.
+type CacheEntryResponseDTO struct {
+	Cache  string    `json:"cache"`
+	Key    string    `json:"key"`
+	Entry  any       `json:"entry,omitempty"`
+	Status StatusDTO `json:"status"`
+}
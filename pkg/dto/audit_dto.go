@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLogRequestDTO holds the query parameters of the audit log REST endpoint.
+type AuditLogRequestDTO struct {
+	Subject  string
+	Actor    string
+	Since    time.Time
+	Until    time.Time
+	AfterSeq int64
+	Limit    int
+}
+
+// AuditEntryDTO is a change recorded in the audit log, as written to the log file.
+type AuditEntryDTO struct {
+	Seq      int64           `json:"seq"`
+	Time     string          `json:"time"`
+	Subject  string          `json:"subject"`
+	Action   string          `json:"action"`
+	Actor    string          `json:"actor"`
+	Reason   string          `json:"reason,omitempty"`
+	Before   json.RawMessage `json:"before"`
+	After    json.RawMessage `json:"after"`
+	PrevHash string          `json:"prev_hash"`
+	Hash     string          `json:"hash"`
+}
+
+// AuditLogResponseDTO is the response of the audit log REST endpoint.
+// NextAfterSeq, the after_seq of the next page, is omitted on the last page.
+type AuditLogResponseDTO struct {
+	Entries      []AuditEntryDTO `json:"entries"`
+	NextAfterSeq int64           `json:"next_after_seq,omitempty"`
+	Status       StatusDTO       `json:"status"`
+}
+
+// AuditVerifyResponseDTO is the response of the audit log verification REST endpoint.
+type AuditVerifyResponseDTO struct {
+	Valid    bool      `json:"valid"`
+	Entries  int       `json:"entries"`
+	LastHash string    `json:"last_hash,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	Status   StatusDTO `json:"status"`
+}
@@ -0,0 +1,169 @@
+package dto
+
+import (
+	"github.com/scanoss/go-component-helper/componenthelper"
+	pb "github.com/scanoss/papi/api/licensesv2"
+)
+
+// ComponentsExtendedRequestDTO is the body accepted by the extended component licenses REST endpoint.
+type ComponentsExtendedRequestDTO struct {
+	Components []componenthelper.ComponentDTO `json:"components"`
+	// Preferences is a ranked list of acceptable licenses used to elect one option of an OR expression.
+	// When empty, Lookup.ElectionPreferences from the server config is used.
+	Preferences []string `json:"preferences,omitempty"`
+	// Explain attaches the resolution trace to each component. Explained lookups bypass the result cache.
+	Explain bool `json:"explain,omitempty"`
+	// AsOf (YYYY-MM-DD) answers as the knowledge base stood on that date, using only the license rows dated
+	// on or before it. As of lookups bypass the result cache.
+	AsOf string `json:"as_of,omitempty"`
+}
+
+// ElectedLicenseDTO describes the license set chosen from a component's OR expression.
+type ElectedLicenseDTO struct {
+	Licenses   []string `json:"licenses"`
+	Expression string   `json:"expression"`
+	Reason     string   `json:"reason"`
+}
+
+// ComponentElectionDTO is the license elected for a component of a gRPC lookup. The papi messages have no field
+// for it, so it is sent in the x-elected-license trailer.
+type ComponentElectionDTO struct {
+	Purl        string `json:"purl"`
+	Requirement string `json:"requirement,omitempty"`
+	ElectedLicenseDTO
+}
+
+// ComponentLicenseExtendedDTO is a ComponentLicenseInfo plus the fields the papi message does not carry.
+type ComponentLicenseExtendedDTO struct {
+	*pb.ComponentLicenseInfo
+	Source         *LicenseSourceDTO   `json:"source,omitempty"`
+	ElectedLicense *ElectedLicenseDTO  `json:"elected_license,omitempty"`
+	Policy         *PolicyDecisionDTO  `json:"policy,omitempty"`
+	Trace          *ResolutionTraceDTO `json:"trace,omitempty"`
+}
+
+// Types of license source.
+const (
+	LicenseSourceKnowledgeBase = "knowledge_base"
+	LicenseSourceCuration      = "curation"
+)
+
+// LicenseSourceDTO is where the licenses of a component were taken from: a knowledge base source or a local curation.
+type LicenseSourceDTO struct {
+	Type string `json:"type"`
+	// ID and Name identify the knowledge base source, for knowledge base licenses.
+	ID   *int16 `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	// Date is the date bucket of the knowledge base rows the licenses were taken from.
+	Date string `json:"date,omitempty"`
+	// Curation is the override that set the licenses, for curated licenses.
+	Curation *CurationDTO `json:"curation,omitempty"`
+}
+
+// CurationDTO is a local curation override that set the licenses of a component.
+type CurationDTO struct {
+	Purl     string `json:"purl"`
+	Versions string `json:"versions,omitempty"`
+	License  string `json:"license"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// PolicyDecisionDTO is the decision of the license policy for a component: allow, deny or needs-review.
+type PolicyDecisionDTO struct {
+	Decision string `json:"decision"`
+	// Rule is the rule behind the decision; empty when a default decision applied.
+	Rule string `json:"rule,omitempty"`
+	// License is the license the rule matched.
+	License string `json:"license,omitempty"`
+	// Exemption is the exemption that allowed the component despite the rule.
+	Exemption *PolicyExemptionDTO        `json:"exemption,omitempty"`
+	Licenses  []PolicyLicenseDecisionDTO `json:"licenses"`
+	// Warnings report expired exemptions that would otherwise have applied.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// PolicyLicenseDecisionDTO is the decision of the license policy for one license of a component.
+type PolicyLicenseDecisionDTO struct {
+	License   string              `json:"license"`
+	Decision  string              `json:"decision"`
+	Rule      string              `json:"rule,omitempty"`
+	Exemption *PolicyExemptionDTO `json:"exemption,omitempty"`
+}
+
+// PolicyExemptionDTO is a policy exemption that allowed a component or one of its licenses.
+type PolicyExemptionDTO struct {
+	Purl          string `json:"purl"`
+	Versions      string `json:"versions,omitempty"`
+	Justification string `json:"justification"`
+	Approver      string `json:"approver"`
+	Expires       string `json:"expires"`
+}
+
+// ComponentsLicenseExtendedResponseDTO is the response of the extended component licenses REST endpoint.
+type ComponentsLicenseExtendedResponseDTO struct {
+	// AsOf echoes the as_of date of the request, if any.
+	AsOf       string                        `json:"as_of,omitempty"`
+	Components []ComponentLicenseExtendedDTO `json:"components"`
+	Status     StatusDTO                     `json:"status"`
+}
+
+// ResolutionTraceDTO records how a component's licenses were resolved, returned in explain mode.
+type ResolutionTraceDTO struct {
+	VersionResolution VersionResolutionTraceDTO `json:"version_resolution"`
+	// Steps are the license resolution steps run, in order.
+	Steps []ResolutionStepTraceDTO `json:"steps"`
+	// PickedSource is the source the component's licenses were taken from, if any.
+	PickedSource *int16 `json:"picked_source,omitempty"`
+	// Curation is the curation override that set the component's licenses, if any.
+	Curation *CurationDTO            `json:"curation,omitempty"`
+	Licenses []LicenseRecordTraceDTO `json:"licenses"`
+	// SPDXCache lists the lookups of the SPDX license cache, made for licenses of SPDX records.
+	SPDXCache []SPDXCacheLookupTraceDTO `json:"spdx_cache"`
+}
+
+// VersionResolutionTraceDTO is the outcome of resolving the requested requirement to a version.
+type VersionResolutionTraceDTO struct {
+	Requirement     string   `json:"requirement"`
+	ResolvedVersion string   `json:"resolved_version"`
+	Status          string   `json:"status"`
+	Message         string   `json:"message,omitempty"`
+	KnownVersions   []string `json:"known_versions"`
+}
+
+// ResolutionStepTraceDTO is a license resolution step: exact_version, nearest_version or unversioned.
+type ResolutionStepTraceDTO struct {
+	Step string `json:"step"`
+	// VersionsTried are the versions the step looked for licenses of, in order.
+	VersionsTried []string `json:"versions_tried,omitempty"`
+	// Rows are the purl_licenses rows the step found, grouped by source and version.
+	Rows          []SourceRowsTraceDTO `json:"rows"`
+	PickedSource  *int16               `json:"picked_source,omitempty"`
+	PickedVersion string               `json:"picked_version,omitempty"`
+	Error         string               `json:"error,omitempty"`
+}
+
+// SourceRowsTraceDTO lists the license IDs a source has for a version of the component.
+type SourceRowsTraceDTO struct {
+	SourceID   int16   `json:"source_id"`
+	SourceName string  `json:"source_name,omitempty"`
+	Version    string  `json:"version"`
+	LicenseIDs []int32 `json:"license_ids"`
+}
+
+// LicenseRecordTraceDTO is a licenses table record picked for the component, and how its SPDX string was parsed.
+type LicenseRecordTraceDTO struct {
+	LicenseID   int32    `json:"license_id"`
+	SPDX        string   `json:"spdx"`
+	IsSPDX      bool     `json:"is_spdx"`
+	Licenses    []string `json:"licenses"`
+	ParseMethod string   `json:"parse_method,omitempty"`
+	// ParseFallback is set when the SPDX string looked like an expression but was read as a legacy list.
+	ParseFallback bool   `json:"parse_fallback,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// SPDXCacheLookupTraceDTO is a lookup of a license in the SPDX license cache.
+type SPDXCacheLookupTraceDTO struct {
+	License string `json:"license"`
+	Hit     bool   `json:"hit"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+// ComponentsByLicenseRequestDTO holds the query parameters of the reverse license lookup REST endpoint.
+type ComponentsByLicenseRequestDTO struct {
+	License   string
+	PurlType  string
+	SourceIDs []int16
+	Cursor    string
+	Limit     int
+}
+
+// LicensedComponentDTO is a purl/version carrying the requested license, as recorded by one source.
+type LicensedComponentDTO struct {
+	Purl     string `json:"purl"`
+	Version  string `json:"version"`
+	Date     string `json:"date"`
+	SourceID int16  `json:"source_id"`
+	License  string `json:"license"`
+}
+
+// ComponentsByLicenseResponseDTO is the response of the reverse license lookup REST endpoint.
+// NextCursor is empty on the last page.
+type ComponentsByLicenseResponseDTO struct {
+	Components []LicensedComponentDTO `json:"components"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+	Status     StatusDTO              `json:"status"`
+}
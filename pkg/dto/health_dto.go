@@ -0,0 +1,14 @@
+package dto
+
+// HealthCheckDTO is the outcome of one health check. Status is "pass" or "fail".
+type HealthCheckDTO struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// HealthResponseDTO is the response of the liveness and readiness endpoints. Status is "pass" when every check passed.
+type HealthResponseDTO struct {
+	Status string           `json:"status"`
+	Checks []HealthCheckDTO `json:"checks"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import pb "github.com/scanoss/papi/api/licensesv2"
+
+// LicenseBatchRequestDTO is the body accepted by the batch license details REST endpoint.
+// Either (or both) of IDs and Expression may be supplied; the middleware folds the licenses
+// referenced by Expression into IDs.
+type LicenseBatchRequestDTO struct {
+	IDs        []string `json:"ids,omitempty"`
+	Expression string   `json:"expression,omitempty"`
+}
+
+// LicenseDetailsResultDTO holds the details found for one requested license ID.
+type LicenseDetailsResultDTO struct {
+	ID string `json:"id"`
+	*pb.LicenseDetails
+}
+
+// LicenseDetailsBatchResponseDTO is the response of the batch license details REST endpoint.
+type LicenseDetailsBatchResponseDTO struct {
+	Licenses []LicenseDetailsResultDTO `json:"licenses"`
+	NotFound []string                  `json:"not_found"`
+	Status   StatusDTO                 `json:"status"`
+}
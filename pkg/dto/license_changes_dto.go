@@ -0,0 +1,28 @@
+package dto
+
+// LicenseChangesRequestDTO holds the parameters of the license changes feed REST endpoint.
+type LicenseChangesRequestDTO struct {
+	// Since is the date (YYYY-MM-DD) to list changes from; the middleware also accepts an RFC 3339 timestamp.
+	Since    string `json:"since,omitempty"`
+	PurlType string `json:"purl_type,omitempty"`
+	// Purls narrows the feed to a list of watched purls (without versions).
+	Purls  []string `json:"purls,omitempty"`
+	Cursor string   `json:"cursor,omitempty"`
+	Limit  int      `json:"limit,omitempty"`
+}
+
+// LicenseChangeDTO is a purl/version whose license rows were added or changed, with the date of its latest row.
+type LicenseChangeDTO struct {
+	Purl    string `json:"purl"`
+	Version string `json:"version"`
+	Date    string `json:"date"`
+}
+
+// LicenseChangesResponseDTO is the response of the license changes feed REST endpoint.
+// NextCursor resumes the feed after this page, or, on the last page, at the latest date seen.
+type LicenseChangesResponseDTO struct {
+	Changes    []LicenseChangeDTO `json:"changes"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+	HasMore    bool               `json:"has_more"`
+	Status     StatusDTO          `json:"status"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+// LicenseIdentifyRequestDTO is the body accepted by the license identification REST endpoint.
+type LicenseIdentifyRequestDTO struct {
+	Text     string  `json:"text"`
+	Limit    int     `json:"limit,omitempty"`
+	MinScore float64 `json:"min_score,omitempty"`
+}
+
+// LicenseCandidateDTO is a license matching the submitted text, with its similarity score (0 to 1).
+type LicenseCandidateDTO struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name,omitempty"`
+	Score float64 `json:"score"`
+}
+
+// LicenseIdentifyResponseDTO is the response of the license identification REST endpoint.
+type LicenseIdentifyResponseDTO struct {
+	Candidates []LicenseCandidateDTO `json:"candidates"`
+	Status     StatusDTO             `json:"status"`
+}
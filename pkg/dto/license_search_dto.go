@@ -0,0 +1,35 @@
+package dto
+
+// LicenseSearchRequestDTO holds the query parameters of the license search REST endpoint.
+// Nil filters are not applied.
+type LicenseSearchRequestDTO struct {
+	Query       string
+	OSIApproved *bool
+	FSFLibre    *bool
+	Deprecated  *bool
+	SPDX        *bool
+	Copyleft    *bool
+	Page        int
+	PageSize    int
+}
+
+// LicenseCatalogueEntryDTO is a license of the catalogue. Copyleft is omitted when OSADL has no data for the license.
+type LicenseCatalogueEntryDTO struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name,omitempty"`
+	IsSPDX        bool     `json:"is_spdx"`
+	IsOSIApproved bool     `json:"is_osi_approved"`
+	IsFSFLibre    bool     `json:"is_fsf_libre"`
+	IsDeprecated  bool     `json:"is_deprecated"`
+	Copyleft      *bool    `json:"copyleft,omitempty"`
+	Score         *float64 `json:"score,omitempty"`
+}
+
+// LicenseSearchResponseDTO is the response of the license search REST endpoint.
+type LicenseSearchResponseDTO struct {
+	Licenses []LicenseCatalogueEntryDTO `json:"licenses"`
+	Total    int                        `json:"total"`
+	Page     int                        `json:"page"`
+	PageSize int                        `json:"page_size"`
+	Status   StatusDTO                  `json:"status"`
+}
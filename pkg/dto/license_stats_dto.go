@@ -0,0 +1,40 @@
+package dto
+
+// LicenseStatsDTO summarises the purl_licenses table. A component is a distinct purl, whatever its number
+// of versions; shares are fractions (0 to 1) of Components.
+type LicenseStatsDTO struct {
+	GeneratedAt          string             `json:"generated_at"`
+	Components           int                `json:"components"`
+	UnresolvedComponents int                `json:"unresolved_components"`
+	UnresolvedShare      float64            `json:"unresolved_share"`
+	Licenses             []LicenseCountDTO  `json:"licenses"`
+	PurlTypes            []PurlTypeCountDTO `json:"purl_types"`
+	Sources              []SourceCountDTO   `json:"sources"`
+}
+
+// LicenseCountDTO is the number of components carrying a license.
+type LicenseCountDTO struct {
+	License    string  `json:"license"`
+	Components int     `json:"components"`
+	Share      float64 `json:"share"`
+}
+
+// PurlTypeCountDTO is the number of components of a purl type.
+type PurlTypeCountDTO struct {
+	PurlType   string  `json:"purl_type"`
+	Components int     `json:"components"`
+	Share      float64 `json:"share"`
+}
+
+// SourceCountDTO is the number of components with license data from a source.
+type SourceCountDTO struct {
+	SourceID   int16   `json:"source_id"`
+	Components int     `json:"components"`
+	Share      float64 `json:"share"`
+}
+
+// LicenseStatsResponseDTO is the response of the license statistics REST endpoint.
+type LicenseStatsResponseDTO struct {
+	Stats  *LicenseStatsDTO `json:"stats,omitempty"`
+	Status StatusDTO        `json:"status"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+// LicenseTextDTO holds the text of a license as stored in the license text store.
+type LicenseTextDTO struct {
+	ID               string `json:"id"`
+	Name             string `json:"name,omitempty"`
+	Text             string `json:"text"`
+	StandardHeader   string `json:"standard_header,omitempty"`
+	StandardTemplate string `json:"standard_template,omitempty"`
+}
+
+// LicenseTextResponseDTO is the response of the license text REST endpoint.
+type LicenseTextResponseDTO struct {
+	License *LicenseTextDTO `json:"license,omitempty"`
+	Status  StatusDTO       `json:"status"`
+}
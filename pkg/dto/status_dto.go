@@ -0,0 +1,7 @@
+package dto
+
+// StatusDTO mirrors commonv2.StatusResponse for responses that are not rendered by the gRPC gateway.
+type StatusDTO struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+// WatchlistRequestDTO is the body of the admin endpoint adding components to the watchlist.
+type WatchlistRequestDTO struct {
+	Components []WatchKeyDTO `json:"components"`
+}
+
+// WatchKeyDTO identifies a watched component: a purl without a version, and an optional version requirement.
+type WatchKeyDTO struct {
+	Purl        string `json:"purl"`
+	Requirement string `json:"requirement,omitempty"`
+}
+
+// WatchDTO is a watched component with its last known licenses. Last is omitted until the component is
+// first resolved, ChangedAt until a license change is reported.
+type WatchDTO struct {
+	WatchKeyDTO
+	AddedAt   string          `json:"added_at"`
+	Last      *WatchResultDTO `json:"last,omitempty"`
+	CheckedAt string          `json:"checked_at,omitempty"`
+	ChangedAt string          `json:"changed_at,omitempty"`
+}
+
+// WatchResultDTO is the last result resolved for a watched component.
+type WatchResultDTO struct {
+	Version   string   `json:"version,omitempty"`
+	Statement string   `json:"statement,omitempty"`
+	Licenses  []string `json:"licenses"`
+	Source    string   `json:"source,omitempty"`
+	InfoCode  string   `json:"info_code,omitempty"`
+}
+
+// WatchlistResponseDTO is the response of the watchlist admin endpoints. Watches lists the whole watchlist,
+// or the components added or removed by the request.
+type WatchlistResponseDTO struct {
+	Watches []WatchDTO `json:"watches"`
+	Status  StatusDTO  `json:"status"`
+}
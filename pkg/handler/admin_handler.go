@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	common "github.com/scanoss/papi/api/commonv2"
+	"scanoss.com/licenses/pkg/audit"
+	"scanoss.com/licenses/pkg/cache"
+	"scanoss.com/licenses/pkg/dto"
+	"scanoss.com/licenses/pkg/middleware"
+	"scanoss.com/licenses/pkg/watchlist"
+)
+
+// AdminHandler serves the admin endpoints that inspect and refresh the caches, read the audit log and
+// manage the watchlist.
+type AdminHandler struct {
+	registry  *cache.Registry
+	audit     *audit.Log
+	watchlist *watchlist.Store
+}
+
+// NewAdminHandler creates a new instance of the Admin handler for the given caches.
+func NewAdminHandler(registry *cache.Registry) *AdminHandler {
+	return &AdminHandler{registry: registry}
+}
+
+// UseAudit serves the given audit log through the audit endpoints. A nil log disables them.
+func (h *AdminHandler) UseAudit(log *audit.Log) {
+	h.audit = log
+}
+
+// UseWatchlist manages the given watchlist through the watchlist endpoints. A nil store disables them.
+func (h *AdminHandler) UseWatchlist(store *watchlist.Store) {
+	h.watchlist = store
+}
+
+// ListCaches reports the size and last refresh of every cache.
+func (h *AdminHandler) ListCaches(ctx context.Context) (*dto.CacheStatusResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	return &dto.CacheStatusResponseDTO{
+		Caches: newCacheStatusDTOs(h.registry.Statuses()),
+		Status: newRESTStatus(s, common.StatusCode_SUCCESS, "Caches retrieved successfully", nil),
+	}, http.StatusOK
+}
+
+// RefreshCaches reloads the named cache, or every cache the registry refreshes together when name is empty,
+// and reports their status.
+func (h *AdminHandler) RefreshCaches(ctx context.Context, name string) (*dto.CacheStatusResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	var err error
+	var statuses []cache.Status
+	if len(name) == 0 {
+		s.Infof("Refreshing all caches on admin request")
+		err = h.registry.RefreshAll(ctx)
+		statuses = h.registry.Statuses()
+	} else {
+		c, ok := h.registry.Get(name)
+		if !ok {
+			return &dto.CacheStatusResponseDTO{
+				Caches: []dto.CacheStatusDTO{},
+				Status: newRESTStatus(s, common.StatusCode_FAILED, fmt.Sprintf("Unknown cache: %s", name), nil),
+			}, http.StatusNotFound
+		}
+		s.Infof("Refreshing cache %s on admin request", name)
+		err = c.Refresh(ctx)
+		statuses = []cache.Status{c.Status()}
+	}
+	if err != nil {
+		s.Errorf("Failed to refresh caches: %v", err)
+		return &dto.CacheStatusResponseDTO{
+			Caches: newCacheStatusDTOs(statuses),
+			Status: newRESTStatus(s, common.StatusCode_FAILED, "", err),
+		}, http.StatusInternalServerError
+	}
+	return &dto.CacheStatusResponseDTO{
+		Caches: newCacheStatusDTOs(statuses),
+		Status: newRESTStatus(s, common.StatusCode_SUCCESS, "Caches refreshed successfully", nil),
+	}, http.StatusOK
+}
+
+// GetCacheEntry dumps the entry cached under key in the named cache.
+func (h *AdminHandler) GetCacheEntry(ctx context.Context, name, key string) (*dto.CacheEntryResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	response := &dto.CacheEntryResponseDTO{Cache: name, Key: key}
+	if len(key) == 0 {
+		response.Status = newRESTStatus(s, common.StatusCode_FAILED, "No cache key supplied", nil)
+		return response, http.StatusBadRequest
+	}
+	c, ok := h.registry.Get(name)
+	if !ok {
+		response.Status = newRESTStatus(s, common.StatusCode_FAILED, fmt.Sprintf("Unknown cache: %s", name), nil)
+		return response, http.StatusNotFound
+	}
+	entry, ok := c.Entry(key)
+	if !ok {
+		response.Status = newRESTStatus(s, common.StatusCode_FAILED, fmt.Sprintf("No entry cached for key: %s", key), nil)
+		return response, http.StatusNotFound
+	}
+	response.Entry = entry
+	response.Status = newRESTStatus(s, common.StatusCode_SUCCESS, "Cache entry retrieved successfully", nil)
+	return response, http.StatusOK
+}
+
+// GetAuditLog returns the audit log entries selected by the request, oldest first.
+func (h *AdminHandler) GetAuditLog(ctx context.Context,
+	middleware middleware.Middleware[dto.AuditLogRequestDTO]) (*dto.AuditLogResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	response := &dto.AuditLogResponseDTO{Entries: []dto.AuditEntryDTO{}}
+	if h.audit == nil {
+		response.Status = newRESTStatus(s, common.StatusCode_FAILED, "No audit log configured", nil)
+		return response, http.StatusNotFound
+	}
+	request, err := middleware.Process()
+	if err != nil {
+		response.Status = newRESTStatus(s, common.StatusCode_FAILED, "", err)
+		return response, http.StatusBadRequest
+	}
+	entries := h.audit.Entries(audit.Filter{
+		Subject:  request.Subject,
+		Actor:    request.Actor,
+		Since:    request.Since,
+		Until:    request.Until,
+		AfterSeq: request.AfterSeq,
+		Limit:    request.Limit,
+	})
+	for _, e := range entries {
+		response.Entries = append(response.Entries, newAuditEntryDTO(e))
+	}
+	if len(entries) == request.Limit {
+		response.NextAfterSeq = entries[len(entries)-1].Seq
+	}
+	response.Status = newRESTStatus(s, common.StatusCode_SUCCESS, "Audit log retrieved successfully", nil)
+	return response, http.StatusOK
+}
+
+// VerifyAuditLog reads the audit log file back and checks its hash chain.
+func (h *AdminHandler) VerifyAuditLog(ctx context.Context) (*dto.AuditVerifyResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	if h.audit == nil {
+		return &dto.AuditVerifyResponseDTO{
+			Status: newRESTStatus(s, common.StatusCode_FAILED, "No audit log configured", nil),
+		}, http.StatusNotFound
+	}
+	entries, err := h.audit.VerifyFile()
+	response := &dto.AuditVerifyResponseDTO{Valid: err == nil, Entries: len(entries)}
+	if len(entries) > 0 {
+		response.LastHash = entries[len(entries)-1].Hash
+	}
+	if err != nil {
+		s.Errorf("Audit log verification failed: %v", err)
+		response.Error = err.Error()
+	}
+	response.Status = newRESTStatus(s, common.StatusCode_SUCCESS, "Audit log verified", nil)
+	return response, http.StatusOK
+}
+
+// GetWatchlist lists the watched components with their last known licenses.
+func (h *AdminHandler) GetWatchlist(ctx context.Context) (*dto.WatchlistResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	if h.watchlist == nil {
+		return &dto.WatchlistResponseDTO{
+			Watches: []dto.WatchDTO{},
+			Status:  newRESTStatus(s, common.StatusCode_FAILED, "No watchlist configured", nil),
+		}, http.StatusNotFound
+	}
+	return &dto.WatchlistResponseDTO{
+		Watches: newWatchDTOs(h.watchlist.List()),
+		Status:  newRESTStatus(s, common.StatusCode_SUCCESS, "Watchlist retrieved successfully", nil),
+	}, http.StatusOK
+}
+
+// AddWatches adds the requested components to the watchlist, skipping those already watched.
+func (h *AdminHandler) AddWatches(ctx context.Context,
+	middleware middleware.Middleware[dto.WatchlistRequestDTO]) (*dto.WatchlistResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	response := &dto.WatchlistResponseDTO{Watches: []dto.WatchDTO{}}
+	if h.watchlist == nil {
+		response.Status = newRESTStatus(s, common.StatusCode_FAILED, "No watchlist configured", nil)
+		return response, http.StatusNotFound
+	}
+	request, err := middleware.Process()
+	if err != nil {
+		response.Status = newRESTStatus(s, common.StatusCode_FAILED, "", err)
+		return response, http.StatusBadRequest
+	}
+	keys := make([]watchlist.Key, 0, len(request.Components))
+	for _, c := range request.Components {
+		key := watchlist.Key{Purl: c.Purl, Requirement: c.Requirement}
+		if err = key.Validate(); err != nil {
+			response.Status = newRESTStatus(s, common.StatusCode_FAILED, "", err)
+			return response, http.StatusBadRequest
+		}
+		keys = append(keys, key)
+	}
+	added, err := h.watchlist.Add(keys)
+	if err != nil {
+		s.Errorf("Failed to add to the watchlist: %v", err)
+		response.Status = newRESTStatus(s, common.StatusCode_FAILED, "", err)
+		return response, http.StatusInternalServerError
+	}
+	s.Infof("Added %d of %d components to the watchlist", len(added), len(keys))
+	response.Watches = newWatchDTOs(added)
+	response.Status = newRESTStatus(s, common.StatusCode_SUCCESS, fmt.Sprintf("%d components added to the watchlist", len(added)), nil)
+	return response, http.StatusOK
+}
+
+// RemoveWatch removes a component from the watchlist.
+func (h *AdminHandler) RemoveWatch(ctx context.Context, purl, requirement string) (*dto.WatchlistResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	response := &dto.WatchlistResponseDTO{Watches: []dto.WatchDTO{}}
+	if h.watchlist == nil {
+		response.Status = newRESTStatus(s, common.StatusCode_FAILED, "No watchlist configured", nil)
+		return response, http.StatusNotFound
+	}
+	key := watchlist.Key{Purl: purl, Requirement: requirement}
+	removed, err := h.watchlist.Remove(key)
+	if err != nil {
+		s.Errorf("Failed to remove %s from the watchlist: %v", key, err)
+		response.Status = newRESTStatus(s, common.StatusCode_FAILED, "", err)
+		return response, http.StatusInternalServerError
+	}
+	if !removed {
+		response.Status = newRESTStatus(s, common.StatusCode_FAILED, fmt.Sprintf("Not watched: %s", key), nil)
+		return response, http.StatusNotFound
+	}
+	response.Watches = []dto.WatchDTO{{WatchKeyDTO: dto.WatchKeyDTO{Purl: purl, Requirement: requirement}}}
+	response.Status = newRESTStatus(s, common.StatusCode_SUCCESS, "Component removed from the watchlist", nil)
+	return response, http.StatusOK
+}
+
+// newWatchDTOs converts watches into their response form.
+func newWatchDTOs(watches []watchlist.Watch) []dto.WatchDTO {
+	result := make([]dto.WatchDTO, 0, len(watches))
+	for _, w := range watches {
+		entry := dto.WatchDTO{
+			WatchKeyDTO: dto.WatchKeyDTO{Purl: w.Purl, Requirement: w.Requirement},
+			AddedAt:     w.AddedAt.UTC().Format(time.RFC3339),
+		}
+		if w.Last != nil {
+			entry.Last = &dto.WatchResultDTO{
+				Version:   w.Last.Version,
+				Statement: w.Last.Statement,
+				Licenses:  w.Last.Licenses,
+				Source:    w.Last.Source,
+				InfoCode:  w.Last.InfoCode,
+			}
+		}
+		if !w.CheckedAt.IsZero() {
+			entry.CheckedAt = w.CheckedAt.UTC().Format(time.RFC3339)
+		}
+		if !w.ChangedAt.IsZero() {
+			entry.ChangedAt = w.ChangedAt.UTC().Format(time.RFC3339)
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// newAuditEntryDTO converts an audit log entry into its response form.
+func newAuditEntryDTO(e audit.Entry) dto.AuditEntryDTO {
+	return dto.AuditEntryDTO{
+		Seq:      e.Seq,
+		Time:     e.Time.UTC().Format(time.RFC3339Nano),
+		Subject:  e.Subject,
+		Action:   e.Action,
+		Actor:    e.Actor,
+		Reason:   e.Reason,
+		Before:   e.Before,
+		After:    e.After,
+		PrevHash: e.PrevHash,
+		Hash:     e.Hash,
+	}
+}
+
+// newCacheStatusDTOs converts cache statuses into their response form.
+func newCacheStatusDTOs(statuses []cache.Status) []dto.CacheStatusDTO {
+	result := make([]dto.CacheStatusDTO, 0, len(statuses))
+	for _, st := range statuses {
+		entry := dto.CacheStatusDTO{Name: st.Name, Size: st.Size, LastError: st.LastError}
+		if !st.LastRefresh.IsZero() {
+			entry.LastRefresh = st.LastRefresh.UTC().Format(time.RFC3339)
+			entry.AgeSeconds = int64(st.Age().Seconds())
+		}
+		result = append(result, entry)
+	}
+	return result
+}
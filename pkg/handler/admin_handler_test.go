@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	common "github.com/scanoss/papi/api/commonv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"scanoss.com/licenses/pkg/audit"
+	"scanoss.com/licenses/pkg/cache"
+	"scanoss.com/licenses/pkg/middleware"
+	"scanoss.com/licenses/pkg/watchlist"
+)
+
+// fakeAdminCache is a minimal cache.Reloadable for exercising the admin handler.
+type fakeAdminCache struct {
+	name        string
+	err         error
+	lastRefresh time.Time
+}
+
+func (f *fakeAdminCache) Name() string { return f.name }
+func (f *fakeAdminCache) Refresh(_ context.Context) error {
+	if f.err == nil {
+		f.lastRefresh = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+	return f.err
+}
+func (f *fakeAdminCache) Status() cache.Status {
+	status := cache.Status{Name: f.name, Size: 1, LastRefresh: f.lastRefresh}
+	if f.err != nil {
+		status.LastError = f.err.Error()
+	}
+	return status
+}
+func (f *fakeAdminCache) Entry(key string) (any, bool) {
+	if key != "MIT" {
+		return nil, false
+	}
+	return map[string]string{"license": key}, true
+}
+
+func TestAdminHandler(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+
+	healthy := &fakeAdminCache{name: "spdx-licenses"}
+	broken := &fakeAdminCache{name: "license-stats", err: errors.New("query failed")}
+	h := NewAdminHandler(cache.NewRegistry(healthy, broken))
+
+	list, code := h.ListCaches(ctx)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, list.Caches, 2)
+	assert.Empty(t, list.Caches[0].LastRefresh)
+
+	refreshed, code := h.RefreshCaches(ctx, "spdx-licenses")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, common.StatusCode_SUCCESS.String(), refreshed.Status.Status)
+	assert.Len(t, refreshed.Caches, 1)
+	assert.Equal(t, "2026-01-02T03:04:05Z", refreshed.Caches[0].LastRefresh)
+
+	_, code = h.RefreshCaches(ctx, "unknown")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	all, code := h.RefreshCaches(ctx, "")
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Equal(t, common.StatusCode_FAILED.String(), all.Status.Status)
+	assert.Len(t, all.Caches, 2)
+	assert.Equal(t, "query failed", all.Caches[1].LastError)
+
+	entry, code := h.GetCacheEntry(ctx, "spdx-licenses", "MIT")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]string{"license": "MIT"}, entry.Entry)
+
+	_, code = h.GetCacheEntry(ctx, "spdx-licenses", "GPL-2.0-only")
+	assert.Equal(t, http.StatusNotFound, code)
+	_, code = h.GetCacheEntry(ctx, "unknown", "MIT")
+	assert.Equal(t, http.StatusNotFound, code)
+	_, code = h.GetCacheEntry(ctx, "spdx-licenses", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestAdminHandler_AuditLog(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	h := NewAdminHandler(cache.NewRegistry())
+
+	_, code := h.GetAuditLog(ctx, middleware.NewAuditLogMiddleware(url.Values{}, ctx))
+	assert.Equal(t, http.StatusNotFound, code, "no audit log is configured")
+	_, code = h.VerifyAuditLog(ctx)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	log, err := audit.Open(filepath.Join(t.TempDir(), "audit.jsonl"))
+	if err != nil {
+		t.Fatalf("failed to open the audit log: %v", err)
+	}
+	for _, d := range []string{"allow", "needs-review", "deny"} {
+		assert.NoError(t, log.Record(audit.WithActor(ctx, "jane", ""), "policy", map[string]string{"default": d}))
+	}
+	h.UseAudit(log)
+
+	page, code := h.GetAuditLog(ctx, middleware.NewAuditLogMiddleware(url.Values{"limit": {"2"}}, ctx))
+	assert.Equal(t, http.StatusOK, code)
+	if assert.Len(t, page.Entries, 2) {
+		assert.Equal(t, "jane", page.Entries[0].Actor)
+		assert.Equal(t, page.Entries[0].Hash, page.Entries[1].PrevHash)
+	}
+	assert.Equal(t, int64(2), page.NextAfterSeq)
+	page, _ = h.GetAuditLog(ctx, middleware.NewAuditLogMiddleware(url.Values{"after_seq": {"2"}, "limit": {"2"}}, ctx))
+	assert.Len(t, page.Entries, 1)
+	assert.Zero(t, page.NextAfterSeq, "the last page has no next page")
+	_, code = h.GetAuditLog(ctx, middleware.NewAuditLogMiddleware(url.Values{"since": {"yesterday"}}, ctx))
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	verified, code := h.VerifyAuditLog(ctx)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, verified.Valid)
+	assert.Equal(t, 3, verified.Entries)
+	assert.NotEmpty(t, verified.LastHash)
+}
+
+func TestAdminHandler_Watchlist(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	h := NewAdminHandler(cache.NewRegistry())
+	body := `{"components":[{"purl":"pkg:npm/express","requirement":"^4.18"},{"purl":"pkg:npm/lodash"}]}`
+
+	_, code := h.GetWatchlist(ctx)
+	assert.Equal(t, http.StatusNotFound, code, "no watchlist is configured")
+	_, code = h.AddWatches(ctx, middleware.NewWatchlistMiddleware(strings.NewReader(body), ctx))
+	assert.Equal(t, http.StatusNotFound, code)
+
+	store, err := watchlist.Open(filepath.Join(t.TempDir(), "watchlist.json"))
+	if err != nil {
+		t.Fatalf("failed to open the watchlist: %v", err)
+	}
+	h.UseWatchlist(store)
+
+	added, code := h.AddWatches(ctx, middleware.NewWatchlistMiddleware(strings.NewReader(body), ctx))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, added.Watches, 2)
+	added, code = h.AddWatches(ctx, middleware.NewWatchlistMiddleware(strings.NewReader(body), ctx))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, added.Watches, "watched components are not added again")
+	_, code = h.AddWatches(ctx, middleware.NewWatchlistMiddleware(strings.NewReader(`{"components":[{"purl":"pkg:npm/a@1.0.0"}]}`), ctx))
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	list, code := h.GetWatchlist(ctx)
+	assert.Equal(t, http.StatusOK, code)
+	if assert.Len(t, list.Watches, 2) {
+		assert.Equal(t, "pkg:npm/express", list.Watches[0].Purl)
+		assert.Equal(t, "^4.18", list.Watches[0].Requirement)
+		assert.NotEmpty(t, list.Watches[0].AddedAt)
+		assert.Nil(t, list.Watches[0].Last, "components are not resolved until the next check")
+	}
+
+	_, code = h.RemoveWatch(ctx, "pkg:npm/express", "")
+	assert.Equal(t, http.StatusNotFound, code, "the requirement is part of the watch")
+	_, code = h.RemoveWatch(ctx, "pkg:npm/express", "^4.18")
+	assert.Equal(t, http.StatusOK, code)
+	list, _ = h.GetWatchlist(ctx)
+	assert.Len(t, list.Watches, 1)
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"scanoss.com/licenses/pkg/cache"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/dto"
+	"scanoss.com/licenses/pkg/usecase"
+)
+
+// HealthHandler serves the liveness and readiness probes.
+type HealthHandler struct {
+	healthUseCase *usecase.HealthUseCase
+}
+
+// NewHealthHandler creates a new instance of the Health handler.
+func NewHealthHandler(config *myconfig.ServerConfig, db *sqlx.DB, spdxCache cache.Reloadable) *HealthHandler {
+	return &HealthHandler{healthUseCase: usecase.NewHealthUseCase(config, db, spdxCache)}
+}
+
+// Live reports that the process is up and able to serve requests. It deliberately checks no dependency,
+// so an unreachable database makes the service not ready rather than getting it restarted.
+func (h *HealthHandler) Live(_ context.Context) (*dto.HealthResponseDTO, int) {
+	return &dto.HealthResponseDTO{Status: usecase.HealthPass, Checks: []dto.HealthCheckDTO{}}, http.StatusOK
+}
+
+// Ready reports whether the service can answer lookups, with the outcome of each readiness check.
+func (h *HealthHandler) Ready(ctx context.Context) (*dto.HealthResponseDTO, int) {
+	checks, ready := h.healthUseCase.Readiness(ctx)
+	if !ready {
+		s := ctxzap.Extract(ctx).Sugar()
+		for _, c := range checks {
+			if c.Status != usecase.HealthPass {
+				s.Warnf("Readiness check %s failed: %s", c.Name, c.Message)
+			}
+		}
+		return &dto.HealthResponseDTO{Status: usecase.HealthFail, Checks: checks}, http.StatusServiceUnavailable
+	}
+	return &dto.HealthResponseDTO{Status: usecase.HealthPass, Checks: checks}, http.StatusOK
+}
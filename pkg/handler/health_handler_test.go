@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	myconfig "scanoss.com/licenses/pkg/config"
+)
+
+func TestHealthHandler(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	defer db.Close()
+	config := &myconfig.ServerConfig{}
+	config.Health.MaxCacheAgeHours = 48
+	spdxCache := &fakeAdminCache{name: "spdx-licenses"}
+	h := NewHealthHandler(config, db, spdxCache)
+
+	live, code := h.Live(ctx)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "pass", live.Status)
+
+	// The fake cache reports a size, but was never refreshed.
+	ready, code := h.Ready(ctx)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "fail", ready.Status)
+	assert.Len(t, ready.Checks, 3)
+
+	spdxCache.lastRefresh = time.Now()
+	ready, code = h.Ready(ctx)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "pass", ready.Status)
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -16,10 +17,13 @@ import (
 	"google.golang.org/grpc/metadata"
 	"scanoss.com/licenses/pkg/cache"
 	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/curation"
 	"scanoss.com/licenses/pkg/dto"
 	"scanoss.com/licenses/pkg/helpers"
 	"scanoss.com/licenses/pkg/middleware"
+	"scanoss.com/licenses/pkg/policy"
 	"scanoss.com/licenses/pkg/usecase"
+	"scanoss.com/licenses/pkg/watchlist"
 )
 
 type LicenseHandler struct {
@@ -28,10 +32,11 @@ type LicenseHandler struct {
 }
 
 // NewLicenseHandler creates a new instance of License handler.
-func NewLicenseHandler(config *myconfig.ServerConfig, db *sqlx.DB, spdxCache cache.SPDXLicenseCacheInterface) *LicenseHandler {
+func NewLicenseHandler(config *myconfig.ServerConfig, db *sqlx.DB, spdxCache cache.SPDXLicenseCacheInterface,
+	detailsCache cache.LicenseDetailsCacheInterface, statsCache cache.LicenseStatsCacheInterface) *LicenseHandler {
 	return &LicenseHandler{
 		config:         config,
-		licenseUseCase: usecase.NewLicenseUseCase(config, db, spdxCache),
+		licenseUseCase: usecase.NewLicenseUseCase(config, db, spdxCache, detailsCache, statsCache),
 	}
 }
 
@@ -52,6 +57,28 @@ func (h *LicenseHandler) getResponseStatus(s *zap.SugaredLogger, ctx context.Con
 	return &statusResp
 }
 
+// setElectedLicenseTrailer sends each elected license as a JSON value of the x-elected-license trailer,
+// as the papi component messages have no field for it.
+func setElectedLicenseTrailer(s *zap.SugaredLogger, ctx context.Context, elections []dto.ComponentElectionDTO) {
+	if len(elections) == 0 {
+		return
+	}
+	values := make([]string, 0, len(elections))
+	for _, e := range elections {
+		value, err := json.Marshal(e)
+		if err != nil {
+			s.Debugf("error encoding the elected license of %s: %v", e.Purl, err)
+			continue
+		}
+		values = append(values, string(value))
+	}
+	md := metadata.MD{}
+	md.Append("x-elected-license", values...)
+	if err := grpc.SetTrailer(ctx, md); err != nil {
+		s.Debugf("error setting x-elected-license to trailer: %v\n", err)
+	}
+}
+
 func (h *LicenseHandler) GetComponentLicense(ctx context.Context, middleware middleware.Middleware[componenthelper.ComponentDTO]) (*pb.ComponentLicenseResponse, error) {
 	s := ctxzap.Extract(ctx).Sugar()
 
@@ -63,13 +90,14 @@ func (h *LicenseHandler) GetComponentLicense(ctx context.Context, middleware mid
 		}, nil
 	}
 
-	componentLicenses, ucErr := h.licenseUseCase.GetComponentLicense(ctx, componentDTO)
+	componentLicenses, elections, ucErr := h.licenseUseCase.GetComponentLicense(ctx, componentDTO)
 	if ucErr != nil {
 		return &pb.ComponentLicenseResponse{
 			Status:    h.getResponseStatus(s, ctx, ucErr.Status, ucErr.Code, "", ucErr.Error),
 			Component: &pb.ComponentLicenseInfo{},
 		}, nil
 	}
+	setElectedLicenseTrailer(s, ctx, elections)
 	return &pb.ComponentLicenseResponse{
 		Status:    h.getResponseStatus(s, ctx, common.StatusCode_SUCCESS, http.StatusOK, "License retrieved successfully", nil),
 		Component: componentLicenses,
@@ -87,13 +115,14 @@ func (h *LicenseHandler) GetComponentsLicense(ctx context.Context, middleware mi
 		}, nil
 	}
 
-	componentLicenses, ucErr := h.licenseUseCase.GetComponentsLicense(ctx, componentsDTO)
+	componentLicenses, elections, ucErr := h.licenseUseCase.GetComponentsLicenseElected(ctx, componentsDTO)
 	if ucErr != nil {
 		return &pb.ComponentsLicenseResponse{
 			Status:     h.getResponseStatus(s, ctx, ucErr.Status, ucErr.Code, "", ucErr.Error),
 			Components: []*pb.ComponentLicenseInfo{},
 		}, nil
 	}
+	setElectedLicenseTrailer(s, ctx, elections)
 	return &pb.ComponentsLicenseResponse{
 		Status:     h.getResponseStatus(s, ctx, common.StatusCode_SUCCESS, http.StatusOK, "Licenses retrieved successfully", nil),
 		Components: componentLicenses,
@@ -125,3 +154,235 @@ func (h *LicenseHandler) GetDetails(ctx context.Context, middleware middleware.M
 		License: &licenseDetail,
 	}, err
 }
+
+// getRESTResponseStatus builds the status block for responses served directly over REST,
+// where there is no gRPC trailer to carry the HTTP code.
+func (h *LicenseHandler) getRESTResponseStatus(s *zap.SugaredLogger, gRPCStatusCode common.StatusCode, msg string, err error) dto.StatusDTO {
+	return newRESTStatus(s, gRPCStatusCode, msg, err)
+}
+
+// newRESTStatus builds the status of a REST-only response; err, when set, replaces msg.
+func newRESTStatus(s *zap.SugaredLogger, gRPCStatusCode common.StatusCode, msg string, err error) dto.StatusDTO {
+	message := msg
+	if err != nil {
+		message = err.Error()
+	}
+	s.Debugf(message)
+	return dto.StatusDTO{Status: gRPCStatusCode.String(), Message: message}
+}
+
+// GetComponentsLicenseExtended resolves component licenses including the REST-only extension fields.
+func (h *LicenseHandler) GetComponentsLicenseExtended(ctx context.Context,
+	middleware middleware.Middleware[dto.ComponentsExtendedRequestDTO]) (*dto.ComponentsLicenseExtendedResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+
+	request, err := middleware.Process()
+	if err != nil {
+		return &dto.ComponentsLicenseExtendedResponseDTO{
+			Status:     h.getRESTResponseStatus(s, common.StatusCode_FAILED, "", err),
+			Components: []dto.ComponentLicenseExtendedDTO{},
+		}, http.StatusBadRequest
+	}
+
+	componentLicenses, ucErr := h.licenseUseCase.GetComponentsLicenseExtended(ctx, request)
+	if ucErr != nil {
+		return &dto.ComponentsLicenseExtendedResponseDTO{
+			Status:     h.getRESTResponseStatus(s, ucErr.Status, "", ucErr.Error),
+			Components: []dto.ComponentLicenseExtendedDTO{},
+		}, ucErr.Code
+	}
+	return &dto.ComponentsLicenseExtendedResponseDTO{
+		Status:     h.getRESTResponseStatus(s, common.StatusCode_SUCCESS, "Licenses retrieved successfully", nil),
+		AsOf:       request.AsOf,
+		Components: componentLicenses,
+	}, http.StatusOK
+}
+
+// GetDetailsBatch retrieves the details of several licenses, reporting the IDs that were not found.
+func (h *LicenseHandler) GetDetailsBatch(ctx context.Context,
+	middleware middleware.Middleware[dto.LicenseBatchRequestDTO]) (*dto.LicenseDetailsBatchResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	request, err := middleware.Process()
+	if err != nil {
+		return &dto.LicenseDetailsBatchResponseDTO{
+			Status:   h.getRESTResponseStatus(s, common.StatusCode_FAILED, "", err),
+			Licenses: []dto.LicenseDetailsResultDTO{},
+			NotFound: []string{},
+		}, http.StatusBadRequest
+	}
+	licenses, notFound, ucErr := h.licenseUseCase.GetDetailsBatch(ctx, s, request)
+	if ucErr != nil {
+		s.Errorf("Error getting license details batch: %v", ucErr)
+		return &dto.LicenseDetailsBatchResponseDTO{
+			Status:   h.getRESTResponseStatus(s, ucErr.Status, "", ucErr.Error),
+			Licenses: []dto.LicenseDetailsResultDTO{},
+			NotFound: []string{},
+		}, ucErr.Code
+	}
+	response := &dto.LicenseDetailsBatchResponseDTO{Licenses: licenses, NotFound: notFound}
+	status, httpCode, message := helpers.DetermineStatusResponse(response)
+	response.Status = h.getRESTResponseStatus(s, status, message, nil)
+	return response, httpCode
+}
+
+// GetLicenseText retrieves the full text, standard header and template of a license.
+func (h *LicenseHandler) GetLicenseText(ctx context.Context,
+	middleware middleware.Middleware[dto.LicenseRequestDTO]) (*dto.LicenseTextResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	licenseDTO, err := middleware.Process()
+	if err != nil {
+		return &dto.LicenseTextResponseDTO{
+			Status: h.getRESTResponseStatus(s, common.StatusCode_FAILED, "", err),
+		}, http.StatusBadRequest
+	}
+	text, ucErr := h.licenseUseCase.GetLicenseText(ctx, s, licenseDTO)
+	if ucErr != nil {
+		s.Errorf("Error getting license text: %v", ucErr)
+		return &dto.LicenseTextResponseDTO{
+			Status: h.getRESTResponseStatus(s, ucErr.Status, "", ucErr.Error),
+		}, ucErr.Code
+	}
+	return &dto.LicenseTextResponseDTO{
+		Status:  h.getRESTResponseStatus(s, common.StatusCode_SUCCESS, "License text retrieved successfully", nil),
+		License: &text,
+	}, http.StatusOK
+}
+
+// IdentifyLicense ranks the known licenses by their similarity to the submitted text.
+func (h *LicenseHandler) IdentifyLicense(ctx context.Context,
+	middleware middleware.Middleware[dto.LicenseIdentifyRequestDTO]) (*dto.LicenseIdentifyResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	request, err := middleware.Process()
+	if err != nil {
+		return &dto.LicenseIdentifyResponseDTO{
+			Status:     h.getRESTResponseStatus(s, common.StatusCode_FAILED, "", err),
+			Candidates: []dto.LicenseCandidateDTO{},
+		}, http.StatusBadRequest
+	}
+	candidates, ucErr := h.licenseUseCase.IdentifyLicense(ctx, s, request)
+	if ucErr != nil {
+		s.Errorf("Error identifying license: %v", ucErr)
+		return &dto.LicenseIdentifyResponseDTO{
+			Status:     h.getRESTResponseStatus(s, ucErr.Status, "", ucErr.Error),
+			Candidates: []dto.LicenseCandidateDTO{},
+		}, ucErr.Code
+	}
+	message := "License candidates retrieved successfully"
+	if len(candidates) == 0 {
+		message = "No license matches the supplied text"
+	}
+	return &dto.LicenseIdentifyResponseDTO{
+		Status:     h.getRESTResponseStatus(s, common.StatusCode_SUCCESS, message, nil),
+		Candidates: candidates,
+	}, http.StatusOK
+}
+
+// SearchLicenses lists the license catalogue, filtered and fuzzy matched as requested.
+func (h *LicenseHandler) SearchLicenses(ctx context.Context,
+	middleware middleware.Middleware[dto.LicenseSearchRequestDTO]) (*dto.LicenseSearchResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	request, err := middleware.Process()
+	if err != nil {
+		return &dto.LicenseSearchResponseDTO{
+			Status:   h.getRESTResponseStatus(s, common.StatusCode_FAILED, "", err),
+			Licenses: []dto.LicenseCatalogueEntryDTO{},
+		}, http.StatusBadRequest
+	}
+	licenses, total := h.licenseUseCase.SearchLicenses(ctx, s, request)
+	return &dto.LicenseSearchResponseDTO{
+		Status:   h.getRESTResponseStatus(s, common.StatusCode_SUCCESS, "Licenses retrieved successfully", nil),
+		Licenses: licenses,
+		Total:    total,
+		Page:     request.Page,
+		PageSize: request.PageSize,
+	}, http.StatusOK
+}
+
+// GetComponentsByLicense lists the components whose license matches the requested license or expression.
+func (h *LicenseHandler) GetComponentsByLicense(ctx context.Context,
+	middleware middleware.Middleware[dto.ComponentsByLicenseRequestDTO]) (*dto.ComponentsByLicenseResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	request, err := middleware.Process()
+	if err != nil {
+		return &dto.ComponentsByLicenseResponseDTO{
+			Status:     h.getRESTResponseStatus(s, common.StatusCode_FAILED, "", err),
+			Components: []dto.LicensedComponentDTO{},
+		}, http.StatusBadRequest
+	}
+	components, nextCursor, ucErr := h.licenseUseCase.GetComponentsByLicense(ctx, s, request)
+	if ucErr != nil {
+		s.Errorf("Error getting components by license: %v", ucErr)
+		return &dto.ComponentsByLicenseResponseDTO{
+			Status:     h.getRESTResponseStatus(s, ucErr.Status, "", ucErr.Error),
+			Components: []dto.LicensedComponentDTO{},
+		}, ucErr.Code
+	}
+	return &dto.ComponentsByLicenseResponseDTO{
+		Status:     h.getRESTResponseStatus(s, common.StatusCode_SUCCESS, "Components retrieved successfully", nil),
+		Components: components,
+		NextCursor: nextCursor,
+	}, http.StatusOK
+}
+
+// GetLicenseChanges lists the components whose license rows were added or changed since a date or cursor.
+func (h *LicenseHandler) GetLicenseChanges(ctx context.Context,
+	middleware middleware.Middleware[dto.LicenseChangesRequestDTO]) (*dto.LicenseChangesResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	request, err := middleware.Process()
+	if err != nil {
+		return &dto.LicenseChangesResponseDTO{
+			Status:  h.getRESTResponseStatus(s, common.StatusCode_FAILED, "", err),
+			Changes: []dto.LicenseChangeDTO{},
+		}, http.StatusBadRequest
+	}
+	changes, nextCursor, hasMore, ucErr := h.licenseUseCase.GetLicenseChanges(ctx, s, request)
+	if ucErr != nil {
+		s.Errorf("Error getting license changes: %v", ucErr)
+		return &dto.LicenseChangesResponseDTO{
+			Status:  h.getRESTResponseStatus(s, ucErr.Status, "", ucErr.Error),
+			Changes: []dto.LicenseChangeDTO{},
+		}, ucErr.Code
+	}
+	return &dto.LicenseChangesResponseDTO{
+		Status:     h.getRESTResponseStatus(s, common.StatusCode_SUCCESS, "License changes retrieved successfully", nil),
+		Changes:    changes,
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, http.StatusOK
+}
+
+// GetLicenseStats returns the precomputed knowledge base statistics.
+func (h *LicenseHandler) GetLicenseStats(ctx context.Context) (*dto.LicenseStatsResponseDTO, int) {
+	s := ctxzap.Extract(ctx).Sugar()
+	stats, ucErr := h.licenseUseCase.GetLicenseStats(ctx, s)
+	if ucErr != nil {
+		s.Warnf("License statistics unavailable: %v", ucErr)
+		return &dto.LicenseStatsResponseDTO{
+			Status: h.getRESTResponseStatus(s, ucErr.Status, "", ucErr.Error),
+		}, ucErr.Code
+	}
+	return &dto.LicenseStatsResponseDTO{
+		Status: h.getRESTResponseStatus(s, common.StatusCode_SUCCESS, "License statistics retrieved successfully", nil),
+		Stats:  &stats,
+	}, http.StatusOK
+}
+
+// Caches returns the caches owned by the license use case, for the admin API.
+func (h *LicenseHandler) Caches() []cache.Reloadable {
+	return h.licenseUseCase.Caches()
+}
+
+// UsePolicy evaluates the given license policy for each component of the extended lookups.
+func (h *LicenseHandler) UsePolicy(engine *policy.Engine) {
+	h.licenseUseCase.UsePolicy(engine)
+}
+
+// UseCurations makes the given curation overrides take precedence over the knowledge base.
+func (h *LicenseHandler) UseCurations(store *curation.Store) {
+	h.licenseUseCase.UseCurations(store)
+}
+
+// WatchlistResolver returns the resolver re-resolving the watched components for the watchlist job.
+func (h *LicenseHandler) WatchlistResolver() watchlist.Resolver {
+	return h.licenseUseCase
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
@@ -16,6 +17,7 @@ import (
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	common "github.com/scanoss/papi/api/commonv2"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	myconfig "scanoss.com/licenses/pkg/config"
 	"scanoss.com/licenses/pkg/dto"
@@ -37,6 +39,22 @@ func (m *mockComponentMiddleware) Process() (componenthelper.ComponentDTO, error
 	return m.processFunc()
 }
 
+type mockComponentsExtendedMiddleware struct {
+	processFunc func() (dto.ComponentsExtendedRequestDTO, error)
+}
+
+func (m *mockComponentsExtendedMiddleware) Process() (dto.ComponentsExtendedRequestDTO, error) {
+	return m.processFunc()
+}
+
+type mockLicenseBatchMiddleware struct {
+	processFunc func() (dto.LicenseBatchRequestDTO, error)
+}
+
+func (m *mockLicenseBatchMiddleware) Process() (dto.LicenseBatchRequestDTO, error) {
+	return m.processFunc()
+}
+
 type mockLicenseDetailsMiddleware struct {
 	processFunc func() (dto.LicenseRequestDTO, error)
 }
@@ -47,7 +65,7 @@ func (m *mockLicenseDetailsMiddleware) Process() (dto.LicenseRequestDTO, error)
 
 func TestNewLicenseHandler(t *testing.T) {
 	config := &myconfig.ServerConfig{}
-	handler := NewLicenseHandler(config, &sqlx.DB{}, nil)
+	handler := NewLicenseHandler(config, &sqlx.DB{}, nil, nil, nil)
 
 	if handler == nil {
 		t.Fatal("Expected handler to be created, got nil")
@@ -60,7 +78,7 @@ func TestNewLicenseHandler(t *testing.T) {
 
 func TestLicenseHandler_getResponseStatus(t *testing.T) {
 	config := &myconfig.ServerConfig{}
-	handler := NewLicenseHandler(config, &sqlx.DB{}, nil)
+	handler := NewLicenseHandler(config, &sqlx.DB{}, nil, nil, nil)
 	ctx := context.Background()
 	logger := zap.NewNop().Sugar()
 
@@ -110,7 +128,7 @@ func TestLicenseHandler_GetLicenses(t *testing.T) {
 		t.Fatal(fmt.Sprintf("Error loading test SQL data %v", err))
 	}
 	defer models.CloseDB(db)
-	handler := NewLicenseHandler(config, db, nil)
+	handler := NewLicenseHandler(config, db, nil, nil, nil)
 	t.Run("successful middleware processing", func(t *testing.T) {
 		mockMW := &mockMiddleware{
 			processFunc: func() ([]componenthelper.ComponentDTO, error) {
@@ -183,7 +201,7 @@ func TestLicenseHandler_GetComponentLicense(t *testing.T) {
 	}
 	defer models.CloseDB(db)
 
-	handler := NewLicenseHandler(config, db, nil)
+	handler := NewLicenseHandler(config, db, nil, nil, nil)
 
 	t.Run("successful middleware processing", func(t *testing.T) {
 		mockMW := &mockComponentMiddleware{
@@ -260,7 +278,7 @@ func TestLicenseHandler_GetComponentsLicense_ResponseStatus(t *testing.T) {
 		t.Fatal(fmt.Sprintf("Error loading test SQL data %v", err))
 	}
 	defer models.CloseDB(db)
-	handler := NewLicenseHandler(config, db, nil)
+	handler := NewLicenseHandler(config, db, nil, nil, nil)
 
 	tests := []struct {
 		name             string
@@ -353,7 +371,7 @@ func TestLicenseHandler_GetComponentLicense_ResponseStatus(t *testing.T) {
 		t.Fatal(fmt.Sprintf("Error loading test SQL data %v", err))
 	}
 	defer models.CloseDB(db)
-	handler := NewLicenseHandler(config, db, nil)
+	handler := NewLicenseHandler(config, db, nil, nil, nil)
 
 	tests := []struct {
 		name             string
@@ -441,7 +459,7 @@ func TestLicenseHandler_GetDetails(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Error reading SQL file: %v", err)
 	}
-	handler := NewLicenseHandler(config, db, nil)
+	handler := NewLicenseHandler(config, db, nil, nil, nil)
 	ctx := ctxzap.ToContext(context.Background(), zap.NewNop())
 
 	t.Run("middleware processing error", func(t *testing.T) {
@@ -470,3 +488,237 @@ func TestLicenseHandler_GetDetails(t *testing.T) {
 		}
 	})
 }
+
+func TestLicenseHandler_GetComponentsLicenseExtended(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	config := &myconfig.ServerConfig{}
+	config.Lookup.MaxWorkers = 5
+	config.Lookup.SourcePriority = []int16{31, 32, 33, 5}
+	config.Lookup.ElectionPreferences = []string{"Apache-2.0", "GPL-2.0-only"}
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db, err := sqlx.Connect("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	err = models.LoadTestSQLData(db, ctx)
+	if err != nil {
+		t.Fatalf("Error loading test SQL data %v", err)
+	}
+	defer models.CloseDB(db)
+	handler := NewLicenseHandler(config, db, nil, nil, nil)
+
+	tests := []struct {
+		name            string
+		request         dto.ComponentsExtendedRequestDTO
+		expectedElected []string
+		expectElection  bool
+	}{
+		{
+			name: "request preferences elect one side of a dual license",
+			request: dto.ComponentsExtendedRequestDTO{
+				Components:  []componenthelper.ComponentDTO{{Purl: "pkg:github/dual/licensed", Requirement: "1.0.0"}},
+				Preferences: []string{"MIT"},
+			},
+			expectedElected: []string{"MIT"},
+			expectElection:  true,
+		},
+		{
+			name: "configured preferences are used when the request has none",
+			request: dto.ComponentsExtendedRequestDTO{
+				Components: []componenthelper.ComponentDTO{{Purl: "pkg:github/dual/licensed", Requirement: "1.0.0"}},
+			},
+			expectedElected: []string{"GPL-2.0-only"},
+			expectElection:  true,
+		},
+		{
+			name: "single license component has no election",
+			request: dto.ComponentsExtendedRequestDTO{
+				Components: []componenthelper.ComponentDTO{{Purl: "pkg:gitlab/gpl/project", Requirement: "1.0.0"}},
+			},
+			expectElection: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMW := &mockComponentsExtendedMiddleware{
+				processFunc: func() (dto.ComponentsExtendedRequestDTO, error) {
+					return tt.request, nil
+				},
+			}
+			response, code := handler.GetComponentsLicenseExtended(ctx, mockMW)
+			if code != http.StatusOK {
+				t.Fatalf("Expected HTTP 200, got %d (%s)", code, response.Status.Message)
+			}
+			if len(response.Components) != 1 {
+				t.Fatalf("Expected 1 component, got %d", len(response.Components))
+			}
+			elected := response.Components[0].ElectedLicense
+			if !tt.expectElection {
+				if elected != nil {
+					t.Errorf("Expected no elected license, got %+v", elected)
+				}
+				return
+			}
+			if elected == nil {
+				t.Fatal("Expected an elected license")
+			}
+			if fmt.Sprint(elected.Licenses) != fmt.Sprint(tt.expectedElected) {
+				t.Errorf("Expected elected licenses %v, got %v (%s)", tt.expectedElected, elected.Licenses, elected.Reason)
+			}
+		})
+	}
+
+	t.Run("middleware processing error", func(t *testing.T) {
+		mockMW := &mockComponentsExtendedMiddleware{
+			processFunc: func() (dto.ComponentsExtendedRequestDTO, error) {
+				return dto.ComponentsExtendedRequestDTO{}, errors.New("middleware error")
+			},
+		}
+		response, code := handler.GetComponentsLicenseExtended(ctx, mockMW)
+		if code != http.StatusBadRequest {
+			t.Errorf("Expected HTTP 400, got %d", code)
+		}
+		if response.Status.Status != common.StatusCode_FAILED.String() {
+			t.Errorf("Expected FAILED status, got %v", response.Status.Status)
+		}
+	})
+}
+
+// trailerStream records the trailers set by a handler, standing in for the gRPC transport.
+type trailerStream struct {
+	trailer metadata.MD
+}
+
+func (s *trailerStream) Method() string {
+	return "/scanoss.api.licenses.v2.License/GetComponentsLicense"
+}
+func (s *trailerStream) SetHeader(_ metadata.MD) error  { return nil }
+func (s *trailerStream) SendHeader(_ metadata.MD) error { return nil }
+func (s *trailerStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func TestLicenseHandler_GetComponentsLicense_ElectedLicenseTrailer(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	config := &myconfig.ServerConfig{}
+	config.Lookup.MaxWorkers = 5
+	config.Lookup.SourcePriority = []int16{31, 32, 33, 5}
+	config.Lookup.ElectionPreferences = []string{"MIT"}
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db, err := sqlx.Connect("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	err = models.LoadTestSQLData(db, ctx)
+	if err != nil {
+		t.Fatalf("Error loading test SQL data %v", err)
+	}
+	defer models.CloseDB(db)
+	handler := NewLicenseHandler(config, db, nil, nil, nil)
+
+	stream := &trailerStream{}
+	mockMW := &mockMiddleware{
+		processFunc: func() ([]componenthelper.ComponentDTO, error) {
+			return []componenthelper.ComponentDTO{
+				{Purl: "pkg:github/dual/licensed", Requirement: "1.0.0"},
+				{Purl: "pkg:gitlab/gpl/project", Requirement: "1.0.0"},
+			}, nil
+		},
+	}
+	response, err := handler.GetComponentsLicense(grpc.NewContextWithServerTransportStream(ctx, stream), mockMW)
+	if err != nil || response.Status.Status != common.StatusCode_SUCCESS {
+		t.Fatalf("Expected a successful response, got %v, %v", response, err)
+	}
+	values := stream.trailer.Get("x-elected-license")
+	if len(values) != 1 {
+		t.Fatalf("Expected one elected license, only the dual licensed component offers a choice, got %v", values)
+	}
+	var election dto.ComponentElectionDTO
+	if err = json.Unmarshal([]byte(values[0]), &election); err != nil {
+		t.Fatalf("Expected a JSON elected license, got %q: %v", values[0], err)
+	}
+	if election.Purl != "pkg:github/dual/licensed" || fmt.Sprint(election.Licenses) != "[MIT]" {
+		t.Errorf("Expected MIT to be elected for pkg:github/dual/licensed, got %+v", election)
+	}
+}
+
+func TestLicenseHandler_GetDetailsBatch_InvalidRequest(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	handler := NewLicenseHandler(&myconfig.ServerConfig{}, nil, nil, nil, nil)
+	mockMW := &mockLicenseBatchMiddleware{
+		processFunc: func() (dto.LicenseBatchRequestDTO, error) {
+			return dto.LicenseBatchRequestDTO{}, errors.New("no license IDs or expression supplied")
+		},
+	}
+	response, code := handler.GetDetailsBatch(ctx, mockMW)
+	if code != http.StatusBadRequest {
+		t.Errorf("Expected HTTP 400, got %d", code)
+	}
+	if response.Status.Status != common.StatusCode_FAILED.String() {
+		t.Errorf("Expected status FAILED, got %s", response.Status.Status)
+	}
+	if response.Licenses == nil || response.NotFound == nil {
+		t.Errorf("Expected empty (non-nil) license lists in the error response")
+	}
+}
+
+func TestLicenseHandler_GetLicenseText(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	config := &myconfig.ServerConfig{}
+	config.LicenseText.Dir = "../model/tests/license-list-data"
+	handler := NewLicenseHandler(config, nil, nil, nil, nil)
+
+	tests := []struct {
+		name         string
+		licenseID    string
+		expectedCode int
+		expectedID   string
+	}{
+		{name: "SPDX license from json details", licenseID: "apache-2.0", expectedCode: http.StatusOK, expectedID: "Apache-2.0"},
+		{name: "LicenseRef from text file", licenseID: "LicenseRef-scancode-example", expectedCode: http.StatusOK, expectedID: "LicenseRef-scancode-example"},
+		{name: "unknown license", licenseID: "Unknown-1.0", expectedCode: http.StatusNotFound},
+		{name: "empty license ID", licenseID: "", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMW := &mockLicenseDetailsMiddleware{
+				processFunc: func() (dto.LicenseRequestDTO, error) {
+					if tt.licenseID == "" {
+						return dto.LicenseRequestDTO{}, errors.New("no license request data supplied")
+					}
+					return dto.LicenseRequestDTO{ID: tt.licenseID}, nil
+				},
+			}
+			response, code := handler.GetLicenseText(ctx, mockMW)
+			if code != tt.expectedCode {
+				t.Fatalf("Expected HTTP %d, got %d (%s)", tt.expectedCode, code, response.Status.Message)
+			}
+			if tt.expectedID == "" {
+				if response.License != nil {
+					t.Errorf("Expected no license in the response, got %v", response.License.ID)
+				}
+				return
+			}
+			if response.License == nil || response.License.ID != tt.expectedID || response.License.Text == "" {
+				t.Errorf("Expected the text of %s, got %+v", tt.expectedID, response.License)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	common "github.com/scanoss/papi/api/commonv2"
 	pb "github.com/scanoss/papi/api/licensesv2"
+	"scanoss.com/licenses/pkg/dto"
 )
 
 // componentsLicenseInfoStatus determines the appropriate status code and message
@@ -73,6 +74,24 @@ func licenseDetailsStatus(licenseDetails *pb.LicenseDetails) (gRPCStatusCode com
 	return common.StatusCode_SUCCESS, http.StatusOK, "License details retrieved successfully"
 }
 
+// licenseDetailsBatchStatus determines the appropriate status code and message
+// for a batch of license details.
+//
+// Returns:
+//   - FAILED with 404 if the input is nil or none of the requested licenses were found
+//   - SUCCEEDED_WITH_WARNINGS with 200 if some of the requested licenses were not found
+//   - SUCCESS with 200 if all requested licenses were found
+func licenseDetailsBatchStatus(batch *dto.LicenseDetailsBatchResponseDTO) (gRPCStatusCode common.StatusCode,
+	httpCode int, message string) {
+	if batch == nil || len(batch.Licenses) == 0 {
+		return common.StatusCode_FAILED, http.StatusNotFound, "License details not found"
+	}
+	if len(batch.NotFound) > 0 {
+		return common.StatusCode_SUCCEEDED_WITH_WARNINGS, http.StatusOK, fmt.Sprintf("License details not found for the following license(s):%v", batch.NotFound)
+	}
+	return common.StatusCode_SUCCESS, http.StatusOK, "License details retrieved successfully"
+}
+
 // DetermineStatusResponse is the main entry point for determining appropriate status
 // codes and messages based on the type and content of the response data.
 //
@@ -83,6 +102,7 @@ func licenseDetailsStatus(licenseDetails *pb.LicenseDetails) (gRPCStatusCode com
 //   - []*pb.ComponentLicenseInfo: List of component license information
 //   - *pb.ComponentLicenseInfo: Single component license information
 //   - *pb.LicenseDetails: License details information
+//   - *dto.LicenseDetailsBatchResponseDTO: Batch of license details information
 //
 // Returns:
 //   - gRPCStatusCode: The gRPC status code (SUCCESS, SUCCEEDED_WITH_WARNINGS, or FAILED)
@@ -98,6 +118,8 @@ func DetermineStatusResponse(data interface{}) (gRPCStatusCode common.StatusCode
 		return componentLicenseInfoStatus(v)
 	case *pb.LicenseDetails:
 		return licenseDetailsStatus(v)
+	case *dto.LicenseDetailsBatchResponseDTO:
+		return licenseDetailsBatchStatus(v)
 	default:
 		return common.StatusCode_FAILED, http.StatusInternalServerError, "Internal server error"
 	}
@@ -6,6 +6,7 @@ import (
 
 	common "github.com/scanoss/papi/api/commonv2"
 	pb "github.com/scanoss/papi/api/licensesv2"
+	"scanoss.com/licenses/pkg/dto"
 )
 
 func TestComponentsLicenseInfoStatus(t *testing.T) {
@@ -208,6 +209,23 @@ func TestDetermineStatusResponse(t *testing.T) {
 			expectedHTTPCode:   http.StatusOK,
 			expectedMessage:    "License details retrieved successfully",
 		},
+		{
+			name: "LicenseDetailsBatchResponseDTO with missing licenses",
+			data: &dto.LicenseDetailsBatchResponseDTO{
+				Licenses: []dto.LicenseDetailsResultDTO{{ID: "MIT", LicenseDetails: &pb.LicenseDetails{FullName: "MIT License"}}},
+				NotFound: []string{"Unknown-1.0"},
+			},
+			expectedGRPCStatus: common.StatusCode_SUCCEEDED_WITH_WARNINGS,
+			expectedHTTPCode:   http.StatusOK,
+			expectedMessage:    "License details not found for the following license(s):[Unknown-1.0]",
+		},
+		{
+			name:               "LicenseDetailsBatchResponseDTO with no licenses",
+			data:               &dto.LicenseDetailsBatchResponseDTO{NotFound: []string{"Unknown-1.0"}},
+			expectedGRPCStatus: common.StatusCode_FAILED,
+			expectedHTTPCode:   http.StatusNotFound,
+			expectedMessage:    "License details not found",
+		},
 		{
 			name:               "unsupported type",
 			data:               "invalid type",
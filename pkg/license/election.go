@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package license
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/go-spdx/v2/spdxexp"
+)
+
+// Election is the outcome of choosing one alternative from a license expression.
+type Election struct {
+	// Licenses is the chosen license set. Empty when no alternative could be elected.
+	Licenses []string
+	// Expression is the chosen license set rendered as an SPDX expression.
+	Expression string
+	// Reason explains why the set was (or could not be) chosen.
+	Reason string
+}
+
+// Elected reports whether an alternative was chosen.
+func (e Election) Elected() bool {
+	return len(e.Licenses) > 0
+}
+
+// ElectLicense picks the alternative of expr that best fits the ranked preferences list.
+// Preferences act as an allow list as well: an alternative is only eligible when every license in
+// it matches a preference, either exactly (ignoring case) or through SPDX range semantics
+// (e.g. "GPL-2.0-or-later" matches a "GPL-3.0-only" preference).
+// Among eligible alternatives the one whose least preferred license ranks highest wins; ties go to
+// the lower total rank, then to fewer licenses, then to the order in which they appear in expr.
+// With no preferences, the alternative with the fewest licenses is chosen.
+func ElectLicense(expr *Expression, preferences []string) Election {
+	if expr == nil {
+		return Election{Reason: "no license expression to elect from"}
+	}
+	alternatives, complete := expr.Alternatives()
+	considered := ""
+	if !complete {
+		considered = fmt.Sprintf(" (only the first %d alternatives were considered)", len(alternatives))
+	}
+	if len(preferences) == 0 {
+		best := alternatives[0]
+		for _, alt := range alternatives[1:] {
+			if len(alt) < len(best) {
+				best = alt
+			}
+		}
+		return newElection(best, "no preference list supplied, elected the alternative with the fewest licenses"+considered)
+	}
+	bestIdx := -1
+	var bestWorst, bestTotal int
+	for i, alt := range alternatives {
+		worst, total, ok := rankAlternative(alt, preferences)
+		if !ok {
+			continue
+		}
+		if bestIdx < 0 || worst < bestWorst ||
+			(worst == bestWorst && total < bestTotal) ||
+			(worst == bestWorst && total == bestTotal && len(alt) < len(alternatives[bestIdx])) {
+			bestIdx, bestWorst, bestTotal = i, worst, total
+		}
+	}
+	if bestIdx < 0 {
+		return Election{Reason: fmt.Sprintf("none of the %d alternatives in %q is covered by the preference list%s",
+			len(alternatives), expr.String(), considered)}
+	}
+	return newElection(alternatives[bestIdx], fmt.Sprintf("elected %d of %d alternatives; least preferred license ranks %d in the preference list%s",
+		bestIdx+1, len(alternatives), bestWorst+1, considered))
+}
+
+// newElection builds an Election for the given license set.
+func newElection(licenses []string, reason string) Election {
+	return Election{
+		Licenses:   licenses,
+		Expression: strings.Join(licenses, " "+OperatorAnd+" "),
+		Reason:     reason,
+	}
+}
+
+// rankAlternative returns the worst (highest) and total rank of the licenses in alt.
+// ok is false when any license is not covered by preferences.
+func rankAlternative(alt, preferences []string) (worst, total int, ok bool) {
+	for _, l := range alt {
+		rank := PreferenceRank(l, preferences)
+		if rank < 0 {
+			return 0, 0, false
+		}
+		worst = max(worst, rank)
+		total += rank
+	}
+	return worst, total, true
+}
+
+// PreferenceRank returns the index of the first preference that covers lic, or -1 if none does.
+func PreferenceRank(lic string, preferences []string) int {
+	for i, p := range preferences {
+		if strings.EqualFold(p, lic) {
+			return i
+		}
+	}
+	for i, p := range preferences {
+		// Satisfies understands "+", "-or-later" and exception semantics, but errors on identifiers
+		// that are not on the SPDX list; those only ever match exactly (handled above).
+		if ok, err := spdxexp.Satisfies(lic, []string{p}); err == nil && ok {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package license
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestElectLicense(t *testing.T) {
+	tests := []struct {
+		name        string
+		expression  string
+		preferences []string
+		want        []string
+	}{
+		{
+			name:        "preferred license of a dual license wins",
+			expression:  "GPL-2.0-only OR MIT",
+			preferences: []string{"MIT", "Apache-2.0", "GPL-2.0-only"},
+			want:        []string{"MIT"},
+		},
+		{
+			name:        "only covered alternative is elected",
+			expression:  "GPL-2.0-only OR SSPL-1.0",
+			preferences: []string{"MIT", "GPL-2.0-only"},
+			want:        []string{"GPL-2.0-only"},
+		},
+		{
+			name:        "least preferred license decides between conjunctions",
+			expression:  "(MIT AND GPL-3.0-only) OR (Apache-2.0 AND BSD-3-Clause)",
+			preferences: []string{"MIT", "Apache-2.0", "BSD-3-Clause", "GPL-3.0-only"},
+			want:        []string{"Apache-2.0", "BSD-3-Clause"},
+		},
+		{
+			name:        "preference matching ignores case",
+			expression:  "mit OR GPL-2.0-only",
+			preferences: []string{"MIT"},
+			want:        []string{"mit"},
+		},
+		{
+			name:        "or-later license satisfies a later version preference",
+			expression:  "GPL-2.0-or-later OR SSPL-1.0",
+			preferences: []string{"GPL-3.0-only"},
+			want:        []string{"GPL-2.0-or-later"},
+		},
+		{
+			name:        "no alternative covered",
+			expression:  "GPL-2.0-only OR SSPL-1.0",
+			preferences: []string{"MIT"},
+			want:        nil,
+		},
+		{
+			name:       "no preferences picks the simplest alternative",
+			expression: "(MIT AND ISC) OR Apache-2.0",
+			want:       []string{"Apache-2.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			election := ElectLicense(expr, tt.preferences)
+			if !reflect.DeepEqual(election.Licenses, tt.want) {
+				t.Errorf("expected %v, got %v (%s)", tt.want, election.Licenses, election.Reason)
+			}
+			if election.Elected() != (tt.want != nil) {
+				t.Errorf("expected Elected() to be %v", tt.want != nil)
+			}
+			if election.Reason == "" {
+				t.Error("expected a reason to be reported")
+			}
+		})
+	}
+}
+
+func TestElectLicense_NilExpression(t *testing.T) {
+	election := ElectLicense(nil, []string{"MIT"})
+	if election.Elected() {
+		t.Errorf("expected no election, got %v", election.Licenses)
+	}
+}
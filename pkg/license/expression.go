@@ -0,0 +1,289 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package license
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SPDX expression operators.
+const (
+	OperatorAnd  = "AND"
+	OperatorOr   = "OR"
+	operatorWith = "WITH"
+)
+
+// MaxAlternatives caps the expansion of an expression into alternatives, which grows exponentially with the
+// number of OR groups joined by AND.
+const MaxAlternatives = 256
+
+// Expression is a node of a parsed SPDX license expression. Leaf nodes hold a single
+// license (including any "WITH <exception>" suffix); inner nodes join Left and Right with Operator.
+type Expression struct {
+	Operator string
+	License  string
+	Left     *Expression
+	Right    *Expression
+}
+
+// ParseExpression parses an SPDX license expression into an Expression tree.
+// WITH binds tighter than AND, which binds tighter than OR. Operators are matched case-insensitively
+// and license identifiers are kept as written, so non-SPDX identifiers from the DB are preserved.
+func ParseExpression(expression string) (*Expression, error) {
+	tokens := tokenizeExpression(expression)
+	if len(tokens) == 0 {
+		return nil, errors.New("empty license expression")
+	}
+	p := &expressionParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q in license expression", p.tokens[p.pos])
+	}
+	return expr, nil
+}
+
+// IsLeaf reports whether the node holds a single license.
+func (e *Expression) IsLeaf() bool {
+	return e.Operator == ""
+}
+
+// Licenses returns the unique licenses referenced by the expression, in order of appearance.
+func (e *Expression) Licenses() []string {
+	var result []string
+	seen := make(map[string]bool)
+	e.walk(func(leaf *Expression) {
+		if !seen[leaf.License] {
+			seen[leaf.License] = true
+			result = append(result, leaf.License)
+		}
+	})
+	return result
+}
+
+// HasChoice reports whether the expression offers more than one alternative, that is whether it holds an OR.
+func (e *Expression) HasChoice() bool {
+	if e.IsLeaf() {
+		return false
+	}
+	return e.Operator == OperatorOr || e.Left.HasChoice() || e.Right.HasChoice()
+}
+
+// Alternatives expands the expression into its disjunctive normal form: each entry is a set of
+// licenses that must all be honoured together, and the caller may pick any one of the entries.
+// For example "MIT AND (Apache-2.0 OR GPL-2.0-only)" becomes [[MIT Apache-2.0] [MIT GPL-2.0-only]].
+// Only the first MaxAlternatives entries are returned; complete is false when some were left out.
+func (e *Expression) Alternatives() (alternatives [][]string, complete bool) {
+	return e.alternatives(MaxAlternatives)
+}
+
+// alternatives expands the expression into at most limit alternatives.
+func (e *Expression) alternatives(limit int) ([][]string, bool) {
+	if e.IsLeaf() {
+		return [][]string{{e.License}}, true
+	}
+	left, leftComplete := e.Left.alternatives(limit)
+	right, rightComplete := e.Right.alternatives(limit)
+	complete := leftComplete && rightComplete
+	if e.Operator == OperatorOr {
+		result := append(left, right...)
+		if len(result) > limit {
+			return result[:limit], false
+		}
+		return result, complete
+	}
+	result := make([][]string, 0, min(len(left)*len(right), limit))
+	for _, l := range left {
+		for _, r := range right {
+			if len(result) == limit {
+				return result, false
+			}
+			result = append(result, mergeLicenses(l, r))
+		}
+	}
+	return result, complete
+}
+
+// String renders the expression back to SPDX syntax, adding parentheses only where needed.
+func (e *Expression) String() string {
+	if e.IsLeaf() {
+		return e.License
+	}
+	return e.operand(e.Left) + " " + e.Operator + " " + e.operand(e.Right)
+}
+
+// operand renders a child node, wrapping OR sub-expressions of an AND in parentheses.
+func (e *Expression) operand(child *Expression) string {
+	if e.Operator == OperatorAnd && child.Operator == OperatorOr {
+		return "(" + child.String() + ")"
+	}
+	return child.String()
+}
+
+// walk calls fn for every leaf of the expression, left to right.
+func (e *Expression) walk(fn func(leaf *Expression)) {
+	if e.IsLeaf() {
+		fn(e)
+		return
+	}
+	e.Left.walk(fn)
+	e.Right.walk(fn)
+}
+
+// JoinExpressions combines several expressions with AND. Nil entries are skipped.
+func JoinExpressions(expressions []*Expression) *Expression {
+	var result *Expression
+	for _, e := range expressions {
+		if e == nil {
+			continue
+		}
+		if result == nil {
+			result = e
+			continue
+		}
+		result = &Expression{Operator: OperatorAnd, Left: result, Right: e}
+	}
+	return result
+}
+
+// mergeLicenses concatenates two license sets, dropping duplicates.
+func mergeLicenses(left, right []string) []string {
+	result := make([]string, 0, len(left)+len(right))
+	result = append(result, left...)
+	for _, r := range right {
+		if !containsFold(result, r) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// containsFold reports whether list contains value, ignoring case.
+func containsFold(list []string, value string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenizeExpression splits an expression into parentheses and whitespace separated words.
+func tokenizeExpression(expression string) []string {
+	replacer := strings.NewReplacer("(", " ( ", ")", " ) ")
+	return strings.Fields(replacer.Replace(expression))
+}
+
+type expressionParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *expressionParser) peekOperator(op string) bool {
+	return p.pos < len(p.tokens) && strings.EqualFold(p.tokens[p.pos], op)
+}
+
+func (p *expressionParser) parseOr() (*Expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekOperator(OperatorOr) {
+		p.pos++
+		var right *Expression
+		if right, err = p.parseAnd(); err != nil {
+			return nil, err
+		}
+		left = &Expression{Operator: OperatorOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseAnd() (*Expression, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekOperator(OperatorAnd) {
+		p.pos++
+		var right *Expression
+		if right, err = p.parseTerm(); err != nil {
+			return nil, err
+		}
+		left = &Expression{Operator: OperatorAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseTerm() (*Expression, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, errors.New("unexpected end of license expression")
+	}
+	token := p.tokens[p.pos]
+	switch {
+	case token == "(":
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos] != ")" {
+			return nil, errors.New("missing closing parenthesis in license expression")
+		}
+		p.pos++
+		return expr, nil
+	case token == ")", p.peekOperator(OperatorAnd), p.peekOperator(OperatorOr), p.peekOperator(operatorWith):
+		return nil, fmt.Errorf("unexpected token %q in license expression", token)
+	}
+	p.pos++
+	leaf := token
+	if p.peekOperator(operatorWith) {
+		p.pos++
+		if p.pos >= len(p.tokens) || p.tokens[p.pos] == "(" || p.tokens[p.pos] == ")" {
+			return nil, fmt.Errorf("missing exception after %s WITH", token)
+		}
+		leaf = token + " " + operatorWith + " " + p.tokens[p.pos]
+		p.pos++
+	}
+	return &Expression{License: leaf}, nil
+}
+
+// ExpressionFromRecord builds the expression for a licenses table record. When spdx holds an SPDX
+// expression it is parsed as such; legacy list formats ("a/b", "a;b") carry no operators, so the
+// licenses extracted from them (ids) are joined with AND, matching how the statement is built.
+func ExpressionFromRecord(spdx string, ids []string) *Expression {
+	if hasExpressionOperators(spdx) {
+		if expr, err := ParseExpression(spdx); err == nil {
+			return expr
+		}
+	}
+	var leaves []*Expression
+	for _, id := range ids {
+		leaves = append(leaves, &Expression{License: id})
+	}
+	return JoinExpressions(leaves)
+}
+
+// hasExpressionOperators reports whether s looks like an SPDX compound expression.
+// It mirrors the check ParseLicenseExpression uses before handing a string to spdxexp.
+func hasExpressionOperators(s string) bool {
+	return strings.Contains(s, " AND ") || strings.Contains(s, " OR ") || strings.Contains(s, "(")
+}
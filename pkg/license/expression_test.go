@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package license
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		name         string
+		expression   string
+		wantString   string
+		alternatives [][]string
+		expectErr    bool
+	}{
+		{
+			name:         "single license",
+			expression:   "MIT",
+			wantString:   "MIT",
+			alternatives: [][]string{{"MIT"}},
+		},
+		{
+			name:         "dual license",
+			expression:   "GPL-2.0-only OR MIT",
+			wantString:   "GPL-2.0-only OR MIT",
+			alternatives: [][]string{{"GPL-2.0-only"}, {"MIT"}},
+		},
+		{
+			name:         "AND binds tighter than OR",
+			expression:   "MIT OR Apache-2.0 AND BSD-3-Clause",
+			wantString:   "MIT OR Apache-2.0 AND BSD-3-Clause",
+			alternatives: [][]string{{"MIT"}, {"Apache-2.0", "BSD-3-Clause"}},
+		},
+		{
+			name:         "parenthesised OR inside AND",
+			expression:   "MIT AND (Apache-2.0 OR GPL-2.0-only)",
+			wantString:   "MIT AND (Apache-2.0 OR GPL-2.0-only)",
+			alternatives: [][]string{{"MIT", "Apache-2.0"}, {"MIT", "GPL-2.0-only"}},
+		},
+		{
+			name:         "WITH exception stays with its license",
+			expression:   "GPL-2.0-only WITH Classpath-exception-2.0 OR MIT",
+			wantString:   "GPL-2.0-only WITH Classpath-exception-2.0 OR MIT",
+			alternatives: [][]string{{"GPL-2.0-only WITH Classpath-exception-2.0"}, {"MIT"}},
+		},
+		{
+			name:         "lowercase operators",
+			expression:   "mit or isc",
+			wantString:   "mit OR isc",
+			alternatives: [][]string{{"mit"}, {"isc"}},
+		},
+		{
+			name:         "duplicate licenses are merged",
+			expression:   "MIT AND (MIT OR ISC)",
+			wantString:   "MIT AND (MIT OR ISC)",
+			alternatives: [][]string{{"MIT"}, {"MIT", "ISC"}},
+		},
+		{name: "empty expression", expression: "  ", expectErr: true},
+		{name: "dangling operator", expression: "MIT OR", expectErr: true},
+		{name: "missing parenthesis", expression: "(MIT OR ISC", expectErr: true},
+		{name: "unbalanced parenthesis", expression: "MIT OR ISC)", expectErr: true},
+		{name: "missing exception", expression: "GPL-2.0-only WITH", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseExpression(tt.expression)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("expected error for %q, got %v", tt.expression, expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error for %q: %v", tt.expression, err)
+			}
+			if got := expr.String(); got != tt.wantString {
+				t.Errorf("expected string %q, got %q", tt.wantString, got)
+			}
+			got, complete := expr.Alternatives()
+			if !reflect.DeepEqual(got, tt.alternatives) || !complete {
+				t.Errorf("expected alternatives %v, got %v (complete %v)", tt.alternatives, got, complete)
+			}
+			if expr.HasChoice() != (len(tt.alternatives) > 1) {
+				t.Errorf("expected HasChoice() to be %v", len(tt.alternatives) > 1)
+			}
+		})
+	}
+}
+
+func TestExpression_AlternativesLimit(t *testing.T) {
+	// 2^20 alternatives: (L0a OR L0b) AND (L1a OR L1b) AND ...
+	groups := make([]string, 20)
+	for i := range groups {
+		groups[i] = fmt.Sprintf("(LicenseRef-%da OR LicenseRef-%db)", i, i)
+	}
+	expr, err := ParseExpression(strings.Join(groups, " AND "))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	alternatives, complete := expr.Alternatives()
+	if len(alternatives) != MaxAlternatives || complete {
+		t.Errorf("expected %d alternatives and an incomplete expansion, got %d (complete %v)", MaxAlternatives, len(alternatives), complete)
+	}
+	if !expr.HasChoice() {
+		t.Error("expected the expression to offer a choice")
+	}
+	election := ElectLicense(expr, nil)
+	if !election.Elected() || !strings.Contains(election.Reason, "only the first") {
+		t.Errorf("expected an election over the first alternatives, got %+v", election)
+	}
+}
+
+func TestExpressionFromRecord(t *testing.T) {
+	tests := []struct {
+		name string
+		spdx string
+		ids  []string
+		want string
+	}{
+		{name: "SPDX expression", spdx: "GPL-2.0-only OR MIT", ids: []string{"GPL-2.0-only", "MIT"}, want: "GPL-2.0-only OR MIT"},
+		{name: "legacy slash list", spdx: "GPL-2.0-only/GPL-3.0-only", ids: []string{"GPL-2.0-only", "GPL-3.0-only"}, want: "GPL-2.0-only AND GPL-3.0-only"},
+		{name: "single license", spdx: "MIT", ids: []string{"MIT"}, want: "MIT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpressionFromRecord(tt.spdx, tt.ids).String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+	if ExpressionFromRecord("", nil) != nil {
+		t.Error("expected nil expression for a record without licenses")
+	}
+}
+
+func TestJoinExpressions(t *testing.T) {
+	dual, _ := ParseExpression("GPL-2.0-only OR MIT")
+	joined := JoinExpressions([]*Expression{{License: "ISC"}, nil, dual})
+	if got := joined.String(); got != "ISC AND (GPL-2.0-only OR MIT)" {
+		t.Errorf("unexpected joined expression %q", got)
+	}
+	if JoinExpressions(nil) != nil {
+		t.Error("expected nil when joining no expressions")
+	}
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package license
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Scores returned by FuzzyScore for the different kinds of match, best first.
+const (
+	fuzzyExactScore    = 1.0
+	fuzzyPrefixScore   = 0.9
+	fuzzyContainsScore = 0.8
+	fuzzyTokensScore   = 0.7
+	// fuzzyEditScale caps the score of matches found by edit distance, so they rank below substring matches.
+	fuzzyEditScale = 0.6
+)
+
+// FuzzyScore rates how well query matches a license ID or name, from 0 (no match) to 1 (exact).
+// Matching ignores case and separators, so "gpl2" matches "GPL-2.0-only" and "apache 2" matches "Apache-2.0".
+// Exact, prefix, substring and all-words matches rank highest; otherwise the score is derived from
+// the edit distance between query and the candidate (or its closest word), scaled below the substring matches.
+func FuzzyScore(query, candidate string) float64 {
+	q := fuzzyKey(query)
+	c := fuzzyKey(candidate)
+	if len(q) == 0 || len(c) == 0 {
+		return 0
+	}
+	switch {
+	case q == c:
+		return fuzzyExactScore
+	case strings.HasPrefix(c, q):
+		return fuzzyPrefixScore
+	case strings.Contains(c, q):
+		return fuzzyContainsScore
+	case containsAllWords(strings.ToLower(candidate), fuzzyWords(query)):
+		return fuzzyTokensScore
+	}
+	best := editSimilarity(q, c)
+	for _, w := range fuzzyWords(candidate) {
+		best = max(best, editSimilarity(q, w))
+	}
+	return fuzzyEditScale * best
+}
+
+// editSimilarity returns 1 minus the edit distance of a and b relative to the longest of the two.
+func editSimilarity(a, b string) float64 {
+	longest := max(len([]rune(a)), len([]rune(b)))
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(editDistance(a, b))/float64(longest)
+}
+
+// fuzzyKey lower-cases s and drops everything but letters and digits.
+func fuzzyKey(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// fuzzyWords splits s into lower-cased words of letters and digits.
+func fuzzyWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// containsAllWords reports whether s contains every one of words.
+func containsAllWords(s string, words []string) bool {
+	if len(words) < 2 {
+		return false
+	}
+	for _, w := range words {
+		if !strings.Contains(s, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// editDistance returns the optimal string alignment distance between a and b: the Levenshtein
+// distance, also counting the transposition of two adjacent characters as a single edit.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package license
+
+import "testing"
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		query     string
+		candidate string
+		want      float64
+	}{
+		{query: "mit", candidate: "MIT", want: fuzzyExactScore},
+		{query: "gpl2", candidate: "GPL-2.0-only", want: fuzzyPrefixScore},
+		{query: "2.0", candidate: "Apache-2.0", want: fuzzyContainsScore},
+		{query: "general gnu", candidate: "GNU General Public License v3.0 only", want: fuzzyTokensScore},
+		{query: "", candidate: "MIT", want: 0},
+		{query: "MIT", candidate: "", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query+"/"+tt.candidate, func(t *testing.T) {
+			if got := FuzzyScore(tt.query, tt.candidate); got != tt.want {
+				t.Errorf("FuzzyScore(%q, %q) = %v, want %v", tt.query, tt.candidate, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("typos rank by edit distance", func(t *testing.T) {
+		near := FuzzyScore("apahce", "Apache")
+		far := FuzzyScore("apahce", "BSD-3-Clause")
+		if near <= far || near >= fuzzyTokensScore {
+			t.Errorf("expected typo score %v to be above %v and below %v", near, far, fuzzyTokensScore)
+		}
+	})
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"apache", "apahce", 1},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
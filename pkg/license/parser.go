@@ -6,14 +6,38 @@ import (
 	"github.com/github/go-spdx/v2/spdxexp"
 )
 
+// Ways ParseLicense reads a license string.
+const (
+	ParseMethodSPDX          = "spdx_expression"
+	ParseMethodSemicolonList = "semicolon_list"
+	ParseMethodSlashList     = "slash_list"
+)
+
+// ParsedLicense is the outcome of parsing a license string with ParseLicense.
+type ParsedLicense struct {
+	Licenses []string
+	// Method is how the licenses were extracted: ParseMethodSPDX or one of the legacy list formats.
+	Method string
+	// Fallback is set when the string looked like an SPDX expression but spdxexp could not
+	// extract any license from it, so a legacy list format was used instead.
+	Fallback bool
+}
+
 // ParseLicenseExpression parses SPDX license expressions and returns individual licenses.
 func ParseLicenseExpression(license string) ([]string, error) {
+	return ParseLicense(license).Licenses, nil
+}
+
+// ParseLicense parses a license string like ParseLicenseExpression, also reporting how it was read.
+func ParseLicense(license string) ParsedLicense {
+	var parsed ParsedLicense
 	// Try SPDX expression parsing first
 	if strings.Contains(license, " AND ") || strings.Contains(license, " OR ") || strings.Contains(license, "(") {
 		licenses, err := spdxexp.ExtractLicenses(license)
 		if err == nil && len(licenses) > 0 {
-			return licenses, nil
+			return ParsedLicense{Licenses: licenses, Method: ParseMethodSPDX}
 		}
+		parsed.Fallback = true
 	}
 
 	// Fallback to simple string splitting for legacy formats
@@ -21,17 +45,18 @@ func ParseLicenseExpression(license string) ([]string, error) {
 	var spdxIDs []string
 	if strings.Contains(license, ";") {
 		spdxIDs = strings.Split(license, ";")
+		parsed.Method = ParseMethodSemicolonList
 	} else {
 		// Handle forward slash separation (existing legacy format)
 		spdxIDs = strings.Split(license, "/")
+		parsed.Method = ParseMethodSlashList
 	}
 
-	var result []string
 	for _, id := range spdxIDs {
 		trimmed := strings.TrimSpace(id)
 		if trimmed != "" {
-			result = append(result, trimmed)
+			parsed.Licenses = append(parsed.Licenses, trimmed)
 		}
 	}
-	return result, nil
+	return parsed
 }
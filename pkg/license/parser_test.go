@@ -0,0 +1,37 @@
+package license
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseLicense(t *testing.T) {
+	tests := []struct {
+		license  string
+		want     []string
+		method   string
+		fallback bool
+	}{
+		{license: "MIT", want: []string{"MIT"}, method: ParseMethodSlashList},
+		{license: "MIT OR Apache-2.0", want: []string{"MIT", "Apache-2.0"}, method: ParseMethodSPDX},
+		{license: "GPL-2.0-only/MIT", want: []string{"GPL-2.0-only", "MIT"}, method: ParseMethodSlashList},
+		{license: "GNU GPL v2; GNU GPL v3", want: []string{"GNU GPL v2", "GNU GPL v3"}, method: ParseMethodSemicolonList},
+		{license: "GNU GPL v2 AND (BSD;MIT", want: []string{"GNU GPL v2 AND (BSD", "MIT"}, method: ParseMethodSemicolonList, fallback: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.license, func(t *testing.T) {
+			// spdxexp does not keep the order of the licenses in an expression.
+			got := ParseLicense(tt.license)
+			slices.Sort(got.Licenses)
+			slices.Sort(tt.want)
+			if !slices.Equal(got.Licenses, tt.want) || got.Method != tt.method || got.Fallback != tt.fallback {
+				t.Errorf("ParseLicense(%q) = %+v, want %v via %s (fallback %v)", tt.license, got, tt.want, tt.method, tt.fallback)
+			}
+			licenses, _ := ParseLicenseExpression(tt.license)
+			slices.Sort(licenses)
+			if !slices.Equal(licenses, got.Licenses) {
+				t.Errorf("ParseLicenseExpression(%q) = %v, want %v", tt.license, licenses, got.Licenses)
+			}
+		})
+	}
+}
@@ -59,3 +59,14 @@ func ExtractLicenseIDsFromPurlLicenses(licenses []models.PurlLicense) []int32 {
 
 	return result
 }
+
+// LatestDate returns the most recent date of the given rows, i.e. the date bucket their license data came from.
+func LatestDate(licenses []models.PurlLicense) string {
+	var latest string
+	for _, l := range licenses {
+		if l.Date > latest {
+			latest = l.Date
+		}
+	}
+	return latest
+}
@@ -110,3 +110,17 @@ func TestPickLicensesByPriority(t *testing.T) {
 		})
 	}
 }
+
+func TestLatestDate(t *testing.T) {
+	if got := LatestDate(nil); got != "" {
+		t.Errorf("expected no date for no rows, got %q", got)
+	}
+	got := LatestDate([]models.PurlLicense{
+		{SourceID: 1, LicenseID: 100, Date: "2023-01-06"},
+		{SourceID: 1, LicenseID: 101, Date: "2024-03-01"},
+		{SourceID: 1, LicenseID: 102, Date: "2023-11-30"},
+	})
+	if got != "2024-03-01" {
+		t.Errorf("expected 2024-03-01, got %q", got)
+	}
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package license
+
+import "testing"
+
+func TestPurlType(t *testing.T) {
+	tests := map[string]string{
+		"pkg:npm/express":           "npm",
+		"pkg:Maven/org.example/lib": "maven",
+		"express":                   "unknown",
+		"pkg:/express":              "unknown",
+	}
+	for purl, want := range tests {
+		if got := PurlType(purl); got != want {
+			t.Errorf("PurlType(%q) = %q, want %q", purl, got, want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package license
+
+// SourceLDBComponentLicenses is the source ID of the licenses read from the ldb_component_licenses table,
+// the LDB export keyed by the MD5 of purl@version. It is outside the range of the purl_licenses source IDs.
+const SourceLDBComponentLicenses int16 = 900
+
+// sourceNames maps the license source IDs to the kind of detection behind them.
+var sourceNames = map[int16]string{
+	0:                          "component_declared",
+	3:                          "license_file",
+	5:                          "scancode",
+	31:                         "license_file",
+	32:                         "license_file",
+	33:                         "metadata_file",
+	34:                         "licenses_folder",
+	35:                         "component_declared",
+	SourceLDBComponentLicenses: "ldb_component_licenses",
+}
+
+// SourceName returns the name of the license source with the given ID, or an empty string when it is unknown.
+func SourceName(sourceID int16) string {
+	return sourceNames[sourceID]
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package license
+
+import "testing"
+
+func TestSourceName(t *testing.T) {
+	tests := map[int16]string{
+		0:                          "component_declared",
+		31:                         "license_file",
+		5:                          "scancode",
+		SourceLDBComponentLicenses: "ldb_component_licenses",
+		77:                         "",
+	}
+	for id, expected := range tests {
+		if got := SourceName(id); got != expected {
+			t.Errorf("SourceName(%d) = %q, expected %q", id, got, expected)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package license
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var (
+	// templateVarRegex matches replaceable template sections: <<var;name="...";original="...";match="...">>.
+	templateVarRegex = regexp.MustCompile(`(?s)<<var;.*?>>`)
+	// copyrightLineRegex matches copyright notice lines, which the guidelines exclude from matching.
+	copyrightLineRegex = regexp.MustCompile(`(?im)^\W*(copyright|\(c\)|©).*$`)
+	// listMarkerRegex matches bullets and numbering at the start of a line ("1.", "(a)", "iv)", "*").
+	listMarkerRegex = regexp.MustCompile(`(?m)^\s*(\(?[0-9a-z]{1,4}[.)]|[*•·-])\s+`)
+)
+
+// varietalWords maps spelling variants to the form used for matching, following the SPDX
+// equivalent words list.
+var varietalWords = map[string]string{
+	"acknowledgment": "acknowledgement",
+	"analogue":       "analog",
+	"authorisation":  "authorization",
+	"authorised":     "authorized",
+	"centre":         "center",
+	"favour":         "favor",
+	"licence":        "license",
+	"licences":       "licenses",
+	"licenced":       "licensed",
+	"licencing":      "licensing",
+	"organisation":   "organization",
+	"percent":        "per cent",
+	"sublicence":     "sublicense",
+	"https":          "http",
+}
+
+// NormalizeLicenseText applies the SPDX matching guidelines to a license text: copyright lines and
+// list markers are dropped, case, punctuation and whitespace differences are ignored, and varietal
+// spellings are unified. The result is a single space separated string of words.
+func NormalizeLicenseText(text string) string {
+	text = copyrightLineRegex.ReplaceAllString(text, " ")
+	text = listMarkerRegex.ReplaceAllString(text, " ")
+	text = strings.ToLower(text)
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		if v, ok := varietalWords[w]; ok {
+			words[i] = v
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// NormalizeLicenseTemplate normalizes an SPDX license template. Replaceable and optional sections
+// may legitimately differ in the matched text, so they are removed before normalization.
+func NormalizeLicenseTemplate(template string) string {
+	template = templateVarRegex.ReplaceAllString(template, " ")
+	template = removeOptionalSections(template)
+	return NormalizeLicenseText(template)
+}
+
+const (
+	beginOptionalMarker = "<<beginOptional>>"
+	endOptionalMarker   = "<<endOptional>>"
+)
+
+// removeOptionalSections replaces each outermost optional template section, including the sections
+// nested in it, with a space. A stray end marker is dropped; an unterminated section runs to the end.
+func removeOptionalSections(template string) string {
+	var b strings.Builder
+	depth := 0
+	for len(template) > 0 {
+		begin := strings.Index(template, beginOptionalMarker)
+		end := strings.Index(template, endOptionalMarker)
+		if begin < 0 && end < 0 {
+			if depth == 0 {
+				b.WriteString(template)
+			}
+			break
+		}
+		if begin >= 0 && (end < 0 || begin < end) {
+			if depth == 0 {
+				b.WriteString(template[:begin])
+				b.WriteString(" ")
+			}
+			depth++
+			template = template[begin+len(beginOptionalMarker):]
+			continue
+		}
+		if depth == 0 {
+			b.WriteString(template[:end])
+			b.WriteString(" ")
+		} else {
+			depth--
+		}
+		template = template[end+len(endOptionalMarker):]
+	}
+	return b.String()
+}
+
+// ReferenceText is a known license text to match against.
+type ReferenceText struct {
+	ID       string
+	Name     string
+	Text     string
+	Template string
+}
+
+// TextCandidate is a license that matches a text, with its similarity score in the range [0, 1].
+type TextCandidate struct {
+	ID    string
+	Name  string
+	Score float64
+}
+
+// TextMatcher ranks reference license texts by their similarity to a given text.
+// Similarity is the Dice coefficient of the word bigrams of both normalized texts.
+type TextMatcher struct {
+	references []matchReference
+}
+
+type matchReference struct {
+	id, name string
+	shingles []map[string]int // one entry for the text, one for the template (when available)
+}
+
+// NewTextMatcher normalizes and indexes the given reference texts.
+func NewTextMatcher(references []ReferenceText) *TextMatcher {
+	m := &TextMatcher{references: make([]matchReference, 0, len(references))}
+	for _, r := range references {
+		ref := matchReference{id: r.ID, name: r.Name}
+		if len(strings.TrimSpace(r.Text)) > 0 {
+			ref.shingles = append(ref.shingles, shingles(NormalizeLicenseText(r.Text)))
+		}
+		if len(strings.TrimSpace(r.Template)) > 0 {
+			ref.shingles = append(ref.shingles, shingles(NormalizeLicenseTemplate(r.Template)))
+		}
+		if len(ref.shingles) > 0 {
+			m.references = append(m.references, ref)
+		}
+	}
+	return m
+}
+
+// Size returns the number of indexed reference texts.
+func (m *TextMatcher) Size() int {
+	return len(m.references)
+}
+
+// Match returns the references scoring at least minScore against text, best first.
+// Only references accepted by include (when not nil) are considered.
+func (m *TextMatcher) Match(text string, minScore float64, include func(id string) bool) []TextCandidate {
+	input := shingles(NormalizeLicenseText(text))
+	if len(input) == 0 {
+		return []TextCandidate{}
+	}
+	candidates := []TextCandidate{}
+	for _, ref := range m.references {
+		if include != nil && !include(ref.id) {
+			continue
+		}
+		var best float64
+		for _, sh := range ref.shingles {
+			best = max(best, dice(input, sh))
+		}
+		if best >= minScore {
+			candidates = append(candidates, TextCandidate{ID: ref.id, Name: ref.name, Score: best})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	return candidates
+}
+
+// shingles returns the multiset of word bigrams of a normalized text. A single word text yields that word.
+func shingles(normalized string) map[string]int {
+	words := strings.Fields(normalized)
+	result := make(map[string]int, len(words))
+	if len(words) == 1 {
+		result[words[0]]++
+	}
+	for i := 0; i+1 < len(words); i++ {
+		result[words[i]+" "+words[i+1]]++
+	}
+	return result
+}
+
+// dice computes the Dice coefficient of two multisets.
+func dice(a, b map[string]int) float64 {
+	var sizeA, sizeB, common int
+	for k, n := range a {
+		sizeA += n
+		common += min(n, b[k])
+	}
+	for _, n := range b {
+		sizeB += n
+	}
+	if sizeA+sizeB == 0 {
+		return 0
+	}
+	return 2 * float64(common) / float64(sizeA+sizeB)
+}
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+package license
+
+import (
+	"testing"
+)
+
+const mitText = `MIT License
+
+Copyright (c) <year> <copyright holders>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated
+documentation files (the "Software"), to deal in the Software without restriction, including without limitation
+the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and
+to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions
+of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED.`
+
+const iscText = `ISC License
+
+Copyright (c) <year> <copyright holders>
+
+Permission to use, copy, modify, and/or distribute this software for any purpose with or without fee is hereby
+granted, provided that the above copyright notice and this permission notice appear in all copies.
+
+THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH REGARD TO THIS SOFTWARE.`
+
+func TestNormalizeLicenseText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "case, punctuation and whitespace", text: "  THE Software,\n\tis   \"provided\" — as-is.", want: "the software is provided as is"},
+		{name: "copyright lines are dropped", text: "Copyright (c) 2024 ACME\n© 2023 Foo\n(C) Bar\nKeep this", want: "keep this"},
+		{name: "list markers are dropped", text: "1. first\n(b) second\n* third\niv) fourth", want: "first second third fourth"},
+		{name: "varietal words", text: "Licence: see https://example.org", want: "license see http example org"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeLicenseText(tt.text); got != tt.want {
+				t.Errorf("NormalizeLicenseText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeLicenseTemplate(t *testing.T) {
+	template := `<<beginOptional>>MIT License<<endOptional>> <<var;name="copyright";original="Copyright (c) <year>";match=".{0,5000}">> Permission is granted.`
+	if got := NormalizeLicenseTemplate(template); got != "permission is granted" {
+		t.Errorf("NormalizeLicenseTemplate() = %q", got)
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name:     "nested optional sections",
+			template: "Preamble. <<beginOptional>>Outer <<beginOptional>>inner<<endOptional>> still outer.<<endOptional>> Permission is granted.",
+			want:     "preamble permission is granted",
+		},
+		{
+			name:     "consecutive optional sections",
+			template: "<<beginOptional>>one<<endOptional>>Kept<<beginOptional>>two<<endOptional>> text.",
+			want:     "kept text",
+		},
+		{
+			name:     "stray end marker",
+			template: "Kept<<endOptional>> text.",
+			want:     "kept text",
+		},
+		{
+			name:     "unterminated section",
+			template: "Kept text. <<beginOptional>>dropped",
+			want:     "kept text",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := NormalizeLicenseTemplate(test.template); got != test.want {
+				t.Errorf("NormalizeLicenseTemplate() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTextMatcher_Match(t *testing.T) {
+	matcher := NewTextMatcher([]ReferenceText{
+		{ID: "MIT", Name: "MIT License", Text: mitText},
+		{ID: "ISC", Name: "ISC License", Text: iscText},
+		{ID: "Empty"},
+	})
+	if matcher.Size() != 2 {
+		t.Fatalf("expected 2 indexed references, got %d", matcher.Size())
+	}
+
+	t.Run("reformatted text with a real copyright matches exactly", func(t *testing.T) {
+		input := "Copyright 2024 ACME Corp.\n\nPermission is hereby granted, free of charge, to any person obtaining a copy of this software and associated " +
+			"documentation files (the 'Software'), to deal in the Software without restriction, including without limitation the rights to use, " +
+			"copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is " +
+			"furnished to do so, subject to the following conditions: The above copyright notice and this permission notice shall be included " +
+			"in all copies or substantial portions of the Software. THE SOFTWARE IS PROVIDED 'AS IS', WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED."
+		candidates := matcher.Match(input, 0.1, nil)
+		if len(candidates) == 0 || candidates[0].ID != "MIT" {
+			t.Fatalf("expected MIT as the best candidate, got %+v", candidates)
+		}
+		if candidates[0].Score < 0.95 {
+			t.Errorf("expected a near exact score for MIT, got %f", candidates[0].Score)
+		}
+		for i := 1; i < len(candidates); i++ {
+			if candidates[i].Score > candidates[i-1].Score {
+				t.Errorf("candidates are not ranked: %+v", candidates)
+			}
+		}
+	})
+
+	t.Run("include filter", func(t *testing.T) {
+		candidates := matcher.Match(iscText, 0, func(id string) bool { return id != "ISC" })
+		for _, c := range candidates {
+			if c.ID == "ISC" {
+				t.Errorf("expected ISC to be filtered out")
+			}
+		}
+	})
+
+	t.Run("unrelated text", func(t *testing.T) {
+		candidates := matcher.Match("lorem ipsum dolor sit amet", 0.2, nil)
+		if len(candidates) != 0 {
+			t.Errorf("expected no candidates, got %+v", candidates)
+		}
+	})
+}
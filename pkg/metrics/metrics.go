@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package metrics holds the Prometheus metrics of the License service and the handler exposing them.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "scanoss_licenses"
+
+// Registry holds every metric of the service, along with the Go runtime and process collectors.
+var Registry = prometheus.NewRegistry()
+
+var (
+	rpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_requests_total",
+		Help:      "gRPC requests handled, by method and response status.",
+	}, []string{"rpc", "status"})
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_request_duration_seconds",
+		Help:      "gRPC request latency, by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"rpc"})
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "REST-only requests handled, by route and HTTP status code.",
+	}, []string{"route", "code"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "REST-only request latency, by route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route"})
+	componentOutcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "component_outcomes_total",
+		Help:      "Component license lookups served, by info code (SUCCESS when none was set).",
+	}, []string{"info_code"})
+	resolutionSteps = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "resolution_steps_total",
+		Help:      "License resolution steps run, by step and whether the step found licenses.",
+	}, []string{"step", "found"})
+	sourceWins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "source_wins_total",
+		Help:      "Components whose licenses were taken from a source, by source ID (curation for local curation overrides).",
+	}, []string{"source_id"})
+	workers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "lookup_workers",
+		Help:      "Component lookup workers running, across all requests.",
+	})
+	busyWorkers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "lookup_workers_busy",
+		Help:      "Component lookup workers resolving a component, across all requests.",
+	})
+	queuedJobs = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "lookup_jobs_queued",
+		Help:      "Components waiting for a lookup worker, across all requests.",
+	})
+	queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Database query latency, by model method.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"method"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		rpcRequests, rpcDuration, httpRequests, httpDuration,
+		componentOutcomes, resolutionSteps, sourceWins,
+		workers, busyWorkers, queuedJobs, queryDuration,
+	)
+}
+
+// Handler serves the metrics of Registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// ObserveRPC records a gRPC request to rpc that answered with status after running since start.
+func ObserveRPC(rpc, status string, start time.Time) {
+	rpcRequests.WithLabelValues(rpc, status).Inc()
+	rpcDuration.WithLabelValues(rpc).Observe(time.Since(start).Seconds())
+}
+
+// ObserveHTTP records a REST request to route that answered with code after running since start.
+func ObserveHTTP(route string, code int, start time.Time) {
+	httpRequests.WithLabelValues(route, strconv.Itoa(code)).Inc()
+	httpDuration.WithLabelValues(route).Observe(time.Since(start).Seconds())
+}
+
+// ObserveComponentOutcome records a component lookup served with the given info code; empty means success.
+func ObserveComponentOutcome(infoCode string) {
+	if len(infoCode) == 0 {
+		infoCode = "SUCCESS"
+	}
+	componentOutcomes.WithLabelValues(infoCode).Inc()
+}
+
+// ObserveResolutionStep records a license resolution step and whether it found licenses.
+func ObserveResolutionStep(step string, found bool) {
+	resolutionSteps.WithLabelValues(step, strconv.FormatBool(found)).Inc()
+}
+
+// ObserveSourceWin records that a component's licenses were taken from the given source.
+func ObserveSourceWin(sourceID int16) {
+	sourceWins.WithLabelValues(strconv.Itoa(int(sourceID))).Inc()
+}
+
+// ObserveCurationWin records that a component's licenses were set by a local curation override.
+func ObserveCurationWin() {
+	sourceWins.WithLabelValues("curation").Inc()
+}
+
+// WorkerStarted records that a lookup worker started.
+func WorkerStarted() { workers.Inc() }
+
+// WorkerStopped records that a lookup worker exited.
+func WorkerStopped() { workers.Dec() }
+
+// JobsQueued records components added to a lookup worker pool queue.
+func JobsQueued(n int) { queuedJobs.Add(float64(n)) }
+
+// JobStarted records that a worker took a component off the queue, and returns a function to call once it is resolved.
+func JobStarted() func() {
+	queuedJobs.Dec()
+	busyWorkers.Inc()
+	return busyWorkers.Dec
+}
+
+// JobSkipped records that a worker dropped a queued component without resolving it.
+func JobSkipped() { queuedJobs.Dec() }
+
+// TimeQuery starts timing a database query run by the given model method.
+// Call the returned function when the query completes, usually with defer.
+func TimeQuery(method string) func() {
+	start := time.Now()
+	return func() {
+		queryDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	}
+}
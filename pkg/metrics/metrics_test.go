@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserve(t *testing.T) {
+	start := time.Now()
+	ObserveRPC("GetDetails", "SUCCESS", start)
+	assert.Equal(t, 1.0, testutil.ToFloat64(rpcRequests.WithLabelValues("GetDetails", "SUCCESS")))
+	ObserveHTTP("GET /v2/licenses/stats", http.StatusServiceUnavailable, start)
+	assert.Equal(t, 1.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET /v2/licenses/stats", "503")))
+
+	ObserveComponentOutcome("")
+	ObserveComponentOutcome("VERSION_NOT_FOUND")
+	assert.Equal(t, 1.0, testutil.ToFloat64(componentOutcomes.WithLabelValues("SUCCESS")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(componentOutcomes.WithLabelValues("VERSION_NOT_FOUND")))
+
+	ObserveResolutionStep("exact_version", false)
+	ObserveResolutionStep("unversioned", true)
+	assert.Equal(t, 1.0, testutil.ToFloat64(resolutionSteps.WithLabelValues("exact_version", "false")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(resolutionSteps.WithLabelValues("unversioned", "true")))
+
+	ObserveSourceWin(31)
+	assert.Equal(t, 1.0, testutil.ToFloat64(sourceWins.WithLabelValues("31")))
+
+	TimeQuery("LicenseModel.GetAllLicenses")()
+	assert.Equal(t, 1, testutil.CollectAndCount(queryDuration))
+}
+
+func TestWorkerPool(t *testing.T) {
+	WorkerStarted()
+	JobsQueued(3)
+	done := JobStarted()
+	assert.Equal(t, 1.0, testutil.ToFloat64(workers))
+	assert.Equal(t, 1.0, testutil.ToFloat64(busyWorkers))
+	assert.Equal(t, 2.0, testutil.ToFloat64(queuedJobs))
+	done()
+	JobSkipped()
+	JobSkipped()
+	WorkerStopped()
+	assert.Equal(t, 0.0, testutil.ToFloat64(workers))
+	assert.Equal(t, 0.0, testutil.ToFloat64(busyWorkers))
+	assert.Equal(t, 0.0, testutil.ToFloat64(queuedJobs))
+}
+
+func TestHandler(t *testing.T) {
+	ObserveSourceWin(5)
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	body := recorder.Body.String()
+	assert.Contains(t, body, `scanoss_licenses_source_wins_total{source_id="5"}`)
+	assert.Contains(t, body, "go_goroutines")
+}
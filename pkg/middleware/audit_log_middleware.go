@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+// Defaults and limits applied to audit log requests.
+const (
+	defaultAuditLogLimit = 100
+	maxAuditLogLimit     = 1000
+)
+
+type AuditLogMiddleware[TOutput any] struct {
+	query url.Values
+	MiddlewareBase
+}
+
+func NewAuditLogMiddleware(query url.Values, ctx context.Context) Middleware[dto.AuditLogRequestDTO] {
+	return &AuditLogMiddleware[dto.AuditLogRequestDTO]{
+		query:          query,
+		MiddlewareBase: MiddlewareBase{s: ctxzap.Extract(ctx).Sugar()},
+	}
+}
+
+// Process parses the query string: subject, actor, since and until (RFC 3339), after_seq and limit.
+func (m *AuditLogMiddleware[TOutput]) Process() (dto.AuditLogRequestDTO, error) {
+	request := dto.AuditLogRequestDTO{
+		Subject: strings.TrimSpace(m.query.Get("subject")),
+		Actor:   strings.TrimSpace(m.query.Get("actor")),
+		Limit:   defaultAuditLogLimit,
+	}
+	var err error
+	if request.Since, err = queryTime(m.query, "since"); err != nil {
+		return dto.AuditLogRequestDTO{}, err
+	}
+	if request.Until, err = queryTime(m.query, "until"); err != nil {
+		return dto.AuditLogRequestDTO{}, err
+	}
+	if value := m.query.Get("after_seq"); value != "" {
+		if request.AfterSeq, err = strconv.ParseInt(value, 10, 64); err != nil || request.AfterSeq < 0 {
+			return dto.AuditLogRequestDTO{}, fmt.Errorf("invalid after_seq %q: must be a sequence number", value)
+		}
+	}
+	if request.Limit, err = positiveQueryInt(m.query, "limit", request.Limit); err != nil {
+		return dto.AuditLogRequestDTO{}, err
+	}
+	if request.Limit > maxAuditLogLimit {
+		return dto.AuditLogRequestDTO{}, fmt.Errorf("invalid limit %d: must not exceed %d", request.Limit, maxAuditLogLimit)
+	}
+	return request, nil
+}
+
+// queryTime parses the RFC 3339 time in the named query parameter, returning the zero time when it is absent.
+func queryTime(query url.Values, name string) (time.Time, error) {
+	value := query.Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q: must be an RFC 3339 time", name, value)
+	}
+	return t, nil
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+func TestAuditLogMiddleware(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+
+	tests := []struct {
+		name      string
+		query     string
+		expected  dto.AuditLogRequestDTO
+		expectErr bool
+	}{
+		{
+			name:     "should apply the defaults",
+			query:    "",
+			expected: dto.AuditLogRequestDTO{Limit: defaultAuditLogLimit},
+		},
+		{
+			name:  "should parse all parameters",
+			query: "subject=policy&actor=jane&since=2026-01-01T00:00:00Z&until=2026-02-01T00:00:00%2B01:00&after_seq=10&limit=5",
+			expected: dto.AuditLogRequestDTO{Subject: "policy", Actor: "jane",
+				Since:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				Until:    time.Date(2026, 2, 1, 0, 0, 0, 0, time.FixedZone("", 3600)),
+				AfterSeq: 10, Limit: 5},
+		},
+		{name: "should reject an invalid since", query: "since=yesterday", expectErr: true},
+		{name: "should reject an invalid until", query: "until=2026-01-01", expectErr: true},
+		{name: "should reject an invalid after_seq", query: "after_seq=-1", expectErr: true},
+		{name: "should reject a limit over the maximum", query: "limit=5000", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			request, err := NewAuditLogMiddleware(query, ctx).Process()
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("Expected error, got %+v", request)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(request, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, request)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+type ComponentExtendedMiddleware[TOutput any] struct {
+	body io.Reader
+	MiddlewareBase
+}
+
+func NewComponentsExtendedRequestMiddleware(body io.Reader, ctx context.Context) Middleware[dto.ComponentsExtendedRequestDTO] {
+	return &ComponentExtendedMiddleware[dto.ComponentsExtendedRequestDTO]{
+		MiddlewareBase: MiddlewareBase{s: ctxzap.Extract(ctx).Sugar()},
+		body:           body,
+	}
+}
+
+func (m *ComponentExtendedMiddleware[TOutput]) Process() (dto.ComponentsExtendedRequestDTO, error) {
+	var request dto.ComponentsExtendedRequestDTO
+	if err := json.NewDecoder(m.body).Decode(&request); err != nil {
+		m.s.Errorf("Parse failure: %v", err)
+		return dto.ComponentsExtendedRequestDTO{}, errors.New("failed to parse request input data")
+	}
+	if len(request.Components) == 0 {
+		m.s.Warn("No components request data supplied to decorate. Ignoring request.")
+		return dto.ComponentsExtendedRequestDTO{}, errors.New("no components request data supplied")
+	}
+	for _, c := range request.Components {
+		if len(c.Purl) == 0 {
+			m.s.Warn("Component without purl supplied. Ignoring request.")
+			return dto.ComponentsExtendedRequestDTO{}, errors.New("no purl request data supplied for component")
+		}
+	}
+	if len(request.AsOf) > 0 {
+		if _, err := time.Parse(time.DateOnly, request.AsOf); err != nil {
+			m.s.Warnf("Invalid as_of date supplied: %v", request.AsOf)
+			return dto.ComponentsExtendedRequestDTO{}, errors.New("invalid as_of date, expected YYYY-MM-DD")
+		}
+	}
+	return request, nil
+}
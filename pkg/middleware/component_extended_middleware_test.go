@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestComponentExtendedMiddleware(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectErr      bool
+		expectedCount  int
+		expectedPrefer int
+	}{
+		{
+			name:           "should process components and preferences",
+			body:           `{"components":[{"purl":"pkg:npm/lodash","requirement":"4.17.21"},{"purl":"pkg:npm/react"}],"preferences":["MIT","Apache-2.0"]}`,
+			expectedCount:  2,
+			expectedPrefer: 2,
+		},
+		{
+			name:          "should process components without preferences",
+			body:          `{"components":[{"purl":"pkg:npm/lodash"}]}`,
+			expectedCount: 1,
+		},
+		{
+			name:      "should not process empty components",
+			body:      `{"components":[]}`,
+			expectErr: true,
+		},
+		{
+			name:      "should not process components without purl",
+			body:      `{"components":[{"requirement":"1.0.0"}]}`,
+			expectErr: true,
+		},
+		{
+			name:          "should process an as_of date",
+			body:          `{"components":[{"purl":"pkg:npm/lodash"}],"as_of":"2024-01-31"}`,
+			expectedCount: 1,
+		},
+		{
+			name:      "should not process an invalid as_of date",
+			body:      `{"components":[{"purl":"pkg:npm/lodash"}],"as_of":"31/01/2024"}`,
+			expectErr: true,
+		},
+		{
+			name:      "should not process invalid JSON",
+			body:      `{"components":`,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewComponentsExtendedRequestMiddleware(strings.NewReader(tt.body), ctx)
+			request, err := m.Process()
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("Expected error, got request %+v", request)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(request.Components) != tt.expectedCount {
+				t.Errorf("Expected %d components, got %d", tt.expectedCount, len(request.Components))
+			}
+			if len(request.Preferences) != tt.expectedPrefer {
+				t.Errorf("Expected %d preferences, got %d", tt.expectedPrefer, len(request.Preferences))
+			}
+		})
+	}
+}
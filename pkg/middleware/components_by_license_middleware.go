@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+// Defaults and limits applied to reverse license lookup requests.
+const (
+	defaultComponentsByLicenseLimit = 100
+	maxComponentsByLicenseLimit     = 1000
+)
+
+// purlTypeRegex matches a valid purl type (see the purl specification).
+var purlTypeRegex = regexp.MustCompile(`^[a-z][a-z0-9.+-]*$`)
+
+type ComponentsByLicenseMiddleware[TOutput any] struct {
+	query url.Values
+	MiddlewareBase
+}
+
+func NewComponentsByLicenseMiddleware(query url.Values, ctx context.Context) Middleware[dto.ComponentsByLicenseRequestDTO] {
+	return &ComponentsByLicenseMiddleware[dto.ComponentsByLicenseRequestDTO]{
+		query:          query,
+		MiddlewareBase: MiddlewareBase{s: ctxzap.Extract(ctx).Sugar()},
+	}
+}
+
+// Process parses the query string: license (required), purl_type, source (comma separated source IDs),
+// cursor and limit.
+func (m *ComponentsByLicenseMiddleware[TOutput]) Process() (dto.ComponentsByLicenseRequestDTO, error) {
+	request := dto.ComponentsByLicenseRequestDTO{
+		License:  strings.TrimSpace(m.query.Get("license")),
+		PurlType: strings.ToLower(strings.TrimSpace(m.query.Get("purl_type"))),
+		Cursor:   m.query.Get("cursor"),
+		Limit:    defaultComponentsByLicenseLimit,
+	}
+	if len(request.License) == 0 {
+		m.s.Warn("No license supplied for reverse lookup. Ignoring request.")
+		return dto.ComponentsByLicenseRequestDTO{}, errors.New("no license supplied")
+	}
+	if len(request.PurlType) > 0 && !purlTypeRegex.MatchString(request.PurlType) {
+		return dto.ComponentsByLicenseRequestDTO{}, fmt.Errorf("invalid purl_type %q", request.PurlType)
+	}
+	for _, source := range strings.Split(m.query.Get("source"), ",") {
+		source = strings.TrimSpace(source)
+		if source == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(source, 10, 16)
+		if err != nil || id < 0 {
+			return dto.ComponentsByLicenseRequestDTO{}, fmt.Errorf("invalid source %q: must be a source ID", source)
+		}
+		request.SourceIDs = append(request.SourceIDs, int16(id))
+	}
+	var err error
+	if request.Limit, err = positiveQueryInt(m.query, "limit", request.Limit); err != nil {
+		return dto.ComponentsByLicenseRequestDTO{}, err
+	}
+	if request.Limit > maxComponentsByLicenseLimit {
+		return dto.ComponentsByLicenseRequestDTO{}, fmt.Errorf("invalid limit %d: must not exceed %d", request.Limit, maxComponentsByLicenseLimit)
+	}
+	return request, nil
+}
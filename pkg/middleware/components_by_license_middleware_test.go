@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+func TestComponentsByLicenseMiddleware(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+
+	tests := []struct {
+		name      string
+		query     string
+		expected  dto.ComponentsByLicenseRequestDTO
+		expectErr bool
+	}{
+		{
+			name:     "should apply the defaults",
+			query:    "license=SSPL-1.0",
+			expected: dto.ComponentsByLicenseRequestDTO{License: "SSPL-1.0", Limit: defaultComponentsByLicenseLimit},
+		},
+		{
+			name:  "should parse all parameters",
+			query: "license=MIT+OR+Apache-2.0&purl_type=NPM&source=31,+5&cursor=abc&limit=10",
+			expected: dto.ComponentsByLicenseRequestDTO{License: "MIT OR Apache-2.0", PurlType: "npm", SourceIDs: []int16{31, 5},
+				Cursor: "abc", Limit: 10},
+		},
+		{name: "should require a license", query: "purl_type=npm", expectErr: true},
+		{name: "should reject an invalid purl type", query: "license=MIT&purl_type=n%25", expectErr: true},
+		{name: "should reject an invalid source", query: "license=MIT&source=abc", expectErr: true},
+		{name: "should reject a limit over the maximum", query: "license=MIT&limit=5000", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			request, err := NewComponentsByLicenseMiddleware(query, ctx).Process()
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("Expected error, got %+v", request)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(request, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, request)
+			}
+		})
+	}
+}
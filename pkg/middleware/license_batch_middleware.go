@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"scanoss.com/licenses/pkg/dto"
+	"scanoss.com/licenses/pkg/license"
+)
+
+// maxBatchLicenseIDs caps the number of license IDs a single batch request may ask for.
+const maxBatchLicenseIDs = 1000
+
+type LicenseBatchMiddleware[TOutput any] struct {
+	body io.Reader
+	MiddlewareBase
+}
+
+func NewLicenseBatchMiddleware(body io.Reader, ctx context.Context) Middleware[dto.LicenseBatchRequestDTO] {
+	return &LicenseBatchMiddleware[dto.LicenseBatchRequestDTO]{
+		body:           body,
+		MiddlewareBase: MiddlewareBase{s: ctxzap.Extract(ctx).Sugar()},
+	}
+}
+
+// Process decodes the request and returns it with IDs holding the de-duplicated list of
+// requested IDs followed by any licenses referenced by the expression.
+func (m *LicenseBatchMiddleware[TOutput]) Process() (dto.LicenseBatchRequestDTO, error) {
+	var request dto.LicenseBatchRequestDTO
+	if err := json.NewDecoder(m.body).Decode(&request); err != nil {
+		m.s.Errorf("Parse failure: %v", err)
+		return dto.LicenseBatchRequestDTO{}, errors.New("failed to parse request input data")
+	}
+	ids := request.IDs
+	if len(strings.TrimSpace(request.Expression)) > 0 {
+		expr, err := license.ParseExpression(request.Expression)
+		if err != nil {
+			m.s.Warnf("Invalid license expression %q: %v", request.Expression, err)
+			return dto.LicenseBatchRequestDTO{}, fmt.Errorf("invalid license expression: %v", err)
+		}
+		for _, l := range expr.Licenses() {
+			// Details are keyed by license, so drop any "WITH <exception>" suffix.
+			ids = append(ids, strings.Fields(l)[0])
+		}
+	}
+	request.IDs = dedupLicenseIDs(ids)
+	if len(request.IDs) == 0 {
+		m.s.Warn("No license request data supplied to decorate. Ignoring request.")
+		return dto.LicenseBatchRequestDTO{}, errors.New("no license request data supplied")
+	}
+	if len(request.IDs) > maxBatchLicenseIDs {
+		return dto.LicenseBatchRequestDTO{}, fmt.Errorf("too many license IDs requested: %d (max %d)", len(request.IDs), maxBatchLicenseIDs)
+	}
+	return request, nil
+}
+
+// dedupLicenseIDs trims the IDs and removes empty and case-insensitive duplicate entries, keeping order.
+func dedupLicenseIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		trimmed := strings.TrimSpace(id)
+		key := strings.ToUpper(trimmed)
+		if trimmed == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, trimmed)
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestLicenseBatchMiddleware(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+
+	tests := []struct {
+		name      string
+		body      string
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "should process a list of IDs",
+			body:     `{"ids":["MIT","Apache-2.0"]}`,
+			expected: []string{"MIT", "Apache-2.0"},
+		},
+		{
+			name:     "should extract IDs from an expression",
+			body:     `{"expression":"MIT OR (GPL-2.0-only WITH Classpath-exception-2.0 AND ISC)"}`,
+			expected: []string{"MIT", "GPL-2.0-only", "ISC"},
+		},
+		{
+			name:     "should merge and de-duplicate IDs and expression",
+			body:     `{"ids":["mit"," ISC ",""],"expression":"MIT AND Apache-2.0"}`,
+			expected: []string{"mit", "ISC", "Apache-2.0"},
+		},
+		{
+			name:      "should not process an invalid expression",
+			body:      `{"expression":"MIT OR"}`,
+			expectErr: true,
+		},
+		{
+			name:      "should not process an empty request",
+			body:      `{}`,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := NewLicenseBatchMiddleware(strings.NewReader(tt.body), ctx).Process()
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("Expected error, got %+v", request)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(request.IDs, tt.expected) {
+				t.Errorf("Expected IDs %v, got %v", tt.expected, request.IDs)
+			}
+		})
+	}
+
+	t.Run("should reject more than the maximum distinct IDs", func(t *testing.T) {
+		ids := make([]string, 0, maxBatchLicenseIDs+1)
+		for i := 0; i <= maxBatchLicenseIDs; i++ {
+			ids = append(ids, fmt.Sprintf(`"LicenseRef-%d"`, i))
+		}
+		body := `{"ids":[` + strings.Join(ids, ",") + `]}`
+		if _, err := NewLicenseBatchMiddleware(strings.NewReader(body), ctx).Process(); err == nil {
+			t.Error("Expected error for too many IDs")
+		}
+	})
+}
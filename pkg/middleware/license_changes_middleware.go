@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+// Defaults and limits applied to license changes feed requests.
+const (
+	defaultLicenseChangesLimit = 100
+	maxLicenseChangesLimit     = 1000
+	maxLicenseChangesPurls     = 1000
+)
+
+type LicenseChangesMiddleware[TOutput any] struct {
+	query url.Values
+	MiddlewareBase
+}
+
+func NewLicenseChangesMiddleware(query url.Values, ctx context.Context) Middleware[dto.LicenseChangesRequestDTO] {
+	return &LicenseChangesMiddleware[dto.LicenseChangesRequestDTO]{
+		query:          query,
+		MiddlewareBase: MiddlewareBase{s: ctxzap.Extract(ctx).Sugar()},
+	}
+}
+
+// Process parses the query string: since or cursor (one is required), purl_type, purl (repeated, one per
+// watched purl) and limit.
+func (m *LicenseChangesMiddleware[TOutput]) Process() (dto.LicenseChangesRequestDTO, error) {
+	request := dto.LicenseChangesRequestDTO{
+		Since:    m.query.Get("since"),
+		PurlType: m.query.Get("purl_type"),
+		Purls:    m.query["purl"],
+		Cursor:   m.query.Get("cursor"),
+		Limit:    defaultLicenseChangesLimit,
+	}
+	var err error
+	if request.Limit, err = positiveQueryInt(m.query, "limit", request.Limit); err != nil {
+		return dto.LicenseChangesRequestDTO{}, err
+	}
+	return validateLicenseChanges(m.s, request)
+}
+
+type LicenseChangesBodyMiddleware[TOutput any] struct {
+	body io.Reader
+	MiddlewareBase
+}
+
+func NewLicenseChangesBodyMiddleware(body io.Reader, ctx context.Context) Middleware[dto.LicenseChangesRequestDTO] {
+	return &LicenseChangesBodyMiddleware[dto.LicenseChangesRequestDTO]{
+		body:           body,
+		MiddlewareBase: MiddlewareBase{s: ctxzap.Extract(ctx).Sugar()},
+	}
+}
+
+// Process parses a JSON request body, for watched purl lists too long for a query string.
+func (m *LicenseChangesBodyMiddleware[TOutput]) Process() (dto.LicenseChangesRequestDTO, error) {
+	var request dto.LicenseChangesRequestDTO
+	if err := json.NewDecoder(m.body).Decode(&request); err != nil {
+		m.s.Errorf("Parse failure: %v", err)
+		return dto.LicenseChangesRequestDTO{}, errors.New("failed to parse request input data")
+	}
+	if request.Limit == 0 {
+		request.Limit = defaultLicenseChangesLimit
+	} else if request.Limit < 0 {
+		return dto.LicenseChangesRequestDTO{}, fmt.Errorf("invalid limit %d: must be a positive integer", request.Limit)
+	}
+	return validateLicenseChanges(m.s, request)
+}
+
+// validateLicenseChanges normalizes a changes feed request, whichever way it was supplied: since is reduced to a date,
+// the purl type is lower-cased and the watched purls are trimmed.
+func validateLicenseChanges(s *zap.SugaredLogger, request dto.LicenseChangesRequestDTO) (dto.LicenseChangesRequestDTO, error) {
+	request.Since = strings.TrimSpace(request.Since)
+	request.PurlType = strings.ToLower(strings.TrimSpace(request.PurlType))
+	if len(request.Since) == 0 && len(request.Cursor) == 0 {
+		s.Warn("No since date or cursor supplied for the changes feed. Ignoring request.")
+		return dto.LicenseChangesRequestDTO{}, errors.New("no since date or cursor supplied")
+	}
+	if len(request.Since) > 0 {
+		since, err := parseSince(request.Since)
+		if err != nil {
+			return dto.LicenseChangesRequestDTO{}, err
+		}
+		request.Since = since
+	}
+	if len(request.PurlType) > 0 && !purlTypeRegex.MatchString(request.PurlType) {
+		return dto.LicenseChangesRequestDTO{}, fmt.Errorf("invalid purl_type %q", request.PurlType)
+	}
+	if len(request.Purls) > maxLicenseChangesPurls {
+		return dto.LicenseChangesRequestDTO{}, fmt.Errorf("too many purls %d: must not exceed %d", len(request.Purls), maxLicenseChangesPurls)
+	}
+	purls := make([]string, 0, len(request.Purls))
+	for _, purl := range request.Purls {
+		purl = strings.TrimSpace(purl)
+		if !strings.HasPrefix(purl, "pkg:") || strings.Contains(purl, "@") {
+			return dto.LicenseChangesRequestDTO{}, fmt.Errorf("invalid purl %q: must be a purl without a version", purl)
+		}
+		purls = append(purls, purl)
+	}
+	request.Purls = nil
+	if len(purls) > 0 {
+		request.Purls = purls
+	}
+	if request.Limit > maxLicenseChangesLimit {
+		return dto.LicenseChangesRequestDTO{}, fmt.Errorf("invalid limit %d: must not exceed %d", request.Limit, maxLicenseChangesLimit)
+	}
+	return request, nil
+}
+
+// parseSince reads a since parameter given as a date (YYYY-MM-DD) or an RFC 3339 timestamp, returning its UTC date.
+// License rows are dated by day, so a timestamp covers its whole day.
+func parseSince(since string) (string, error) {
+	if t, err := time.Parse(time.DateOnly, since); err == nil {
+		return t.Format(time.DateOnly), nil
+	}
+	t, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return "", fmt.Errorf("invalid since %q: must be a date (YYYY-MM-DD) or an RFC 3339 timestamp", since)
+	}
+	return t.UTC().Format(time.DateOnly), nil
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+func TestLicenseChangesMiddleware(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+
+	tests := []struct {
+		name      string
+		query     string
+		expected  dto.LicenseChangesRequestDTO
+		expectErr bool
+	}{
+		{
+			name:     "should apply the defaults",
+			query:    "since=2024-01-31",
+			expected: dto.LicenseChangesRequestDTO{Since: "2024-01-31", Limit: defaultLicenseChangesLimit},
+		},
+		{
+			name:  "should parse all parameters",
+			query: "since=2024-01-31T23:30:00-02:00&purl_type=NPM&purl=pkg:npm/express&purl=+pkg:npm/lodash&cursor=abc&limit=10",
+			expected: dto.LicenseChangesRequestDTO{Since: "2024-02-01", PurlType: "npm", Purls: []string{"pkg:npm/express", "pkg:npm/lodash"},
+				Cursor: "abc", Limit: 10},
+		},
+		{
+			name:     "should accept a cursor alone",
+			query:    "cursor=abc",
+			expected: dto.LicenseChangesRequestDTO{Cursor: "abc", Limit: defaultLicenseChangesLimit},
+		},
+		{name: "should require since or a cursor", query: "purl_type=npm", expectErr: true},
+		{name: "should reject an invalid since", query: "since=yesterday", expectErr: true},
+		{name: "should reject an invalid purl type", query: "since=2024-01-31&purl_type=n%25", expectErr: true},
+		{name: "should reject a purl with a version", query: "since=2024-01-31&purl=pkg:npm/express@4.18.2", expectErr: true},
+		{name: "should reject a limit over the maximum", query: "since=2024-01-31&limit=5000", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			request, err := NewLicenseChangesMiddleware(query, ctx).Process()
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("Expected error, got %+v", request)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(request, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, request)
+			}
+		})
+	}
+}
+
+func TestLicenseChangesBodyMiddleware(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+
+	request, err := NewLicenseChangesBodyMiddleware(strings.NewReader(
+		`{"since":"2024-01-31","purls":["pkg:npm/express","pkg:pypi/requests"]}`), ctx).Process()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := dto.LicenseChangesRequestDTO{Since: "2024-01-31", Purls: []string{"pkg:npm/express", "pkg:pypi/requests"},
+		Limit: defaultLicenseChangesLimit}
+	if !reflect.DeepEqual(request, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, request)
+	}
+	for _, body := range []string{`{"since":`, `{"purls":["pkg:npm/express"]}`, `{"since":"2024-01-31","limit":-1}`} {
+		if request, err = NewLicenseChangesBodyMiddleware(strings.NewReader(body), ctx).Process(); err == nil {
+			t.Errorf("Expected error for %s, got %+v", body, request)
+		}
+	}
+}
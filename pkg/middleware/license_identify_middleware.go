@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+// Defaults and limits applied to license identification requests.
+const (
+	defaultIdentifyLimit    = 5
+	maxIdentifyLimit        = 50
+	defaultIdentifyMinScore = 0.3
+)
+
+type LicenseIdentifyMiddleware[TOutput any] struct {
+	body io.Reader
+	MiddlewareBase
+}
+
+func NewLicenseIdentifyMiddleware(body io.Reader, ctx context.Context) Middleware[dto.LicenseIdentifyRequestDTO] {
+	return &LicenseIdentifyMiddleware[dto.LicenseIdentifyRequestDTO]{
+		body:           body,
+		MiddlewareBase: MiddlewareBase{s: ctxzap.Extract(ctx).Sugar()},
+	}
+}
+
+// Process decodes the request, validates it and fills in the default limit and minimum score.
+func (m *LicenseIdentifyMiddleware[TOutput]) Process() (dto.LicenseIdentifyRequestDTO, error) {
+	var request dto.LicenseIdentifyRequestDTO
+	if err := json.NewDecoder(m.body).Decode(&request); err != nil {
+		m.s.Errorf("Parse failure: %v", err)
+		return dto.LicenseIdentifyRequestDTO{}, errors.New("failed to parse request input data")
+	}
+	if len(strings.TrimSpace(request.Text)) == 0 {
+		m.s.Warn("No license text supplied to identify. Ignoring request.")
+		return dto.LicenseIdentifyRequestDTO{}, errors.New("no license text supplied")
+	}
+	if request.Limit < 0 || request.Limit > maxIdentifyLimit {
+		return dto.LicenseIdentifyRequestDTO{}, fmt.Errorf("invalid limit: %d (must be between 1 and %d)", request.Limit, maxIdentifyLimit)
+	}
+	if request.Limit == 0 {
+		request.Limit = defaultIdentifyLimit
+	}
+	if request.MinScore < 0 || request.MinScore > 1 {
+		return dto.LicenseIdentifyRequestDTO{}, fmt.Errorf("invalid min_score: %v (must be between 0 and 1)", request.MinScore)
+	}
+	if request.MinScore == 0 {
+		request.MinScore = defaultIdentifyMinScore
+	}
+	return request, nil
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+func TestLicenseIdentifyMiddleware(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+
+	tests := []struct {
+		name      string
+		body      string
+		expected  dto.LicenseIdentifyRequestDTO
+		expectErr bool
+	}{
+		{
+			name:     "should apply the defaults",
+			body:     `{"text":"Permission is hereby granted"}`,
+			expected: dto.LicenseIdentifyRequestDTO{Text: "Permission is hereby granted", Limit: defaultIdentifyLimit, MinScore: defaultIdentifyMinScore},
+		},
+		{
+			name:     "should keep the supplied limit and score",
+			body:     `{"text":"Permission is hereby granted","limit":10,"min_score":0.8}`,
+			expected: dto.LicenseIdentifyRequestDTO{Text: "Permission is hereby granted", Limit: 10, MinScore: 0.8},
+		},
+		{
+			name:      "should not process an empty text",
+			body:      `{"text":"  "}`,
+			expectErr: true,
+		},
+		{
+			name:      "should not process a limit over the maximum",
+			body:      `{"text":"x","limit":1000}`,
+			expectErr: true,
+		},
+		{
+			name:      "should not process a score over 1",
+			body:      `{"text":"x","min_score":1.5}`,
+			expectErr: true,
+		},
+		{
+			name:      "should not process invalid JSON",
+			body:      `{"text":`,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := NewLicenseIdentifyMiddleware(strings.NewReader(tt.body), ctx).Process()
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("Expected error, got %+v", request)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if request != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, request)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+// Defaults and limits applied to license search requests.
+const (
+	defaultSearchPageSize = 50
+	maxSearchPageSize     = 500
+)
+
+type LicenseSearchMiddleware[TOutput any] struct {
+	query url.Values
+	MiddlewareBase
+}
+
+func NewLicenseSearchMiddleware(query url.Values, ctx context.Context) Middleware[dto.LicenseSearchRequestDTO] {
+	return &LicenseSearchMiddleware[dto.LicenseSearchRequestDTO]{
+		query:          query,
+		MiddlewareBase: MiddlewareBase{s: ctxzap.Extract(ctx).Sugar()},
+	}
+}
+
+// Process parses the query string: q, the osi, fsf, deprecated, spdx and copyleft boolean filters,
+// and the page (1-based) and page_size pagination parameters.
+func (m *LicenseSearchMiddleware[TOutput]) Process() (dto.LicenseSearchRequestDTO, error) {
+	request := dto.LicenseSearchRequestDTO{
+		Query:    strings.TrimSpace(m.query.Get("q")),
+		Page:     1,
+		PageSize: defaultSearchPageSize,
+	}
+	filters := map[string]**bool{
+		"osi":        &request.OSIApproved,
+		"fsf":        &request.FSFLibre,
+		"deprecated": &request.Deprecated,
+		"spdx":       &request.SPDX,
+		"copyleft":   &request.Copyleft,
+	}
+	for name, target := range filters {
+		value := m.query.Get(name)
+		if value == "" {
+			continue
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			m.s.Warnf("Invalid %s filter: %q", name, value)
+			return dto.LicenseSearchRequestDTO{}, fmt.Errorf("invalid %s filter %q: must be true or false", name, value)
+		}
+		*target = &b
+	}
+	var err error
+	if request.Page, err = positiveQueryInt(m.query, "page", request.Page); err != nil {
+		return dto.LicenseSearchRequestDTO{}, err
+	}
+	if request.PageSize, err = positiveQueryInt(m.query, "page_size", request.PageSize); err != nil {
+		return dto.LicenseSearchRequestDTO{}, err
+	}
+	if request.PageSize > maxSearchPageSize {
+		return dto.LicenseSearchRequestDTO{}, fmt.Errorf("invalid page_size %d: must not exceed %d", request.PageSize, maxSearchPageSize)
+	}
+	return request, nil
+}
+
+// positiveQueryInt parses the named query parameter as a positive integer, returning def when it is absent.
+func positiveQueryInt(query url.Values, name string, def int) (int, error) {
+	value := query.Get(name)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid %s %q: must be a positive integer", name, value)
+	}
+	return n, nil
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestLicenseSearchMiddleware(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+
+	t.Run("should apply the defaults", func(t *testing.T) {
+		request, err := NewLicenseSearchMiddleware(url.Values{}, ctx).Process()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if request.Page != 1 || request.PageSize != defaultSearchPageSize || request.OSIApproved != nil || request.Copyleft != nil {
+			t.Errorf("Unexpected defaults: %+v", request)
+		}
+	})
+
+	t.Run("should parse the query and filters", func(t *testing.T) {
+		query, _ := url.ParseQuery("q=+gpl+&osi=true&copyleft=false&spdx=1&page=3&page_size=20")
+		request, err := NewLicenseSearchMiddleware(query, ctx).Process()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if request.Query != "gpl" || request.Page != 3 || request.PageSize != 20 {
+			t.Errorf("Unexpected request: %+v", request)
+		}
+		if request.OSIApproved == nil || !*request.OSIApproved || request.Copyleft == nil || *request.Copyleft ||
+			request.SPDX == nil || !*request.SPDX || request.FSFLibre != nil || request.Deprecated != nil {
+			t.Errorf("Unexpected filters: %+v", request)
+		}
+	})
+
+	for _, raw := range []string{"osi=maybe", "page=0", "page=abc", "page_size=-1", "page_size=10000"} {
+		t.Run("should reject "+raw, func(t *testing.T) {
+			query, _ := url.ParseQuery(raw)
+			if request, err := NewLicenseSearchMiddleware(query, ctx).Process(); err == nil {
+				t.Errorf("Expected error, got %+v", request)
+			}
+		})
+	}
+}
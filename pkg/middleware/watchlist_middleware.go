@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+// maxWatchlistComponents caps the number of components added to the watchlist by one request.
+const maxWatchlistComponents = 1000
+
+type WatchlistMiddleware[TOutput any] struct {
+	body io.Reader
+	MiddlewareBase
+}
+
+func NewWatchlistMiddleware(body io.Reader, ctx context.Context) Middleware[dto.WatchlistRequestDTO] {
+	return &WatchlistMiddleware[dto.WatchlistRequestDTO]{
+		body:           body,
+		MiddlewareBase: MiddlewareBase{s: ctxzap.Extract(ctx).Sugar()},
+	}
+}
+
+// Process parses the components to watch from the JSON body, trimming their purls and requirements.
+func (m *WatchlistMiddleware[TOutput]) Process() (dto.WatchlistRequestDTO, error) {
+	var request dto.WatchlistRequestDTO
+	if err := json.NewDecoder(m.body).Decode(&request); err != nil {
+		m.s.Errorf("Parse failure: %v", err)
+		return dto.WatchlistRequestDTO{}, errors.New("failed to parse request input data")
+	}
+	if len(request.Components) == 0 {
+		m.s.Warn("No components supplied to watch. Ignoring request.")
+		return dto.WatchlistRequestDTO{}, errors.New("no components supplied")
+	}
+	if len(request.Components) > maxWatchlistComponents {
+		return dto.WatchlistRequestDTO{}, fmt.Errorf("too many components %d: must not exceed %d", len(request.Components), maxWatchlistComponents)
+	}
+	for i, c := range request.Components {
+		c.Purl = strings.TrimSpace(c.Purl)
+		c.Requirement = strings.TrimSpace(c.Requirement)
+		if len(c.Purl) == 0 {
+			return dto.WatchlistRequestDTO{}, errors.New("no purl supplied for component")
+		}
+		request.Components[i] = c
+	}
+	return request, nil
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+func TestWatchlistMiddleware(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+
+	tests := []struct {
+		name      string
+		body      string
+		expected  dto.WatchlistRequestDTO
+		expectErr bool
+	}{
+		{
+			name: "should process components",
+			body: `{"components":[{"purl":" pkg:npm/express ","requirement":"^4.18"},{"purl":"pkg:npm/lodash"}]}`,
+			expected: dto.WatchlistRequestDTO{Components: []dto.WatchKeyDTO{
+				{Purl: "pkg:npm/express", Requirement: "^4.18"}, {Purl: "pkg:npm/lodash"},
+			}},
+		},
+		{name: "should not process empty components", body: `{"components":[]}`, expectErr: true},
+		{name: "should not process components without purl", body: `{"components":[{"requirement":"1.0.0"}]}`, expectErr: true},
+		{name: "should not process invalid JSON", body: `{"components":`, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := NewWatchlistMiddleware(strings.NewReader(tt.body), ctx).Process()
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("Expected error, got %+v", request)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(request, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, request)
+			}
+		})
+	}
+}
@@ -28,8 +28,21 @@ import (
 	"github.com/jmoiron/sqlx"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
 	_ "modernc.org/sqlite"
+	"scanoss.com/licenses/pkg/metrics"
+	"scanoss.com/licenses/pkg/tracing"
 )
 
+// startQuery starts the span and latency timer of a database query run by the given model method.
+// Call the returned function when the query completes, usually with defer.
+func startQuery(ctx context.Context, method string) (context.Context, func()) {
+	ctx, span := tracing.Start(ctx, method, tracing.DBOperation.String(method))
+	done := metrics.TimeQuery(method)
+	return ctx, func() {
+		done()
+		span.End()
+	}
+}
+
 // loadSQLData Load the specified SQL files into the supplied DB.
 func loadSQLData(db *sqlx.DB, ctx context.Context, filename string) error {
 	fmt.Printf("Loading test data file: %v\n", filename)
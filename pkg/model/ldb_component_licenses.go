@@ -22,6 +22,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"github.com/jmoiron/sqlx"
@@ -60,6 +61,8 @@ func NewLDBComponentLicensesModel(db *sqlx.DB) *LDBComponentLicensesModel {
 }
 
 func (lcl *LDBComponentLicensesModel) GetLicensesByPurlMD5(ctx context.Context, purlMD5 string) ([]LDBComponentLicense, error) {
+	ctx, end := startQuery(ctx, "LDBComponentLicensesModel.GetLicensesByPurlMD5")
+	defer end()
 	s := ctxzap.Extract(ctx).Sugar()
 
 	if len(purlMD5) == 0 {
@@ -83,6 +86,39 @@ func (lcl *LDBComponentLicensesModel) GetLicensesByPurlMD5(ctx context.Context,
 	return componentLicenses, nil
 }
 
+// GetLicensesByPurlMD5s retrieves the licenses of several components at once, given the MD5s of their purl@version.
+func (lcl *LDBComponentLicensesModel) GetLicensesByPurlMD5s(ctx context.Context, purlMD5s []string) ([]LDBComponentLicense, error) {
+	ctx, end := startQuery(ctx, "LDBComponentLicensesModel.GetLicensesByPurlMD5s")
+	defer end()
+	s := ctxzap.Extract(ctx).Sugar()
+
+	if len(purlMD5s) == 0 {
+		s.Error("Please specify at least one Purl MD5 to query")
+		return nil, errors.New("please specify at least one purlMD5 to query")
+	}
+
+	args := make([]interface{}, len(purlMD5s))
+	placeholders := make([]string, len(purlMD5s))
+	for i, purlMD5 := range purlMD5s {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = purlMD5
+	}
+	query := fmt.Sprintf("SELECT lcl.purl_md5, lcl.source, lcl.license_id, l.license_name as license"+
+		" FROM ldb_component_licenses lcl"+
+		" LEFT JOIN licenses l ON lcl.license_id = l.id"+
+		" WHERE purl_md5 IN (%s)", strings.Join(placeholders, ","))
+
+	var componentLicenses []LDBComponentLicense
+	err := lcl.db.SelectContext(ctx, &componentLicenses, query, args...)
+	if err != nil {
+		s.Errorf("Failed to query ldb_component_licenses table for %d purl MD5s: %v", len(purlMD5s), err)
+		return nil, fmt.Errorf("failed to query the ldb_component_licenses table: %v", err)
+	}
+
+	s.Debugf("Found %v results for %d purl MD5s", len(componentLicenses), len(purlMD5s))
+	return componentLicenses, nil
+}
+
 // CalculateMD5FromPurlVersion generates MD5 hash from component and version.
 func (lcl *LDBComponentLicensesModel) CalculateMD5FromPurlVersion(purl, version string) string {
 	purlString := fmt.Sprintf("%s@%s", purl, version)
@@ -211,3 +211,34 @@ func TestGetLicensesByPurlMD5_DatabaseError(t *testing.T) {
 		t.Errorf("GetLicensesByPurlMD5() should return error when table doesn't exist")
 	}
 }
+
+func TestGetLicensesByPurlMD5s(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db := sqliteSetup(t)
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db)
+	defer CloseConn(conn)
+
+	err = loadTestSQLDataFiles(db, ctx, []string{"tests/licenses.sql", "tests/ldb_component_licenses.sql"})
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	ldbModel := NewLDBComponentLicensesModel(db)
+
+	licenses, err := ldbModel.GetLicensesByPurlMD5s(ctx, []string{"abc123def456789", "dual456license", "nonexistent123"})
+	if err != nil {
+		t.Fatalf("GetLicensesByPurlMD5s() unexpected error = %v", err)
+	}
+	if len(licenses) != 3 {
+		t.Errorf("GetLicensesByPurlMD5s() returned %d licenses, expected 3", len(licenses))
+	}
+
+	if _, err = ldbModel.GetLicensesByPurlMD5s(ctx, nil); err == nil {
+		t.Errorf("GetLicensesByPurlMD5s() with no MD5s should return an error")
+	}
+}
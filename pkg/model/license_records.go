@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle the reverse lookups on the licenses table (license_name/spdx_id to license row IDs)
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// LicenseRecordModel searches the licenses table referenced by purl_licenses.license_id.
+type LicenseRecordModel struct {
+	db *sqlx.DB
+}
+
+// LicenseRecord is a row of the licenses table referenced by purl_licenses.license_id.
+type LicenseRecord struct {
+	ID          int32  `json:"id" db:"id"`
+	LicenseName string `json:"license_name" db:"license_name"`
+	SPDX        string `json:"spdx_id" db:"spdx_id"`
+	IsSPDX      bool   `json:"is_spdx" db:"is_spdx"`
+}
+
+// NewLicenseRecordModel creates a new instance of the LicenseRecord Model.
+func NewLicenseRecordModel(db *sqlx.DB) *LicenseRecordModel {
+	return &LicenseRecordModel{db: db}
+}
+
+// GetLicenseRecordsContaining retrieves the licenses rows whose spdx_id or license_name contains
+// the given text, ignoring case. Callers are expected to refine the (over-inclusive) result.
+func (m *LicenseRecordModel) GetLicenseRecordsContaining(ctx context.Context, s *zap.SugaredLogger, text string) ([]LicenseRecord, error) {
+	ctx, end := startQuery(ctx, "LicenseRecordModel.GetLicenseRecordsContaining")
+	defer end()
+	pattern := "%" + escapeLike(strings.ToUpper(text)) + "%"
+	var records []LicenseRecord
+	err := m.db.SelectContext(ctx, &records,
+		`SELECT id, license_name, spdx_id, is_spdx FROM licenses WHERE UPPER(spdx_id) LIKE $1 ESCAPE '\' OR UPPER(license_name) LIKE $1 ESCAPE '\'`,
+		pattern)
+	if err != nil {
+		s.Errorf("Error: Failed to query licenses table for %v: %v", text, err)
+		return nil, fmt.Errorf("failed to query the licenses table: %v", err)
+	}
+	return records, nil
+}
+
+// escapeLike escapes the LIKE wildcards in s, for use with ESCAPE '\'.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestGetLicenseRecordsContaining(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	err = loadTestSQLDataFiles(db, ctx, []string{"tests/licenses.sql"})
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	model := NewLicenseRecordModel(db)
+
+	tests := []struct {
+		text     string
+		expected int
+	}{
+		{text: "apache-2.0", expected: 2},
+		{text: "MIT", expected: 2},
+		{text: "100%", expected: 0},
+		{text: "does-not-exist", expected: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			records, err := model.GetLicenseRecordsContaining(ctx, s, test.text)
+			if err != nil {
+				t.Fatalf("GetLicenseRecordsContaining() unexpected error = %v", err)
+			}
+			if len(records) != test.expected {
+				t.Errorf("GetLicenseRecordsContaining() got %d records, want %d: %v", len(records), test.expected, records)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle the bulk reads of the purl_licenses and licenses tables used to build the knowledge base statistics
+
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+type LicenseStatsModelInterface interface {
+	GetAllLicenseRecords(ctx context.Context, s *zap.SugaredLogger) ([]LicenseRecord, error)
+	ForEachPurlLicense(ctx context.Context, s *zap.SugaredLogger, fn func(PurlLicense) error) error
+}
+
+// LicenseStatsModel reads whole tables for the statistics, which are computed in the background.
+type LicenseStatsModel struct {
+	db *sqlx.DB
+}
+
+// NewLicenseStatsModel creates a new instance of the LicenseStats Model.
+func NewLicenseStatsModel(db *sqlx.DB) *LicenseStatsModel {
+	return &LicenseStatsModel{db: db}
+}
+
+// GetAllLicenseRecords retrieves every row of the licenses table referenced by purl_licenses.license_id.
+func (m *LicenseStatsModel) GetAllLicenseRecords(ctx context.Context, s *zap.SugaredLogger) ([]LicenseRecord, error) {
+	ctx, end := startQuery(ctx, "LicenseStatsModel.GetAllLicenseRecords")
+	defer end()
+	var records []LicenseRecord
+	err := m.db.SelectContext(ctx, &records, "SELECT id, license_name, spdx_id, is_spdx FROM licenses")
+	if err != nil {
+		s.Errorf("Error: Failed to query all licenses: %v", err)
+		return nil, fmt.Errorf("failed to query the licenses table: %v", err)
+	}
+	return records, nil
+}
+
+// ForEachPurlLicense streams the purl, source_id and license_id of every purl_licenses row to fn,
+// ordered by purl so all the rows of a component are delivered together. Version and date are not read.
+// Iteration stops at the first error returned by fn.
+func (m *LicenseStatsModel) ForEachPurlLicense(ctx context.Context, s *zap.SugaredLogger, fn func(PurlLicense) error) error {
+	ctx, end := startQuery(ctx, "LicenseStatsModel.ForEachPurlLicense")
+	defer end()
+	rows, err := m.db.QueryxContext(ctx, "SELECT purl, source_id, license_id FROM purl_licenses ORDER BY purl")
+	if err != nil {
+		s.Errorf("Error: Failed to query purl_licenses table: %v", err)
+		return fmt.Errorf("failed to query the purl_licenses table: %v", err)
+	}
+	defer CloseRows(rows)
+	for rows.Next() {
+		var purlLicense PurlLicense
+		if err = rows.StructScan(&purlLicense); err != nil {
+			s.Errorf("Error: Failed to read purl_licenses row: %v", err)
+			return fmt.Errorf("failed to read the purl_licenses table: %v", err)
+		}
+		if err = fn(purlLicense); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		s.Errorf("Error: Failed to read purl_licenses table: %v", err)
+		return fmt.Errorf("failed to read the purl_licenses table: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestLicenseStatsModel(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	err = loadTestSQLDataFiles(db, ctx, []string{"tests/licenses.sql", "tests/purl_licenses.sql"})
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	model := NewLicenseStatsModel(db)
+
+	records, err := model.GetAllLicenseRecords(ctx, s)
+	if err != nil {
+		t.Fatalf("GetAllLicenseRecords() unexpected error = %v", err)
+	}
+	if len(records) == 0 {
+		t.Errorf("GetAllLicenseRecords() returned no records")
+	}
+
+	var rows []PurlLicense
+	err = model.ForEachPurlLicense(ctx, s, func(pl PurlLicense) error {
+		rows = append(rows, pl)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEachPurlLicense() unexpected error = %v", err)
+	}
+	if len(rows) != 14 {
+		t.Errorf("ForEachPurlLicense() got %d rows, want 14", len(rows))
+	}
+	for i := 1; i < len(rows); i++ {
+		if rows[i-1].Purl > rows[i].Purl {
+			t.Errorf("ForEachPurlLicense() rows not ordered by purl: %v before %v", rows[i-1].Purl, rows[i].Purl)
+		}
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = model.ForEachPurlLicense(ctx, s, func(PurlLicense) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("ForEachPurlLicense() expected to stop at the first error, got %v after %d calls", err, calls)
+	}
+}
@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle all interaction with the license text store (license_texts table or a license-list-data directory)
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+type LicenseTextModelInterface interface {
+	GetLicenseTextByID(ctx context.Context, s *zap.SugaredLogger, id string) (LicenseText, error)
+	GetAllLicenseTexts(ctx context.Context, s *zap.SugaredLogger) ([]LicenseText, error)
+}
+
+// LicenseText holds the text of a license. An empty LicenseID means the license was not found.
+type LicenseText struct {
+	LicenseID        string `json:"licenseId" db:"license_id"`
+	Name             string `json:"name" db:"name"`
+	Text             string `json:"licenseText" db:"license_text"`
+	StandardHeader   string `json:"standardLicenseHeader" db:"standard_header"`
+	StandardTemplate string `json:"standardLicenseTemplate" db:"standard_template"`
+}
+
+// LicenseTextModel reads license texts from the license_texts table.
+type LicenseTextModel struct {
+	db *sqlx.DB
+}
+
+// NewLicenseTextModel creates a new instance of the LicenseText Model.
+func NewLicenseTextModel(db *sqlx.DB) *LicenseTextModel {
+	return &LicenseTextModel{db: db}
+}
+
+// GetLicenseTextByID retrieves the text of the given license ID, matched case-insensitively.
+func (m *LicenseTextModel) GetLicenseTextByID(ctx context.Context, s *zap.SugaredLogger, licenseID string) (LicenseText, error) {
+	ctx, end := startQuery(ctx, "LicenseTextModel.GetLicenseTextByID")
+	defer end()
+	licenseIDToUpper := strings.ToUpper(licenseID)
+	var text LicenseText
+	err := m.db.QueryRowxContext(ctx,
+		"SELECT license_id, COALESCE(name, '') AS name, license_text, COALESCE(standard_header, '') AS standard_header,"+
+			" COALESCE(standard_template, '') AS standard_template FROM license_texts WHERE UPPER(license_id) = $1",
+		licenseIDToUpper).StructScan(&text)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		s.Errorf("Error: Failed to query 'license_texts' table for %v: %#v", licenseIDToUpper, err)
+		return LicenseText{}, fmt.Errorf("failed to query the 'license_texts' table: %v", err)
+	}
+	return text, nil
+}
+
+// GetAllLicenseTexts retrieves every license text in the license_texts table.
+func (m *LicenseTextModel) GetAllLicenseTexts(ctx context.Context, s *zap.SugaredLogger) ([]LicenseText, error) {
+	ctx, end := startQuery(ctx, "LicenseTextModel.GetAllLicenseTexts")
+	defer end()
+	var texts []LicenseText
+	err := m.db.SelectContext(ctx, &texts,
+		"SELECT license_id, COALESCE(name, '') AS name, license_text, COALESCE(standard_header, '') AS standard_header,"+
+			" COALESCE(standard_template, '') AS standard_template FROM license_texts")
+	if err != nil {
+		s.Errorf("Error: Failed to query all 'license_texts' rows: %#v", err)
+		return nil, fmt.Errorf("failed to query the 'license_texts' table: %v", err)
+	}
+	return texts, nil
+}
+
+// License-list-data layout: json/details holds one JSON document per license, with text/ and
+// template/ as plain-text alternatives (used for LicenseRefs added by hand).
+const (
+	licenseDataDetailsDir  = "json/details"
+	licenseDataTextDir     = "text"
+	licenseDataTemplateDir = "template"
+	licenseDataTemplateExt = ".template.txt"
+)
+
+// LicenseTextDirModel reads license texts from a directory in SPDX license-list-data layout.
+// The directory is indexed on first use, and again on Reindex, so lookups are case-insensitive
+// and never build file paths from user input.
+type LicenseTextDirModel struct {
+	dir   string
+	mu    sync.RWMutex
+	index *licenseTextIndex // nil until the directory was indexed successfully
+}
+
+// licenseTextIndex maps the upper-cased license IDs of a license-list-data directory to their files.
+type licenseTextIndex struct {
+	details   map[string]string // json/details files
+	texts     map[string]string // text files
+	templates map[string]string // template files
+}
+
+// NewLicenseTextDirModel creates a new instance of the directory backed LicenseText Model.
+func NewLicenseTextDirModel(dir string) *LicenseTextDirModel {
+	return &LicenseTextDirModel{dir: dir}
+}
+
+// GetLicenseTextByID retrieves the text of the given license ID, matched case-insensitively.
+// The json/details document is preferred; otherwise the text/ and template/ files are used.
+func (m *LicenseTextDirModel) GetLicenseTextByID(_ context.Context, s *zap.SugaredLogger, licenseID string) (LicenseText, error) {
+	index, err := m.currentIndex()
+	if err != nil {
+		s.Errorf("Error: Failed to index license text directory %v: %v", m.dir, err)
+		return LicenseText{}, fmt.Errorf("failed to read the license text directory: %v", err)
+	}
+	return index.licenseText(s, licenseID)
+}
+
+// GetAllLicenseTexts retrieves every license text in the directory.
+func (m *LicenseTextDirModel) GetAllLicenseTexts(_ context.Context, s *zap.SugaredLogger) ([]LicenseText, error) {
+	index, err := m.currentIndex()
+	if err != nil {
+		s.Errorf("Error: Failed to index license text directory %v: %v", m.dir, err)
+		return nil, fmt.Errorf("failed to read the license text directory: %v", err)
+	}
+	ids := make([]string, 0, index.size())
+	for id := range index.details {
+		ids = append(ids, id)
+	}
+	for id := range index.texts {
+		if _, ok := index.details[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	texts := make([]LicenseText, 0, len(ids))
+	for _, id := range ids {
+		text, err := index.licenseText(s, id)
+		if err != nil {
+			s.Warnf("Skipping license text %v: %v", id, err)
+			continue
+		}
+		texts = append(texts, text)
+	}
+	return texts, nil
+}
+
+// Reindex lists the license-list-data directory again, picking up the files added or removed since.
+// When the directory holds no license texts, the previous index is kept and an error returned.
+func (m *LicenseTextDirModel) Reindex() error {
+	index := &licenseTextIndex{
+		details:   indexLicenseDataDir(filepath.Join(m.dir, licenseDataDetailsDir), ".json"),
+		texts:     indexLicenseDataDir(filepath.Join(m.dir, licenseDataTextDir), ".txt"),
+		templates: indexLicenseDataDir(filepath.Join(m.dir, licenseDataTemplateDir), licenseDataTemplateExt),
+	}
+	// Only json/details or text needs to exist.
+	if len(index.details) == 0 && len(index.texts) == 0 {
+		return fmt.Errorf("no license texts found under %v", m.dir)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.index = index
+	return nil
+}
+
+// Size returns the number of license texts indexed, or zero when the directory was not indexed yet.
+func (m *LicenseTextDirModel) Size() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.index.size()
+}
+
+// currentIndex returns the index of the directory, indexing it first if needed.
+func (m *LicenseTextDirModel) currentIndex() (*licenseTextIndex, error) {
+	m.mu.RLock()
+	index := m.index
+	m.mu.RUnlock()
+	if index != nil {
+		return index, nil
+	}
+	if err := m.Reindex(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.index, nil
+}
+
+// size returns the number of license texts in the index, which may be nil.
+func (i *licenseTextIndex) size() int {
+	if i == nil {
+		return 0
+	}
+	size := len(i.details)
+	for id := range i.texts {
+		if _, ok := i.details[id]; !ok {
+			size++
+		}
+	}
+	return size
+}
+
+// licenseText reads the text of the given license ID, or returns an empty LicenseText when it is not indexed.
+func (i *licenseTextIndex) licenseText(s *zap.SugaredLogger, licenseID string) (LicenseText, error) {
+	key := strings.ToUpper(licenseID)
+	if path, ok := i.details[key]; ok {
+		return readLicenseDetailsFile(path)
+	}
+	path, ok := i.texts[key]
+	if !ok {
+		return LicenseText{}, nil
+	}
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return LicenseText{}, fmt.Errorf("failed to read license text for %v: %v", licenseID, err)
+	}
+	result := LicenseText{LicenseID: strings.TrimSuffix(filepath.Base(path), ".txt"), Text: string(text)}
+	if templatePath, found := i.templates[key]; found {
+		template, tErr := os.ReadFile(templatePath)
+		if tErr != nil {
+			s.Warnf("Failed to read license template %v: %v", templatePath, tErr)
+		}
+		result.StandardTemplate = string(template)
+	}
+	return result, nil
+}
+
+// indexLicenseDataDir maps the upper-cased license ID of every file in dir with the given extension to its path.
+func indexLicenseDataDir(dir, ext string) map[string]string {
+	index := make(map[string]string)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return index
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		index[strings.ToUpper(strings.TrimSuffix(name, ext))] = filepath.Join(dir, name)
+	}
+	return index
+}
+
+// readLicenseDetailsFile parses a license-list-data json/details document.
+func readLicenseDetailsFile(path string) (LicenseText, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return LicenseText{}, fmt.Errorf("failed to read license details file %v: %v", path, err)
+	}
+	var text LicenseText
+	if err = json.Unmarshal(data, &text); err != nil {
+		return LicenseText{}, fmt.Errorf("failed to parse license details file %v: %v", path, err)
+	}
+	return text, nil
+}
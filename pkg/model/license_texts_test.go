@@ -0,0 +1,159 @@
+package models
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestGetLicenseTextByID(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	err = loadTestSQLDataFiles(db, ctx, []string{"tests/license_texts.sql"})
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+
+	stores := map[string]LicenseTextModelInterface{
+		"db":  NewLicenseTextModel(db),
+		"dir": NewLicenseTextDirModel("tests/license-list-data"),
+	}
+	tests := []struct {
+		store          string
+		licenseID      string
+		expectedID     string
+		expectTemplate bool
+	}{
+		{store: "db", licenseID: "mit", expectedID: "MIT", expectTemplate: true},
+		{store: "db", licenseID: "LicenseRef-scancode-example", expectedID: "LicenseRef-scancode-example"},
+		{store: "db", licenseID: "Apache-2.0", expectedID: ""},
+		{store: "dir", licenseID: "apache-2.0", expectedID: "Apache-2.0", expectTemplate: true},
+		{store: "dir", licenseID: "licenseref-scancode-example", expectedID: "LicenseRef-scancode-example", expectTemplate: true},
+		{store: "dir", licenseID: "../../license_texts.sql", expectedID: ""},
+		{store: "dir", licenseID: "MIT", expectedID: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.store+"/"+test.licenseID, func(t *testing.T) {
+			text, err := stores[test.store].GetLicenseTextByID(ctx, s, test.licenseID)
+			if err != nil {
+				t.Fatalf("GetLicenseTextByID() unexpected error = %v", err)
+			}
+			if text.LicenseID != test.expectedID {
+				t.Errorf("GetLicenseTextByID() license ID = %q, want %q", text.LicenseID, test.expectedID)
+			}
+			if test.expectedID != "" && len(text.Text) == 0 {
+				t.Errorf("GetLicenseTextByID() returned no text for %v", test.licenseID)
+			}
+			if test.expectTemplate && len(text.StandardTemplate) == 0 {
+				t.Errorf("GetLicenseTextByID() returned no template for %v", test.licenseID)
+			}
+		})
+	}
+}
+
+func TestGetLicenseTextByID_MissingDir(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+
+	_, err = NewLicenseTextDirModel("tests/does-not-exist").GetLicenseTextByID(ctx, s, "MIT")
+	if err == nil {
+		t.Errorf("GetLicenseTextByID() expected an error for a missing directory")
+	}
+}
+
+func TestGetAllLicenseTexts(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	err = loadTestSQLDataFiles(db, ctx, []string{"tests/license_texts.sql"})
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+
+	for name, store := range map[string]LicenseTextModelInterface{
+		"db":  NewLicenseTextModel(db),
+		"dir": NewLicenseTextDirModel("tests/license-list-data"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			texts, err := store.GetAllLicenseTexts(ctx, s)
+			if err != nil {
+				t.Fatalf("GetAllLicenseTexts() unexpected error = %v", err)
+			}
+			if len(texts) != 2 {
+				t.Errorf("GetAllLicenseTexts() got %d texts, want 2", len(texts))
+			}
+		})
+	}
+}
+
+func TestLicenseTextDirModel_Reindex(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	dir := t.TempDir()
+	textDir := filepath.Join(dir, licenseDataTextDir)
+	if err = os.MkdirAll(textDir, 0o755); err != nil {
+		t.Fatalf("failed to create text directory: %v", err)
+	}
+	writeText := func(id string) {
+		if err := os.WriteFile(filepath.Join(textDir, id+".txt"), []byte(id+" license text"), 0o600); err != nil {
+			t.Fatalf("failed to write license text: %v", err)
+		}
+	}
+	writeText("LicenseRef-first")
+	store := NewLicenseTextDirModel(dir)
+	if text, _ := store.GetLicenseTextByID(ctx, s, "LicenseRef-first"); text.LicenseID != "LicenseRef-first" {
+		t.Errorf("GetLicenseTextByID() license ID = %q, want LicenseRef-first", text.LicenseID)
+	}
+
+	writeText("LicenseRef-second")
+	if text, _ := store.GetLicenseTextByID(ctx, s, "LicenseRef-second"); text.LicenseID != "" {
+		t.Errorf("GetLicenseTextByID() found %q before the directory was indexed again", text.LicenseID)
+	}
+	if err = store.Reindex(); err != nil {
+		t.Fatalf("Reindex() unexpected error = %v", err)
+	}
+	if text, _ := store.GetLicenseTextByID(ctx, s, "LicenseRef-second"); text.LicenseID != "LicenseRef-second" {
+		t.Errorf("GetLicenseTextByID() license ID = %q, want LicenseRef-second", text.LicenseID)
+	}
+	if store.Size() != 2 {
+		t.Errorf("Size() = %d, want 2", store.Size())
+	}
+
+	if err = os.RemoveAll(textDir); err != nil {
+		t.Fatalf("failed to remove text directory: %v", err)
+	}
+	if err = store.Reindex(); err == nil {
+		t.Errorf("Reindex() expected an error for an empty directory")
+	}
+	if store.Size() != 2 {
+		t.Errorf("Size() = %d, want the previous index to be kept", store.Size())
+	}
+}
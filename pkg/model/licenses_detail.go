@@ -33,6 +33,8 @@ import (
 
 type LicenseDetailModelInterface interface {
 	GetLicenseByID(ctx context.Context, s *zap.SugaredLogger, id string) (LicenseDetail, error)
+	GetLicensesByIDs(ctx context.Context, s *zap.SugaredLogger, ids []string) ([]LicenseDetail, error)
+	GetAllLicenses(ctx context.Context, s *zap.SugaredLogger) ([]LicenseDetail, error)
 }
 
 type LicenseModel struct {
@@ -101,10 +103,13 @@ func NewLicenseDetailModel(db *sqlx.DB) *LicenseModel {
 
 // GetLicenseByID retrieves license data by the given row ID.
 func (m *LicenseModel) GetLicenseByID(ctx context.Context, s *zap.SugaredLogger, licenseID string) (LicenseDetail, error) {
+	ctx, end := startQuery(ctx, "LicenseModel.GetLicenseByID")
+	defer end()
 	conn, err := NewConn(ctx, m.db)
 	if err != nil {
 		return LicenseDetail{}, err
 	}
+	defer CloseConn(conn)
 	licenseIDToUpper := strings.ToUpper(licenseID)
 	var license LicenseDetail
 	err = conn.QueryRowxContext(ctx,
@@ -115,3 +120,45 @@ func (m *LicenseModel) GetLicenseByID(ctx context.Context, s *zap.SugaredLogger,
 	}
 	return license, nil
 }
+
+// GetLicensesByIDs retrieves the license data for all the given license IDs in a single query.
+// IDs are matched case-insensitively; IDs without a row are simply absent from the result.
+func (m *LicenseModel) GetLicensesByIDs(ctx context.Context, s *zap.SugaredLogger, licenseIDs []string) ([]LicenseDetail, error) {
+	ctx, end := startQuery(ctx, "LicenseModel.GetLicensesByIDs")
+	defer end()
+	if len(licenseIDs) == 0 {
+		return []LicenseDetail{}, nil
+	}
+	query, args := upperInClause("SELECT * FROM licenses WHERE UPPER(license_id) IN (%s)", licenseIDs)
+	var licenses []LicenseDetail
+	err := m.db.SelectContext(ctx, &licenses, query, args...)
+	if err != nil {
+		s.Errorf("Error: Failed to query license table for %v: %#v", licenseIDs, err)
+		return nil, fmt.Errorf("failed to query the license table: %v", err)
+	}
+	return licenses, nil
+}
+
+// GetAllLicenses retrieves every row of the licenses table.
+func (m *LicenseModel) GetAllLicenses(ctx context.Context, s *zap.SugaredLogger) ([]LicenseDetail, error) {
+	ctx, end := startQuery(ctx, "LicenseModel.GetAllLicenses")
+	defer end()
+	var licenses []LicenseDetail
+	err := m.db.SelectContext(ctx, &licenses, "SELECT * FROM licenses")
+	if err != nil {
+		s.Errorf("Error: Failed to query all licenses: %#v", err)
+		return nil, fmt.Errorf("failed to query the license table: %v", err)
+	}
+	return licenses, nil
+}
+
+// upperInClause formats query with a "$1,$2,..." placeholder list holding the upper-cased values.
+func upperInClause(query string, values []string) (string, []interface{}) {
+	placeholders := make([]string, len(values))
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = strings.ToUpper(v)
+	}
+	return fmt.Sprintf(query, strings.Join(placeholders, ",")), args
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestGetLicensesByIDs(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	err = loadTestSQLDataFiles(db, ctx, []string{"tests/license_details.sql"})
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	licenseModel := NewLicenseDetailModel(db)
+
+	tests := []struct {
+		name     string
+		ids      []string
+		expected []string
+	}{
+		{name: "found ids", ids: []string{"MIT", "Apache-2.0"}, expected: []string{"MIT", "Apache-2.0"}},
+		{name: "mixed case ids", ids: []string{"mit", "gpl-2.0"}, expected: []string{"MIT", "GPL-2.0"}},
+		{name: "missing ids", ids: []string{"MIT", "my-license"}, expected: []string{"MIT"}},
+		{name: "only missing ids", ids: []string{"my-license"}, expected: []string{}},
+		{name: "no ids", ids: nil, expected: []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			licenses, err := licenseModel.GetLicensesByIDs(ctx, s, test.ids)
+			if err != nil {
+				t.Fatalf("licenseModel.GetLicensesByIDs() unexpected error = %v", err)
+			}
+			if len(licenses) != len(test.expected) {
+				t.Fatalf("licenseModel.GetLicensesByIDs() got %d rows, want %d", len(licenses), len(test.expected))
+			}
+			found := make(map[string]LicenseDetail, len(licenses))
+			for _, l := range licenses {
+				found[l.LicenseID] = l
+			}
+			for _, id := range test.expected {
+				if _, ok := found[id]; !ok {
+					t.Errorf("licenseModel.GetLicensesByIDs() is missing %v, got %#v", id, licenses)
+				}
+			}
+		})
+	}
+
+	licenses, err := licenseModel.GetLicensesByIDs(ctx, s, []string{"MIT"})
+	if err != nil || len(licenses) != 1 {
+		t.Fatalf("licenseModel.GetLicensesByIDs() = %v, %v", licenses, err)
+	}
+	if licenses[0].Name != "MIT License" || !licenses[0].IsOsiApproved || len(licenses[0].SeeAlso) != 1 {
+		t.Errorf("licenseModel.GetLicensesByIDs() returned an unexpected row %#v", licenses[0])
+	}
+}
@@ -15,6 +15,8 @@ import (
 
 type OSADLModelInterface interface {
 	GetOSADLByLicenseID(ctx context.Context, s *zap.SugaredLogger, id string) (OSADL, error)
+	GetOSADLByLicenseIDs(ctx context.Context, s *zap.SugaredLogger, ids []string) ([]OSADL, error)
+	GetAllOSADL(ctx context.Context, s *zap.SugaredLogger) ([]OSADL, error)
 }
 
 type OSADLModel struct {
@@ -82,10 +84,13 @@ func NewOSADLModel(db *sqlx.DB) *OSADLModel {
 
 // GetOSADLByLicenseID retrieves OSADL data by the given license ID.
 func (m *OSADLModel) GetOSADLByLicenseID(ctx context.Context, s *zap.SugaredLogger, licenseID string) (OSADL, error) {
+	ctx, end := startQuery(ctx, "OSADLModel.GetOSADLByLicenseID")
+	defer end()
 	conn, err := NewConn(ctx, m.db)
 	if err != nil {
 		return OSADL{}, err
 	}
+	defer CloseConn(conn)
 	licenseIDToUpper := strings.ToUpper(licenseID)
 	var osadl OSADL
 	s.Debugf("LicenseDetail ID: %v", licenseIDToUpper)
@@ -97,3 +102,33 @@ func (m *OSADLModel) GetOSADLByLicenseID(ctx context.Context, s *zap.SugaredLogg
 	}
 	return osadl, nil
 }
+
+// GetOSADLByLicenseIDs retrieves the OSADL data for all the given license IDs in a single query.
+func (m *OSADLModel) GetOSADLByLicenseIDs(ctx context.Context, s *zap.SugaredLogger, licenseIDs []string) ([]OSADL, error) {
+	ctx, end := startQuery(ctx, "OSADLModel.GetOSADLByLicenseIDs")
+	defer end()
+	if len(licenseIDs) == 0 {
+		return []OSADL{}, nil
+	}
+	query, args := upperInClause("SELECT * FROM osadl WHERE UPPER(license_id) IN (%s)", licenseIDs)
+	var osadls []OSADL
+	err := m.db.SelectContext(ctx, &osadls, query, args...)
+	if err != nil {
+		s.Errorf("Error: Failed to query 'osadl' table for %v: %#v", licenseIDs, err)
+		return nil, fmt.Errorf("failed to query the 'osadl' table: %v", err)
+	}
+	return osadls, nil
+}
+
+// GetAllOSADL retrieves every row of the osadl table.
+func (m *OSADLModel) GetAllOSADL(ctx context.Context, s *zap.SugaredLogger) ([]OSADL, error) {
+	ctx, end := startQuery(ctx, "OSADLModel.GetAllOSADL")
+	defer end()
+	var osadls []OSADL
+	err := m.db.SelectContext(ctx, &osadls, "SELECT * FROM osadl")
+	if err != nil {
+		s.Errorf("Error: Failed to query all 'osadl' rows: %#v", err)
+		return nil, fmt.Errorf("failed to query the 'osadl' table: %v", err)
+	}
+	return osadls, nil
+}
@@ -56,3 +56,42 @@ func TestGetOSDALByLicenseId(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOSADLByLicenseIDs(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	err = loadTestSQLDataFiles(db, ctx, []string{"tests/osadl.sql"})
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	osadlModel := NewOSADLModel(db)
+
+	tests := []struct {
+		name     string
+		ids      []string
+		expected int
+	}{
+		{name: "mixed case ids", ids: []string{"0bsd", "APACHE-2.0"}, expected: 2},
+		{name: "unknown id", ids: []string{"my-license"}, expected: 0},
+		{name: "no ids", ids: nil, expected: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows, err := osadlModel.GetOSADLByLicenseIDs(ctx, s, test.ids)
+			if err != nil {
+				t.Fatalf("osadlModel.GetOSADLByLicenseIDs() unexpected error = %v", err)
+			}
+			if len(rows) != test.expected {
+				t.Errorf("osadlModel.GetOSADLByLicenseIDs() got %d rows, want %d", len(rows), test.expected)
+			}
+		})
+	}
+}
@@ -43,6 +43,8 @@ func NewPurlLicensesModel(db *sqlx.DB) *PurlLicensesModel {
 }
 
 func (m *PurlLicensesModel) GetLicensesByPurlVersion(ctx context.Context, purl, version string) ([]PurlLicense, error) {
+	ctx, end := startQuery(ctx, "PurlLicensesModel.GetLicensesByPurlVersion")
+	defer end()
 	s := ctxzap.Extract(ctx).Sugar()
 	if len(purl) == 0 || len(version) == 0 {
 		s.Error("Please specify a valid purl and version to query")
@@ -62,7 +64,12 @@ func (m *PurlLicensesModel) GetLicensesByPurlVersion(ctx context.Context, purl,
 	return purlLicenses, nil
 }
 
-func (m *PurlLicensesModel) GetLicensesByPurlVersionAndSource(ctx context.Context, purl, version string, sourceID []int16) ([]PurlLicense, error) {
+// GetLicensesByPurlVersionAndSource retrieves license data for a purl version with source filtering.
+// A non-empty asOf (YYYY-MM-DD) restricts the results to rows dated on or before it.
+func (m *PurlLicensesModel) GetLicensesByPurlVersionAndSource(ctx context.Context, purl, version string, sourceID []int16,
+	asOf string) ([]PurlLicense, error) {
+	ctx, end := startQuery(ctx, "PurlLicensesModel.GetLicensesByPurlVersionAndSource")
+	defer end()
 	s := ctxzap.Extract(ctx).Sugar()
 
 	if len(purl) == 0 || len(version) == 0 {
@@ -87,6 +94,7 @@ func (m *PurlLicensesModel) GetLicensesByPurlVersionAndSource(ctx context.Contex
 			"WHERE purl = $1 AND version = $2 AND source_id IN (%s)",
 		strings.Join(sourcePlaceholders, ","),
 	)
+	query, args = restrictAsOf(query, args, asOf)
 
 	var purlLicenses []PurlLicense
 	err := m.db.SelectContext(ctx, &purlLicenses, query, args...)
@@ -99,7 +107,11 @@ func (m *PurlLicensesModel) GetLicensesByPurlVersionAndSource(ctx context.Contex
 }
 
 // GetLicensesByPurlVersionsAndSource retrieves license data for a purl across multiple versions with source filtering.
-func (m *PurlLicensesModel) GetLicensesByPurlVersionsAndSource(ctx context.Context, purl string, versions []string, sourceID []int16) ([]PurlLicense, error) {
+// A non-empty asOf (YYYY-MM-DD) restricts the results to rows dated on or before it.
+func (m *PurlLicensesModel) GetLicensesByPurlVersionsAndSource(ctx context.Context, purl string, versions []string, sourceID []int16,
+	asOf string) ([]PurlLicense, error) {
+	ctx, end := startQuery(ctx, "PurlLicensesModel.GetLicensesByPurlVersionsAndSource")
+	defer end()
 	s := ctxzap.Extract(ctx).Sugar()
 
 	if len(purl) == 0 {
@@ -143,6 +155,7 @@ func (m *PurlLicensesModel) GetLicensesByPurlVersionsAndSource(ctx context.Conte
 		strings.Join(versionPlaceholders, ","),
 		strings.Join(sourcePlaceholders, ","),
 	)
+	query, args = restrictAsOf(query, args, asOf)
 
 	var purlLicenses []PurlLicense
 	err := m.db.SelectContext(ctx, &purlLicenses, query, args...)
@@ -156,7 +169,11 @@ func (m *PurlLicensesModel) GetLicensesByPurlVersionsAndSource(ctx context.Conte
 }
 
 // GetLicensesByUnversionedPurlAndSource retrieves license data from unversioned purl with source filtering.
-func (m *PurlLicensesModel) GetLicensesByUnversionedPurlAndSource(ctx context.Context, purl string, sourceID []int16) ([]PurlLicense, error) {
+// A non-empty asOf (YYYY-MM-DD) restricts the results to rows dated on or before it.
+func (m *PurlLicensesModel) GetLicensesByUnversionedPurlAndSource(ctx context.Context, purl string, sourceID []int16,
+	asOf string) ([]PurlLicense, error) {
+	ctx, end := startQuery(ctx, "PurlLicensesModel.GetLicensesByUnversionedPurlAndSource")
+	defer end()
 	s := ctxzap.Extract(ctx).Sugar()
 
 	if len(purl) == 0 {
@@ -181,8 +198,9 @@ func (m *PurlLicensesModel) GetLicensesByUnversionedPurlAndSource(ctx context.Co
 	query := fmt.Sprintf(`
 		SELECT purl, version, date, source_id, license_id 
 		FROM purl_licenses 
-		WHERE purl = $1 AND (version = '' OR version IS NULL) AND source_id IN (%s)
-		ORDER BY source_id, license_id`, strings.Join(placeholders, ","))
+		WHERE purl = $1 AND (version = '' OR version IS NULL) AND source_id IN (%s)`, strings.Join(placeholders, ","))
+	query, args = restrictAsOf(query, args, asOf)
+	query += " ORDER BY source_id, license_id"
 
 	var purlLicenses []PurlLicense
 	err := m.db.SelectContext(ctx, &purlLicenses, query, args...)
@@ -194,3 +212,147 @@ func (m *PurlLicensesModel) GetLicensesByUnversionedPurlAndSource(ctx context.Co
 	s.Debugf("Found %v unversioned license results for purl %v, source_ids %v", len(purlLicenses), purl, sourceID)
 	return purlLicenses, nil
 }
+
+// restrictAsOf appends a condition to query keeping only the rows dated on or before asOf, when it is set.
+func restrictAsOf(query string, args []interface{}, asOf string) (string, []interface{}) {
+	if len(asOf) == 0 {
+		return query, args
+	}
+	return query + fmt.Sprintf(" AND date <= $%d", len(args)+1), append(args, asOf)
+}
+
+// PurlLicenseCursor is the keyset position of a purl_licenses row, following the table's unique key order.
+type PurlLicenseCursor struct {
+	Purl      string `json:"p"`
+	Version   string `json:"v"`
+	SourceID  int16  `json:"s"`
+	LicenseID int32  `json:"l"`
+}
+
+// GetPurlsByLicenseIDs retrieves the purl_licenses rows carrying any of the given license IDs, ordered by
+// (purl, version, source_id, license_id) and starting after the given cursor (keyset pagination).
+// The results can be narrowed to a purl type (e.g. "npm") and to a set of source IDs.
+func (m *PurlLicensesModel) GetPurlsByLicenseIDs(ctx context.Context, licenseIDs []int32, purlType string, sourceIDs []int16,
+	after *PurlLicenseCursor, limit int) ([]PurlLicense, error) {
+	ctx, end := startQuery(ctx, "PurlLicensesModel.GetPurlsByLicenseIDs")
+	defer end()
+	s := ctxzap.Extract(ctx).Sugar()
+	if len(licenseIDs) == 0 {
+		s.Error("Please specify at least one license_id to query")
+		return nil, errors.New("please specify at least one license_id to query")
+	}
+	var args []interface{}
+	placeholders := func(n int) string {
+		p := make([]string, n)
+		for i := range p {
+			p[i] = fmt.Sprintf("$%d", len(args)+i+1)
+		}
+		return strings.Join(p, ",")
+	}
+	conditions := []string{fmt.Sprintf("license_id IN (%s)", placeholders(len(licenseIDs)))}
+	for _, id := range licenseIDs {
+		args = append(args, id)
+	}
+	if len(purlType) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`purl LIKE %s ESCAPE '\'`, placeholders(1)))
+		args = append(args, "pkg:"+escapeLike(purlType)+"/%")
+	}
+	if len(sourceIDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf("source_id IN (%s)", placeholders(len(sourceIDs))))
+		for _, id := range sourceIDs {
+			args = append(args, id)
+		}
+	}
+	if after != nil {
+		conditions = append(conditions, fmt.Sprintf("(purl, version, source_id, license_id) > (%s)", placeholders(4)))
+		args = append(args, after.Purl, after.Version, after.SourceID, after.LicenseID)
+	}
+	query := fmt.Sprintf("SELECT purl, version, date, source_id, license_id FROM purl_licenses WHERE %s "+
+		"ORDER BY purl, version, source_id, license_id LIMIT %s", strings.Join(conditions, " AND "), placeholders(1))
+	args = append(args, limit)
+
+	var purlLicenses []PurlLicense
+	err := m.db.SelectContext(ctx, &purlLicenses, query, args...)
+	if err != nil {
+		s.Errorf("Failed to query purl_licenses table for license_ids %v: %v", licenseIDs, err)
+		return nil, fmt.Errorf("failed to query the purl_licenses table: %v", err)
+	}
+	s.Debugf("Found %v results for license_ids %v", len(purlLicenses), licenseIDs)
+	return purlLicenses, nil
+}
+
+// PurlChange is a purl/version whose license rows were added or changed, with the date of its latest row.
+type PurlChange struct {
+	Purl    string `json:"purl" db:"purl"`
+	Version string `json:"version" db:"version"`
+	Date    string `json:"date" db:"date"`
+}
+
+// PurlChangeCursor is the keyset position of a PurlChange, following the changes feed order.
+type PurlChangeCursor struct {
+	Date    string `json:"d"`
+	Purl    string `json:"p"`
+	Version string `json:"v"`
+}
+
+// GetLicenseChanges retrieves the purl/versions with purl_licenses rows dated on or after since (YYYY-MM-DD),
+// ordered by (latest date, purl, version) and starting after the given cursor (keyset pagination). When a cursor
+// is given, its date takes the place of since. The results can be narrowed to a purl type (e.g. "npm"), to a set
+// of purls and to a set of source IDs.
+func (m *PurlLicensesModel) GetLicenseChanges(ctx context.Context, since, purlType string, purls []string, sourceIDs []int16,
+	after *PurlChangeCursor, limit int) ([]PurlChange, error) {
+	ctx, end := startQuery(ctx, "PurlLicensesModel.GetLicenseChanges")
+	defer end()
+	s := ctxzap.Extract(ctx).Sugar()
+	if after != nil {
+		since = after.Date
+	}
+	if len(since) == 0 {
+		s.Error("Please specify a date or cursor to query changes since")
+		return nil, errors.New("please specify a date or cursor to query changes since")
+	}
+	var args []interface{}
+	placeholders := func(n int) string {
+		p := make([]string, n)
+		for i := range p {
+			p[i] = fmt.Sprintf("$%d", len(args)+i+1)
+		}
+		return strings.Join(p, ",")
+	}
+	conditions := []string{fmt.Sprintf("date >= %s", placeholders(1))}
+	args = append(args, since)
+	if len(purlType) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`purl LIKE %s ESCAPE '\'`, placeholders(1)))
+		args = append(args, "pkg:"+escapeLike(purlType)+"/%")
+	}
+	if len(purls) > 0 {
+		conditions = append(conditions, fmt.Sprintf("purl IN (%s)", placeholders(len(purls))))
+		for _, p := range purls {
+			args = append(args, p)
+		}
+	}
+	if len(sourceIDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf("source_id IN (%s)", placeholders(len(sourceIDs))))
+		for _, id := range sourceIDs {
+			args = append(args, id)
+		}
+	}
+	var having string
+	if after != nil {
+		having = fmt.Sprintf(" HAVING (MAX(date), purl, version) > (%s)", placeholders(3))
+		args = append(args, after.Date, after.Purl, after.Version)
+	}
+	query := fmt.Sprintf("SELECT purl, version, MAX(date) AS date FROM purl_licenses WHERE %s "+
+		"GROUP BY purl, version%s ORDER BY MAX(date), purl, version LIMIT %s",
+		strings.Join(conditions, " AND "), having, placeholders(1))
+	args = append(args, limit)
+
+	var changes []PurlChange
+	err := m.db.SelectContext(ctx, &changes, query, args...)
+	if err != nil {
+		s.Errorf("Failed to query purl_licenses table for changes since %v: %v", since, err)
+		return nil, fmt.Errorf("failed to query the purl_licenses table: %v", err)
+	}
+	s.Debugf("Found %v changed purl versions since %v", len(changes), since)
+	return changes, nil
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"reflect"
 	"testing"
 )
 
@@ -98,7 +99,7 @@ func TestPurlLicensesModel_GetLicensesByPurlAndSource(t *testing.T) {
 	model := NewPurlLicensesModel(db)
 
 	t.Run("GetExistingPurlLicensesBySource", func(t *testing.T) {
-		licenses, err := model.GetLicensesByPurlVersionAndSource(ctx, "pkg:npm/express", "4.18.2", []int16{1})
+		licenses, err := model.GetLicensesByPurlVersionAndSource(ctx, "pkg:npm/express", "4.18.2", []int16{1}, "")
 		if err != nil {
 			t.Fatalf("Expected no error, got: %v", err)
 		}
@@ -114,7 +115,7 @@ func TestPurlLicensesModel_GetLicensesByPurlAndSource(t *testing.T) {
 	})
 
 	t.Run("GetNonExistentSourceID", func(t *testing.T) {
-		licenses, err := model.GetLicensesByPurlVersionAndSource(ctx, "pkg:npm/express", "4.18.2", []int16{999})
+		licenses, err := model.GetLicensesByPurlVersionAndSource(ctx, "pkg:npm/express", "4.18.2", []int16{999}, "")
 		if err != nil {
 			t.Fatalf("Expected no error, got: %v", err)
 		}
@@ -123,3 +124,215 @@ func TestPurlLicensesModel_GetLicensesByPurlAndSource(t *testing.T) {
 		}
 	})
 }
+
+func TestPurlLicensesModel_AsOf(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db := sqliteSetup(t)
+	defer CloseDB(db)
+	err = loadTestSQLDataFiles(db, ctx, []string{"tests/purl_licenses.sql"})
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	_, err = db.ExecContext(ctx, "INSERT INTO purl_licenses (purl, version, date, source_id, license_id) VALUES "+
+		"('pkg:npm/express', '4.18.2', '2024-05-01', 1, 109), ('pkg:npm/express', '', '2022-06-01', 1, 5614), "+
+		"('pkg:npm/express', '', '2024-05-01', 2, 552)")
+	if err != nil {
+		t.Fatalf("failed to insert test rows: %v", err)
+	}
+	model := NewPurlLicensesModel(db)
+
+	t.Run("Version", func(t *testing.T) {
+		licenses, err := model.GetLicensesByPurlVersionAndSource(ctx, "pkg:npm/express", "4.18.2", []int16{1, 2}, "")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(licenses) != 3 {
+			t.Errorf("Expected 3 licenses without as_of, got: %v", len(licenses))
+		}
+		licenses, err = model.GetLicensesByPurlVersionAndSource(ctx, "pkg:npm/express", "4.18.2", []int16{1, 2}, "2023-12-31")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(licenses) != 2 {
+			t.Errorf("Expected 2 licenses as of 2023-12-31, got: %v", len(licenses))
+		}
+		for _, l := range licenses {
+			if l.Date > "2023-12-31" {
+				t.Errorf("Expected no rows dated after 2023-12-31, got: %+v", l)
+			}
+		}
+		licenses, err = model.GetLicensesByPurlVersionAndSource(ctx, "pkg:npm/express", "4.18.2", []int16{1, 2}, "2022-12-31")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(licenses) != 0 {
+			t.Errorf("Expected 0 licenses as of 2022-12-31, got: %v", len(licenses))
+		}
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		licenses, err := model.GetLicensesByPurlVersionsAndSource(ctx, "pkg:npm/express", []string{"4.18.2", "4.17.1"}, []int16{1}, "2022-12-31")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(licenses) != 1 || licenses[0].Version != "4.17.1" {
+			t.Errorf("Expected only the 4.17.1 row as of 2022-12-31, got: %+v", licenses)
+		}
+	})
+
+	t.Run("Unversioned", func(t *testing.T) {
+		licenses, err := model.GetLicensesByUnversionedPurlAndSource(ctx, "pkg:npm/express", []int16{1, 2}, "2023-01-01")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(licenses) != 1 || licenses[0].LicenseID != 5614 {
+			t.Errorf("Expected only the 2022-06-01 row as of 2023-01-01, got: %+v", licenses)
+		}
+	})
+}
+
+func TestPurlLicensesModel_GetPurlsByLicenseIDs(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db := sqliteSetup(t)
+	defer CloseDB(db)
+	err = loadTestSQLDataFiles(db, ctx, []string{"tests/purl_licenses.sql"})
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	model := NewPurlLicensesModel(db)
+
+	t.Run("KeysetPagination", func(t *testing.T) {
+		var all []PurlLicense
+		var cursor *PurlLicenseCursor
+		for page := 0; page < 10; page++ {
+			rows, err := model.GetPurlsByLicenseIDs(ctx, []int32{5614}, "", nil, cursor, 2)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			all = append(all, rows...)
+			if len(rows) < 2 {
+				break
+			}
+			last := rows[len(rows)-1]
+			cursor = &PurlLicenseCursor{Purl: last.Purl, Version: last.Version, SourceID: last.SourceID, LicenseID: last.LicenseID}
+		}
+		if len(all) != 4 {
+			t.Fatalf("Expected 4 rows, got: %v", len(all))
+		}
+		if all[0].Purl != "pkg:gem/rails" || all[3].Purl != "pkg:npm/lodash" {
+			t.Errorf("Unexpected order: %v", all)
+		}
+	})
+
+	t.Run("PurlTypeAndSourceFilters", func(t *testing.T) {
+		rows, err := model.GetPurlsByLicenseIDs(ctx, []int32{552, 5614}, "npm", []int16{2}, nil, 10)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(rows) != 1 || rows[0].Purl != "pkg:npm/express" || rows[0].LicenseID != 552 {
+			t.Errorf("Unexpected rows: %v", rows)
+		}
+	})
+
+	t.Run("PurlTypeWildcards", func(t *testing.T) {
+		rows, err := model.GetPurlsByLicenseIDs(ctx, []int32{552, 5614}, "%", nil, nil, 10)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(rows) != 0 {
+			t.Errorf("Expected the purl type to match literally, got: %v", rows)
+		}
+	})
+
+	t.Run("NoLicenseIDs", func(t *testing.T) {
+		if _, err := model.GetPurlsByLicenseIDs(ctx, nil, "", nil, nil, 10); err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestPurlLicensesModel_GetLicenseChanges(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db := sqliteSetup(t)
+	defer CloseDB(db)
+	err = loadTestSQLDataFiles(db, ctx, []string{"tests/purl_licenses.sql"})
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	model := NewPurlLicensesModel(db)
+
+	t.Run("SinceDate", func(t *testing.T) {
+		changes, err := model.GetLicenseChanges(ctx, "2023-01-05", "", nil, nil, nil, 100)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		expected := []PurlChange{
+			{Purl: "pkg:pypi/numpy", Version: "1.24.0", Date: "2023-01-05"},
+			{Purl: "pkg:gem/rails", Version: "7.0.4", Date: "2023-01-06"},
+			{Purl: "pkg:gitlab/gpl/project", Version: "1.0.0", Date: "2023-01-06"},
+			{Purl: "pkg:github/dual/licensed", Version: "1.0.0", Date: "2023-01-07"},
+		}
+		if !reflect.DeepEqual(changes, expected) {
+			t.Errorf("Expected %+v, got: %+v", expected, changes)
+		}
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		changes, err := model.GetLicenseChanges(ctx, "2022-01-01", "npm", []string{"pkg:npm/express", "pkg:pypi/requests"}, []int16{1}, nil, 100)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(changes) != 2 || changes[0].Version != "4.17.1" || changes[1].Version != "4.18.2" {
+			t.Errorf("Expected the two express versions, got: %+v", changes)
+		}
+		changes, err = model.GetLicenseChanges(ctx, "2022-01-01", "", []string{"pkg:npm/express"}, []int16{3}, nil, 100)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(changes) != 0 {
+			t.Errorf("Expected no changes from source 3, got: %+v", changes)
+		}
+	})
+
+	t.Run("PurlTypeWildcards", func(t *testing.T) {
+		changes, err := model.GetLicenseChanges(ctx, "2022-01-01", "np_", nil, nil, nil, 100)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(changes) != 0 {
+			t.Errorf("Expected the purl type to match literally, got: %+v", changes)
+		}
+	})
+
+	t.Run("Cursor", func(t *testing.T) {
+		after := &PurlChangeCursor{Date: "2023-01-06", Purl: "pkg:gem/rails", Version: "7.0.4"}
+		changes, err := model.GetLicenseChanges(ctx, "", "", nil, nil, after, 1)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(changes) != 1 || changes[0].Purl != "pkg:gitlab/gpl/project" {
+			t.Errorf("Expected the change after the cursor, got: %+v", changes)
+		}
+	})
+
+	t.Run("NoSince", func(t *testing.T) {
+		if _, err := model.GetLicenseChanges(ctx, "", "", nil, nil, nil, 100); err == nil {
+			t.Error("Expected an error without a date or cursor")
+		}
+	})
+}
@@ -19,3 +19,4 @@ CREATE TABLE all_urls
 
 INSERT INTO all_urls (package_hash, url_hash,vendor, component, version, date,  url,mine_id, purl_name, version_id, license_id) values ('c8b5647654826091fb65a97bec820eb9','c8b5647654826091fb65a97bec820eb9', 'pineappleea','pineapple-src','v1.0','2024-06-24','https://github.com/pineappleea/pineapple-src',5,'pineappleea/pineapple-src',1,83);
 INSERT INTO all_urls (package_hash, vendor, component, version, date, url, url_hash, mine_id, license, purl_name, version_id, license_id) values ('abc123def456ghi789jkl012mno345pq', 'gpl', 'project', '1.0.0', '2025-09-30', 'https://gitlab.com/gpl/project', 'xyz789abc123def456ghi789jkl012mn', 39, 'GPL-2.0-only', 'gpl/project', 1, 2815);
+INSERT INTO all_urls (package_hash, vendor, component, version, date, url, url_hash, mine_id, license, purl_name, version_id, license_id) values ('dd11ee22ff33aa44bb55cc66dd77ee88', 'dual', 'licensed', '1.0.0', '2025-10-01', 'https://github.com/dual/licensed', 'ee22ff33aa44bb55cc66dd77ee88ff99', 5, 'GPL-2.0-only OR MIT', 'dual/licensed', 1, 5700);
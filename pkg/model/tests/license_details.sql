@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS licenses;
+CREATE TABLE licenses
+(
+    id                       INTEGER PRIMARY KEY AUTOINCREMENT,
+    reference                text    not null default '',
+    is_deprecated_license_id boolean not null default false,
+    details_url              text    not null default '',
+    reference_number         integer not null default 0,
+    name                     text    not null default '',
+    license_id               text    not null unique,
+    see_also                 text,
+    is_osi_approved          boolean not null default false,
+    is_fsf_libre             boolean not null default false
+);
+
+insert into licenses (id, name, license_id, see_also, is_osi_approved, is_fsf_libre) values (1, 'MIT License', 'MIT', '["https://opensource.org/license/mit/"]', true, true);
+insert into licenses (id, name, license_id, see_also, is_osi_approved, is_fsf_libre) values (2, 'Apache License 2.0', 'Apache-2.0', '["https://www.apache.org/licenses/LICENSE-2.0"]', true, true);
+insert into licenses (id, name, license_id, is_deprecated_license_id) values (3, 'GNU General Public License v2.0 only', 'GPL-2.0', true);
@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS license_texts;
+CREATE TABLE license_texts
+(
+    license_id TEXT PRIMARY KEY NOT NULL,
+    name TEXT,
+    license_text TEXT NOT NULL,
+    standard_header TEXT,
+    standard_template TEXT
+);
+
+INSERT INTO license_texts (license_id, name, license_text, standard_header, standard_template) VALUES ('MIT', 'MIT License', 'MIT License
+
+Copyright (c) <year> <copyright holders>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction.', NULL, '<<var;name="copyright";original="Copyright (c) <year> <copyright holders>";match=".{0,5000}">>');
+INSERT INTO license_texts (license_id, name, license_text, standard_header, standard_template) VALUES ('LicenseRef-scancode-example', NULL, 'Example proprietary license text.', NULL, NULL);
@@ -18,4 +18,5 @@ insert into licenses (id, license_name, spdx_id, is_spdx) values (2821,'GPL-3.0-
 insert into licenses (id, license_name, spdx_id, is_spdx) values (4863, 'ISC', 'ISC', true);
 insert into licenses (id, license_name, spdx_id, is_spdx) values (5236, 'LGPLv2.1+', 'LGPL-2.1-or-later', true);
 insert into licenses (id, license_name, spdx_id, is_spdx) values (5614, 'MIT', 'MIT', true);
+insert into licenses (id, license_name, spdx_id, is_spdx) values (5700, 'GPL-2.0-only OR MIT', 'GPL-2.0-only OR MIT', true);
 insert into licenses (id, license_name, spdx_id, is_spdx) values (9999, '', '', false);
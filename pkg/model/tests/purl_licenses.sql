@@ -22,4 +22,5 @@ INSERT INTO purl_licenses (purl, version, date, source_id, license_id) VALUES
 ('pkg:gem/rails', '7.0.4', '2023-01-06', 1, 5614),
 ('pkg:gitlab/gpl/project', '1.0.0', '2023-01-06', 0, 2815),
 ('pkg:gitlab/gpl/project', '1.0.0', '2023-01-06', 31, 2815),
-('pkg:gitlab/gpl/project', '1.0.0', '2023-01-06', 6, 2815);
\ No newline at end of file
+('pkg:gitlab/gpl/project', '1.0.0', '2023-01-06', 6, 2815),
+('pkg:github/dual/licensed', '1.0.0', '2023-01-07', 31, 5700);
\ No newline at end of file
@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package policy
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/audit"
+	"scanoss.com/licenses/pkg/cache"
+)
+
+// Engine holds the policy loaded from a file, reloading it when the file changes.
+// A nil *Engine is valid and means no policy is configured.
+type Engine struct {
+	file     string
+	interval time.Duration
+	logger   *zap.SugaredLogger
+	audit    *audit.Log
+
+	mu          sync.RWMutex
+	policy      *Policy
+	modTime     time.Time
+	lastRefresh time.Time
+	lastError   string
+
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewEngine creates an engine for the policy in file, checking it for changes every interval.
+// It returns nil when file is empty (no policy). An interval of zero disables the checks,
+// leaving reloads to Refresh.
+func NewEngine(file string, interval time.Duration, logger *zap.SugaredLogger) *Engine {
+	if len(file) == 0 {
+		return nil
+	}
+	return &Engine{file: file, interval: interval, logger: logger, done: make(chan struct{})}
+}
+
+// Start loads the policy and starts watching the file. A policy that fails to load is an error.
+func (e *Engine) Start(ctx context.Context) error {
+	if err := e.Refresh(ctx); err != nil {
+		return err
+	}
+	if e.interval > 0 {
+		go e.watch()
+	}
+	return nil
+}
+
+// Stop stops watching the file.
+func (e *Engine) Stop() {
+	e.stopOnce.Do(func() { close(e.done) })
+}
+
+// Name returns the name of the engine in the admin API.
+func (e *Engine) Name() string {
+	return "policy"
+}
+
+// UseAudit records each change of the policy, including its exemptions, in the given audit log.
+func (e *Engine) UseAudit(log *audit.Log) {
+	e.audit = log
+}
+
+// Refresh reloads the policy file. When the file is invalid, or its changes can't be recorded in the audit log,
+// the policy loaded before is kept.
+func (e *Engine) Refresh(ctx context.Context) error {
+	info, err := os.Stat(e.file)
+	var p *Policy
+	if err == nil {
+		p, err = Load(e.file)
+	}
+	// Record and swap under the same lock, so concurrent refreshes log their changes in the order they apply them.
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err == nil {
+		err = e.audit.Record(ctx, e.Name(), p)
+	}
+	// Remember the version of the file read even when it is invalid, so the watcher only retries once it changes again.
+	if info != nil {
+		e.modTime = info.ModTime()
+	}
+	if err != nil {
+		e.lastError = err.Error()
+		return err
+	}
+	e.policy = p
+	e.lastRefresh = time.Now()
+	e.lastError = ""
+	for i := range p.Exemptions {
+		if p.Exemptions[i].Expired(e.lastRefresh) {
+			e.logger.Warnf("Policy %s: %s", e.file, p.Exemptions[i].warning())
+		}
+	}
+	return nil
+}
+
+// Status returns the number of rules loaded and the outcome of the last reload.
+func (e *Engine) Status() cache.Status {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	status := cache.Status{Name: e.Name(), LastRefresh: e.lastRefresh, LastError: e.lastError}
+	if e.policy != nil {
+		status.Size = len(e.policy.Rules)
+	}
+	return status
+}
+
+// Entry returns the rule with the given name.
+func (e *Engine) Entry(name string) (any, bool) {
+	p := e.current()
+	if p == nil {
+		return nil, false
+	}
+	for _, r := range p.Rules {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// Evaluate decides on a component with the current policy and exemptions. It returns false when no policy
+// is configured.
+func (e *Engine) Evaluate(c Component, facts FactsLookup) (Result, bool) {
+	p := e.current()
+	if p == nil {
+		return Result{}, false
+	}
+	return p.Evaluate(c, facts, time.Now()), true
+}
+
+// current returns the loaded policy, or nil when there is none.
+func (e *Engine) current() *Policy {
+	if e == nil {
+		return nil
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.policy
+}
+
+// changed reports whether the policy file was modified since it was last loaded.
+func (e *Engine) changed() bool {
+	info, err := os.Stat(e.file)
+	if err != nil {
+		return false
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return !info.ModTime().Equal(e.modTime)
+}
+
+// watch reloads the policy whenever the file changes, until Stop is called.
+func (e *Engine) watch() {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !e.changed() {
+				continue
+			}
+			if err := e.Refresh(audit.WithActor(context.Background(), audit.ActorFileWatch, "policy file changed")); err != nil {
+				e.logger.Errorf("Failed to reload policy, keeping the previous one: %v", err)
+			} else {
+				e.logger.Infof("Reloaded policy from %s", e.file)
+			}
+		case <-e.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package policy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/audit"
+	"scanoss.com/licenses/pkg/license"
+)
+
+func TestEngine(t *testing.T) {
+	ctx := context.Background()
+	mit, _ := license.ParseExpression("MIT")
+	file := writePolicy(t, "policy.yaml", "rules:\n  - name: mit\n    decision: allow\n    match:\n      licenses: [MIT]\n")
+	engine := NewEngine(file, 10*time.Millisecond, zap.NewNop().Sugar())
+	if err := engine.Start(ctx); err != nil {
+		t.Fatalf("failed to start the policy engine: %v", err)
+	}
+	defer engine.Stop()
+	result, ok := engine.Evaluate(Component{Purl: "pkg:npm/x", Expression: mit}, nil)
+	assert.True(t, ok)
+	assert.Equal(t, Allow, result.Decision)
+	assert.Equal(t, "policy", engine.Name())
+	assert.Equal(t, 1, engine.Status().Size)
+	rule, ok := engine.Entry("mit")
+	if assert.True(t, ok) {
+		assert.Equal(t, Allow, rule.(Rule).Decision)
+	}
+	_, ok = engine.Entry("missing")
+	assert.False(t, ok)
+
+	t.Run("hot reload", func(t *testing.T) {
+		updated := "rules:\n  - name: mit\n    decision: deny\n    match:\n      licenses: [MIT]\n"
+		assert.NoError(t, os.WriteFile(file, []byte(updated), 0o600))
+		// Make sure the modification time changes, whatever the file system granularity.
+		later := time.Now().Add(time.Minute)
+		assert.NoError(t, os.Chtimes(file, later, later))
+		assert.Eventually(t, func() bool {
+			result, _ := engine.Evaluate(Component{Purl: "pkg:npm/x", Expression: mit}, nil)
+			return result.Decision == Deny
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("an invalid file keeps the previous policy", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(file, []byte("rules: [oops"), 0o600))
+		assert.Error(t, engine.Refresh(ctx))
+		assert.NotEmpty(t, engine.Status().LastError)
+		result, ok := engine.Evaluate(Component{Purl: "pkg:npm/x", Expression: mit}, nil)
+		assert.True(t, ok)
+		assert.Equal(t, Deny, result.Decision)
+	})
+
+	t.Run("invalid at start", func(t *testing.T) {
+		assert.Error(t, NewEngine(file, 0, zap.NewNop().Sugar()).Start(ctx))
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		var disabled *Engine
+		assert.Nil(t, NewEngine("", time.Second, zap.NewNop().Sugar()))
+		_, ok := disabled.Evaluate(Component{Purl: "pkg:npm/x", Expression: mit}, nil)
+		assert.False(t, ok)
+	})
+}
+
+func TestEngine_Audit(t *testing.T) {
+	file := writePolicy(t, "policy.yaml", "rules:\n  - name: mit\n    decision: allow\n    match:\n      licenses: [MIT]\n")
+	log, err := audit.Open(filepath.Join(t.TempDir(), "audit.jsonl"))
+	if err != nil {
+		t.Fatalf("failed to open the audit log: %v", err)
+	}
+	engine := NewEngine(file, 0, zap.NewNop().Sugar())
+	engine.UseAudit(log)
+	assert.NoError(t, engine.Start(audit.WithActor(context.Background(), audit.ActorStartup, "")))
+	assert.NoError(t, engine.Refresh(context.Background()), "an unchanged policy is not recorded again")
+
+	assert.NoError(t, os.WriteFile(file, []byte("default: deny\n"), 0o600))
+	assert.NoError(t, engine.Refresh(audit.WithActor(context.Background(), "jane", "drop the MIT rule")))
+	entries := log.Entries(audit.Filter{Subject: "policy"})
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, audit.ActorStartup, entries[0].Actor)
+		assert.Equal(t, audit.ActionUpdate, entries[1].Action)
+		assert.Equal(t, "jane", entries[1].Actor)
+		assert.Equal(t, "drop the MIT rule", entries[1].Reason)
+		assert.Contains(t, string(entries[1].Before), `"name":"mit"`)
+		assert.Contains(t, string(entries[1].After), `"default":"deny"`)
+	}
+}
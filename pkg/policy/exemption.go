@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// expiryLayout is the format of exemption expiry dates.
+const expiryLayout = "2006-01-02"
+
+// Exemption allows licenses of the matching components that the rules would deny or send for review.
+type Exemption struct {
+	// Purl is a pattern for the component purl, using path.Match syntax.
+	Purl string `json:"purl" yaml:"purl"`
+	// Versions is a semver constraint (e.g. ">=1.2.0 <2.0.0") on the component version. Empty matches any version.
+	Versions string `json:"versions,omitempty" yaml:"versions,omitempty"`
+	// Licenses limits the exemption to the listed licenses. Empty exempts every license of the component.
+	Licenses      []string `json:"licenses,omitempty" yaml:"licenses,omitempty"`
+	Justification string   `json:"justification" yaml:"justification"`
+	Approver      string   `json:"approver" yaml:"approver"`
+	// Expires is the last day (UTC) the exemption applies, as YYYY-MM-DD.
+	Expires string `json:"expires" yaml:"expires"`
+
+	constraints *semver.Constraints
+	expiresAt   time.Time
+}
+
+// validate checks the exemption and parses its version constraint and expiry date.
+func (e *Exemption) validate() error {
+	var errs []error
+	if len(e.Purl) == 0 {
+		errs = append(errs, errors.New("missing purl"))
+	} else if _, err := path.Match(e.Purl, ""); err != nil {
+		errs = append(errs, fmt.Errorf("bad purl pattern: %w", err))
+	}
+	if len(e.Versions) > 0 {
+		c, err := semver.NewConstraint(e.Versions)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("bad version range %q: %w", e.Versions, err))
+		}
+		e.constraints = c
+	}
+	if len(e.Justification) == 0 {
+		errs = append(errs, errors.New("missing justification"))
+	}
+	if len(e.Approver) == 0 {
+		errs = append(errs, errors.New("missing approver"))
+	}
+	expires, err := time.Parse(expiryLayout, e.Expires)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("bad expiry date %q, expected YYYY-MM-DD", e.Expires))
+	}
+	// The exemption applies through the whole expiry day.
+	e.expiresAt = expires.AddDate(0, 0, 1)
+	return errors.Join(errs...)
+}
+
+// Expired reports whether the exemption no longer applies at the given time.
+func (e *Exemption) Expired(now time.Time) bool {
+	return !now.Before(e.expiresAt)
+}
+
+// covers reports whether the exemption matches the component and, when lic is not empty, the license.
+func (e *Exemption) covers(c Component, lic string) bool {
+	if ok, _ := path.Match(e.Purl, c.Purl); !ok {
+		return false
+	}
+	if e.constraints != nil {
+		v, err := semver.NewVersion(c.Version)
+		if err != nil || !e.constraints.Check(v) {
+			return false
+		}
+	}
+	if len(e.Licenses) > 0 {
+		return len(lic) > 0 && matchesLicense(e.Licenses, lic)
+	}
+	return true
+}
+
+// warning describes an expired exemption that would otherwise have applied.
+func (e *Exemption) warning() string {
+	return fmt.Sprintf("exemption for %s approved by %s expired on %s", e.Purl, e.Approver, e.Expires)
+}
+
+// exempt returns the first active exemption covering the license lic of the component. When none applies,
+// it returns warnings for the expired exemptions that would have.
+func (p *Policy) exempt(c Component, lic string, now time.Time) (*Exemption, []string) {
+	var warnings []string
+	for i := range p.Exemptions {
+		e := &p.Exemptions[i]
+		if !e.covers(c, lic) {
+			continue
+		}
+		if e.Expired(now) {
+			warnings = append(warnings, e.warning())
+			continue
+		}
+		return e, nil
+	}
+	return nil, warnings
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"scanoss.com/licenses/pkg/license"
+)
+
+const testExemptions = `
+no_license: deny
+rules:
+  - name: no-agpl
+    decision: deny
+    match:
+      licenses: [AGPL-3.0-only]
+exemptions:
+  - purl: "pkg:npm/legacy-*"
+    versions: "<2.0.0"
+    licenses: [AGPL-3.0-only]
+    justification: Internal tool, never distributed
+    approver: legal@acme.com
+    expires: 2026-06-30
+  - purl: pkg:npm/unlicensed
+    justification: Licensed to us by contract
+    approver: legal@acme.com
+    expires: 2026-06-30
+  - purl: pkg:npm/old
+    justification: Replaced by the new client
+    approver: legal@acme.com
+    expires: 2026-01-31
+`
+
+func TestPolicy_Exemptions(t *testing.T) {
+	p, err := Load(writePolicy(t, "policy.yaml", testExemptions))
+	if err != nil {
+		t.Fatalf("failed to load the test policy: %v", err)
+	}
+	now := time.Date(2026, 6, 30, 23, 0, 0, 0, time.UTC)
+	agpl, _ := license.ParseExpression("AGPL-3.0-only")
+	tests := []struct {
+		name      string
+		component Component
+		decision  string
+		exempted  bool
+		warnings  int
+	}{
+		{name: "purl and version", component: Component{Purl: "pkg:npm/legacy-ui", Version: "1.4.0", Expression: agpl}, decision: Allow, exempted: true},
+		{name: "version out of range", component: Component{Purl: "pkg:npm/legacy-ui", Version: "2.1.0", Expression: agpl}, decision: Deny},
+		{name: "no version", component: Component{Purl: "pkg:npm/legacy-ui", Expression: agpl}, decision: Deny},
+		{name: "other purl", component: Component{Purl: "pkg:npm/modern", Version: "1.0.0", Expression: agpl}, decision: Deny},
+		{name: "no license", component: Component{Purl: "pkg:npm/unlicensed"}, decision: Allow, exempted: true},
+		{name: "expired", component: Component{Purl: "pkg:npm/old", Expression: agpl}, decision: Deny, warnings: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := p.Evaluate(tt.component, nil, now)
+			assert.Equal(t, tt.decision, result.Decision)
+			assert.Equal(t, tt.exempted, result.Exemption != nil)
+			assert.Len(t, result.Warnings, tt.warnings)
+		})
+	}
+
+	t.Run("exempted licenses only", func(t *testing.T) {
+		expr, _ := license.ParseExpression("AGPL-3.0-only AND LicenseRef-custom")
+		result := p.Evaluate(Component{Purl: "pkg:npm/legacy-ui", Version: "1.0.0", Expression: expr}, nil, now)
+		assert.Equal(t, NeedsReview, result.Decision, "the exemption only covers AGPL-3.0-only")
+		if assert.Len(t, result.Licenses, 2) {
+			assert.Equal(t, Allow, result.Licenses[0].Decision)
+			assert.Equal(t, "no-agpl", result.Licenses[0].Rule, "the rule overridden is kept")
+			assert.NotNil(t, result.Licenses[0].Exemption)
+			assert.Nil(t, result.Licenses[1].Exemption)
+		}
+	})
+	t.Run("expiry day is included", func(t *testing.T) {
+		component := Component{Purl: "pkg:npm/unlicensed"}
+		assert.Equal(t, Allow, p.Evaluate(component, nil, now).Decision)
+		result := p.Evaluate(component, nil, now.Add(time.Hour))
+		assert.Equal(t, Deny, result.Decision)
+		assert.Equal(t, []string{"exemption for pkg:npm/unlicensed approved by legal@acme.com expired on 2026-06-30"}, result.Warnings)
+	})
+	t.Run("warnings are not repeated", func(t *testing.T) {
+		expr, _ := license.ParseExpression("AGPL-3.0-only AND LicenseRef-custom")
+		result := p.Evaluate(Component{Purl: "pkg:npm/old", Expression: expr}, nil, now)
+		assert.Len(t, result.Warnings, 1)
+	})
+
+	invalid := map[string]string{
+		"missing purl":          "exemptions:\n  - justification: j\n    approver: a\n    expires: 2026-01-01\n",
+		"bad version range":     "exemptions:\n  - purl: pkg:npm/x\n    versions: nope\n    justification: j\n    approver: a\n    expires: 2026-01-01\n",
+		"missing justification": "exemptions:\n  - purl: pkg:npm/x\n    approver: a\n    expires: 2026-01-01\n",
+		"missing approver":      "exemptions:\n  - purl: pkg:npm/x\n    justification: j\n    expires: 2026-01-01\n",
+		"missing expiry":        "exemptions:\n  - purl: pkg:npm/x\n    justification: j\n    approver: a\n",
+		"bad expiry":            "exemptions:\n  - purl: pkg:npm/x\n    justification: j\n    approver: a\n    expires: 01/02/2026\n",
+	}
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(writePolicy(t, "policy.yaml", content))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,345 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package policy evaluates resolved component licenses against allow, deny and review rules
+// loaded from a YAML or JSON policy file.
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"scanoss.com/licenses/pkg/license"
+)
+
+// Decisions a policy can reach for a component.
+const (
+	Allow       = "allow"
+	NeedsReview = "needs-review"
+	Deny        = "deny"
+)
+
+// severity orders the decisions from the most to the least permissive.
+var severity = map[string]int{Allow: 0, NeedsReview: 1, Deny: 2}
+
+// Policy is the content of a policy file.
+type Policy struct {
+	// Default is the decision for licenses no rule matches. Defaults to needs-review.
+	Default string `json:"default" yaml:"default"`
+	// NoLicense is the decision for components without any license. Defaults to needs-review.
+	NoLicense string `json:"no_license" yaml:"no_license"`
+	// Categories name lists of licenses, so rules can refer to them as a group.
+	Categories map[string][]string `json:"categories" yaml:"categories"`
+	// Rules are tried in order; the first rule matching a license decides it.
+	Rules []Rule `json:"rules" yaml:"rules"`
+	// Exemptions allow licenses of specific components that the rules would deny or send for review.
+	Exemptions []Exemption `json:"exemptions" yaml:"exemptions"`
+}
+
+// Rule reaches Decision for the licenses it matches.
+type Rule struct {
+	Name     string `json:"name" yaml:"name"`
+	Decision string `json:"decision" yaml:"decision"`
+	Match    Match  `json:"match" yaml:"match"`
+}
+
+// Match holds the criteria of a rule. Every criterion given must hold; within a criterion, any value may match.
+type Match struct {
+	// Licenses are SPDX IDs, matched ignoring case or through SPDX range semantics
+	// (e.g. "GPL-2.0-or-later" matches "GPL-3.0-only"). A license with an exception also matches its base ID.
+	Licenses []string `json:"licenses,omitempty" yaml:"licenses,omitempty"`
+	// Categories match the licenses listed in the named categories.
+	Categories []string `json:"categories,omitempty" yaml:"categories,omitempty"`
+	// OSADL matches on the OSADL flags of the license. Licenses without OSADL data never match.
+	OSADL *OSADLMatch `json:"osadl,omitempty" yaml:"osadl,omitempty"`
+	// Purls are patterns for the component purl, using path.Match syntax (e.g. "pkg:npm/@acme/*").
+	Purls []string `json:"purls,omitempty" yaml:"purls,omitempty"`
+}
+
+// OSADLMatch matches on OSADL flags; unset flags are not checked.
+type OSADLMatch struct {
+	Copyleft    *bool `json:"copyleft,omitempty" yaml:"copyleft,omitempty"`
+	PatentHints *bool `json:"patent_hints,omitempty" yaml:"patent_hints,omitempty"`
+}
+
+// Load reads and validates a policy file. Files ending in .json are read as JSON, anything else as YAML.
+// Unknown fields are rejected, so misspelt criteria don't silently match everything.
+func Load(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var p Policy
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&p)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&p)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", file, err)
+	}
+	if err = p.validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", file, err)
+	}
+	return &p, nil
+}
+
+// validate checks the policy and fills in the default decisions.
+func (p *Policy) validate() error {
+	if len(p.Default) == 0 {
+		p.Default = NeedsReview
+	}
+	if len(p.NoLicense) == 0 {
+		p.NoLicense = NeedsReview
+	}
+	if err := checkDecision(p.Default); err != nil {
+		return fmt.Errorf("default: %w", err)
+	}
+	if err := checkDecision(p.NoLicense); err != nil {
+		return fmt.Errorf("no_license: %w", err)
+	}
+	var errs []error
+	names := make(map[string]bool, len(p.Rules))
+	for i, r := range p.Rules {
+		if len(r.Name) == 0 {
+			errs = append(errs, fmt.Errorf("rule %d: missing name", i+1))
+		} else if names[r.Name] {
+			errs = append(errs, fmt.Errorf("rule %q: duplicate name", r.Name))
+		}
+		names[r.Name] = true
+		if err := checkDecision(r.Decision); err != nil {
+			errs = append(errs, fmt.Errorf("rule %q: %w", r.Name, err))
+		}
+		m := r.Match
+		if len(m.Licenses) == 0 && len(m.Categories) == 0 && m.OSADL == nil && len(m.Purls) == 0 {
+			errs = append(errs, fmt.Errorf("rule %q: no match criteria, use default instead", r.Name))
+		}
+		for _, c := range m.Categories {
+			if _, ok := p.Categories[c]; !ok {
+				errs = append(errs, fmt.Errorf("rule %q: unknown category %q", r.Name, c))
+			}
+		}
+		for _, pattern := range m.Purls {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errs = append(errs, fmt.Errorf("rule %q: bad purl pattern %q: %w", r.Name, pattern, err))
+			}
+		}
+	}
+	for i := range p.Exemptions {
+		if err := p.Exemptions[i].validate(); err != nil {
+			errs = append(errs, fmt.Errorf("exemption %d: %w", i+1, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkDecision returns an error if decision is not one of the known decisions.
+func checkDecision(decision string) error {
+	if _, ok := severity[decision]; !ok {
+		return fmt.Errorf("unknown decision %q, expected %s, %s or %s", decision, Allow, NeedsReview, Deny)
+	}
+	return nil
+}
+
+// LicenseFacts are the OSADL flags of a license.
+type LicenseFacts struct {
+	Copyleft    bool
+	PatentHints bool
+}
+
+// FactsLookup returns the OSADL flags of a license, or false when there is no OSADL data for it.
+type FactsLookup func(licenseID string) (LicenseFacts, bool)
+
+// Component is what a policy decides on.
+type Component struct {
+	Purl    string
+	Version string
+	// Expression holds the licenses of the component, nil when it has none.
+	Expression *license.Expression
+}
+
+// LicenseResult is the decision reached for one license of a component.
+type LicenseResult struct {
+	License  string
+	Decision string
+	// Rule is the name of the rule that matched, or empty when the default decision applied.
+	Rule string
+	// Exemption is the exemption that allowed the license despite the rule, if any.
+	Exemption *Exemption
+}
+
+// Result is the decision reached for a component.
+type Result struct {
+	Decision string
+	// Rule is the name of the rule behind the decision, or empty when a default decision applied.
+	Rule string
+	// License is the license the rule matched, empty when the component has no license.
+	License string
+	// Exemption is the exemption behind the decision, if any.
+	Exemption *Exemption
+	// Licenses holds the decision for each license of the expression, in order.
+	Licenses []LicenseResult
+	// Warnings report expired exemptions that would otherwise have applied.
+	Warnings []string
+}
+
+// evaluation carries the state of a single Evaluate call.
+type evaluation struct {
+	policy    *Policy
+	component Component
+	facts     FactsLookup
+	now       time.Time
+	leaves    []LicenseResult
+	warnings  []string
+}
+
+// Evaluate decides on a component at the given time. Each license of the expression is decided by the first
+// rule matching it, or by the default decision, unless an active exemption covers it. Licenses joined with AND
+// must all be acceptable, so the strictest decision wins; for licenses joined with OR, the most permissive one
+// does. facts may be nil, in which case OSADL criteria never match.
+func (p *Policy) Evaluate(c Component, facts FactsLookup, now time.Time) Result {
+	ev := &evaluation{policy: p, component: c, facts: facts, now: now, leaves: []LicenseResult{}}
+	var decided LicenseResult
+	if c.Expression == nil {
+		decided = LicenseResult{Decision: p.NoLicense}
+		ev.applyExemption(&decided, "")
+	} else {
+		decided = ev.evaluate(c.Expression)
+	}
+	return Result{Decision: decided.Decision, Rule: decided.Rule, License: decided.License,
+		Exemption: decided.Exemption, Licenses: ev.leaves, Warnings: ev.warnings}
+}
+
+// evaluate returns the license result deciding expr, recording the result of each of its licenses.
+func (ev *evaluation) evaluate(expr *license.Expression) LicenseResult {
+	if expr.IsLeaf() {
+		result := ev.policy.evaluateLicense(ev.component.Purl, expr.License, ev.facts)
+		ev.applyExemption(&result, expr.License)
+		ev.leaves = append(ev.leaves, result)
+		return result
+	}
+	left := ev.evaluate(expr.Left)
+	right := ev.evaluate(expr.Right)
+	leftSeverity, rightSeverity := severity[left.Decision], severity[right.Decision]
+	if expr.Operator == license.OperatorOr {
+		leftSeverity, rightSeverity = -leftSeverity, -rightSeverity
+	}
+	// Ties go to the leftmost license.
+	if rightSeverity > leftSeverity {
+		return right
+	}
+	return left
+}
+
+// applyExemption allows the license lic (empty for a component without licenses) when the rules did not,
+// and an active exemption covers it.
+func (ev *evaluation) applyExemption(result *LicenseResult, lic string) {
+	if result.Decision == Allow {
+		return
+	}
+	exemption, warnings := ev.policy.exempt(ev.component, lic, ev.now)
+	for _, w := range warnings {
+		if !slices.Contains(ev.warnings, w) {
+			ev.warnings = append(ev.warnings, w)
+		}
+	}
+	if exemption != nil {
+		result.Decision = Allow
+		result.Exemption = exemption
+	}
+}
+
+// evaluateLicense decides a single license (possibly with a WITH exception) of the component.
+func (p *Policy) evaluateLicense(purl, lic string, facts FactsLookup) LicenseResult {
+	for _, r := range p.Rules {
+		if p.matches(r.Match, purl, lic, facts) {
+			return LicenseResult{License: lic, Decision: r.Decision, Rule: r.Name}
+		}
+	}
+	return LicenseResult{License: lic, Decision: p.Default}
+}
+
+// matches reports whether every criterion of m holds for the license lic of the component purl.
+func (p *Policy) matches(m Match, purl, lic string, facts FactsLookup) bool {
+	if len(m.Purls) > 0 && !matchesPurl(m.Purls, purl) {
+		return false
+	}
+	if len(m.Licenses) > 0 || len(m.Categories) > 0 {
+		listed := slices.Clone(m.Licenses)
+		for _, c := range m.Categories {
+			listed = append(listed, p.Categories[c]...)
+		}
+		if !matchesLicense(listed, lic) {
+			return false
+		}
+	}
+	if m.OSADL != nil {
+		if facts == nil {
+			return false
+		}
+		f, ok := facts(baseLicense(lic))
+		if !ok {
+			return false
+		}
+		if m.OSADL.Copyleft != nil && *m.OSADL.Copyleft != f.Copyleft {
+			return false
+		}
+		if m.OSADL.PatentHints != nil && *m.OSADL.PatentHints != f.PatentHints {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesPurl reports whether purl matches any of the patterns.
+func matchesPurl(patterns []string, purl string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, purl); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesLicense reports whether lic, or its base license when it carries an exception, is covered by listed.
+func matchesLicense(listed []string, lic string) bool {
+	if license.PreferenceRank(lic, listed) >= 0 {
+		return true
+	}
+	base := baseLicense(lic)
+	return base != lic && license.PreferenceRank(base, listed) >= 0
+}
+
+// baseLicense strips a "WITH <exception>" suffix from lic.
+func baseLicense(lic string) string {
+	if i := strings.Index(strings.ToUpper(lic), " WITH "); i >= 0 {
+		return lic[:i]
+	}
+	return lic
+}
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"scanoss.com/licenses/pkg/license"
+)
+
+const testPolicy = `
+default: needs-review
+no_license: deny
+categories:
+  permissive: [MIT, Apache-2.0, BSD-3-Clause]
+rules:
+  - name: internal-components
+    decision: allow
+    match:
+      purls: ["pkg:npm/@acme/*"]
+  - name: no-agpl
+    decision: deny
+    match:
+      licenses: [AGPL-3.0-only]
+  - name: gpl-for-tools
+    decision: allow
+    match:
+      licenses: [GPL-2.0-or-later]
+      purls: ["pkg:github/acme-tools/*"]
+  - name: copyleft-review
+    decision: needs-review
+    match:
+      osadl:
+        copyleft: true
+  - name: permissive
+    decision: allow
+    match:
+      categories: [permissive]
+`
+
+// writePolicy writes content to a policy file with the given name in a temporary directory.
+func writePolicy(t *testing.T, name, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write policy file: %v", err)
+	}
+	return file
+}
+
+// testFacts reports GPL and AGPL licenses as copyleft.
+func testFacts(licenseID string) (LicenseFacts, bool) {
+	switch licenseID {
+	case "GPL-2.0-only", "GPL-3.0-only", "AGPL-3.0-only":
+		return LicenseFacts{Copyleft: true}, true
+	case "MIT", "Apache-2.0":
+		return LicenseFacts{}, true
+	}
+	return LicenseFacts{}, false
+}
+
+func TestLoad(t *testing.T) {
+	p, err := Load(writePolicy(t, "policy.yaml", testPolicy))
+	if assert.NoError(t, err) {
+		assert.Len(t, p.Rules, 5)
+		assert.Equal(t, Deny, p.NoLicense)
+	}
+
+	p, err = Load(writePolicy(t, "policy.json", `{"rules": [{"name": "mit", "decision": "allow", "match": {"licenses": ["MIT"]}}]}`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, NeedsReview, p.Default, "the default decision defaults to needs-review")
+		assert.Equal(t, NeedsReview, p.NoLicense)
+	}
+
+	invalid := map[string]string{
+		"unknown field":     "rules:\n  - name: a\n    decision: allow\n    match:\n      license: [MIT]\n",
+		"unknown decision":  "rules:\n  - name: a\n    decision: maybe\n    match:\n      licenses: [MIT]\n",
+		"no criteria":       "rules:\n  - name: a\n    decision: allow\n",
+		"missing name":      "rules:\n  - decision: allow\n    match:\n      licenses: [MIT]\n",
+		"duplicate name":    "rules:\n  - name: a\n    decision: allow\n    match:\n      licenses: [MIT]\n  - name: a\n    decision: deny\n    match:\n      licenses: [MIT]\n",
+		"unknown category":  "rules:\n  - name: a\n    decision: allow\n    match:\n      categories: [nope]\n",
+		"bad purl pattern":  "rules:\n  - name: a\n    decision: allow\n    match:\n      purls: [\"pkg:npm/[\"]\n",
+		"bad default":       "default: ok\n",
+		"not a policy file": "- just\n- a list\n",
+	}
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(writePolicy(t, "policy.yml", content))
+			assert.Error(t, err)
+		})
+	}
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	p, err := Load(writePolicy(t, "policy.yaml", testPolicy))
+	if err != nil {
+		t.Fatalf("failed to load the test policy: %v", err)
+	}
+	tests := []struct {
+		name       string
+		purl       string
+		expression string
+		decision   string
+		rule       string
+		license    string
+	}{
+		{name: "category", purl: "pkg:npm/left-pad", expression: "MIT", decision: Allow, rule: "permissive", license: "MIT"},
+		{name: "default", purl: "pkg:npm/left-pad", expression: "LicenseRef-custom", decision: NeedsReview, license: "LicenseRef-custom"},
+		{name: "osadl flag", purl: "pkg:npm/left-pad", expression: "GPL-2.0-only", decision: NeedsReview, rule: "copyleft-review", license: "GPL-2.0-only"},
+		{name: "rules apply in order", purl: "pkg:npm/left-pad", expression: "AGPL-3.0-only", decision: Deny, rule: "no-agpl", license: "AGPL-3.0-only"},
+		{name: "purl pattern", purl: "pkg:npm/@acme/ui", expression: "AGPL-3.0-only", decision: Allow, rule: "internal-components", license: "AGPL-3.0-only"},
+		{name: "purl pattern does not cross slashes", purl: "pkg:npm/@acme/ui/extra", expression: "AGPL-3.0-only", decision: Deny, rule: "no-agpl"},
+		{name: "license and purl", purl: "pkg:github/acme-tools/cli", expression: "GPL-3.0-only", decision: Allow, rule: "gpl-for-tools", license: "GPL-3.0-only"},
+		{name: "license without purl", purl: "pkg:github/other/cli", expression: "GPL-3.0-only", decision: NeedsReview, rule: "copyleft-review"},
+		{name: "AND takes the strictest", purl: "pkg:npm/x", expression: "MIT AND AGPL-3.0-only", decision: Deny, rule: "no-agpl", license: "AGPL-3.0-only"},
+		{name: "OR takes the most permissive", purl: "pkg:npm/x", expression: "AGPL-3.0-only OR MIT", decision: Allow, rule: "permissive", license: "MIT"},
+		{name: "nested", purl: "pkg:npm/x", expression: "Apache-2.0 AND (AGPL-3.0-only OR GPL-2.0-only)", decision: NeedsReview, rule: "copyleft-review", license: "GPL-2.0-only"},
+		{name: "ties go left", purl: "pkg:npm/x", expression: "MIT OR Apache-2.0", decision: Allow, rule: "permissive", license: "MIT"},
+		{name: "exception matches its base license", purl: "pkg:npm/x", expression: "AGPL-3.0-only WITH Classpath-exception-2.0", decision: Deny, rule: "no-agpl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := license.ParseExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("failed to parse %q: %v", tt.expression, err)
+			}
+			result := p.Evaluate(Component{Purl: tt.purl, Expression: expr}, testFacts, time.Now())
+			assert.Equal(t, tt.decision, result.Decision)
+			assert.Equal(t, tt.rule, result.Rule)
+			if len(tt.license) > 0 {
+				assert.Equal(t, tt.license, result.License)
+			}
+			assert.Len(t, result.Licenses, len(expr.Licenses()), "each license is decided")
+		})
+	}
+
+	t.Run("no license", func(t *testing.T) {
+		result := p.Evaluate(Component{Purl: "pkg:npm/x"}, testFacts, time.Now())
+		assert.Equal(t, Deny, result.Decision)
+		assert.Empty(t, result.Rule)
+		assert.Empty(t, result.Licenses)
+	})
+	t.Run("no OSADL data", func(t *testing.T) {
+		expr, _ := license.ParseExpression("GPL-2.0-only")
+		assert.Equal(t, NeedsReview, p.Evaluate(Component{Purl: "pkg:npm/x", Expression: expr}, nil, time.Now()).Decision)
+		assert.Empty(t, p.Evaluate(Component{Purl: "pkg:npm/x", Expression: expr}, nil, time.Now()).Rule, "OSADL criteria never match without OSADL data")
+	})
+}
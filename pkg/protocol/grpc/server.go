@@ -23,11 +23,12 @@ import (
 	gs "github.com/scanoss/go-grpc-helper/pkg/grpc/server"
 	l "github.com/scanoss/papi/api/licensesv2"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	myconfig "scanoss.com/licenses/pkg/config"
 )
 
-// RunServer runs gRPC service to serve incoming requests.
-func RunServer(config *myconfig.ServerConfig, lServer l.LicenseServer, port string,
+// RunServer runs gRPC service to serve incoming requests, alongside the standard health service.
+func RunServer(config *myconfig.ServerConfig, lServer l.LicenseServer, healthServer healthpb.HealthServer, port string,
 	allowedIPs, deniedIPs []string, startTLS bool, version string) (*grpc.Server, error) {
 	// Start up Open Telemetry is requested
 	var oltpShutdown = func() {}
@@ -49,6 +50,7 @@ func RunServer(config *myconfig.ServerConfig, lServer l.LicenseServer, port stri
 	}
 
 	l.RegisterLicenseServer(server, lServer)
+	healthpb.RegisterHealthServer(server, healthServer)
 	go func() {
 		gs.StartGrpcServer(listen, server, startTLS)
 		oltpShutdown()
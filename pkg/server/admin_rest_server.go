@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	common "github.com/scanoss/papi/api/commonv2"
+	"scanoss.com/licenses/pkg/audit"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/dto"
+	"scanoss.com/licenses/pkg/handler"
+	"scanoss.com/licenses/pkg/middleware"
+)
+
+// AdminRESTServer serves the admin endpoints. Every request must carry the configured
+// Admin.Token as a bearer token; when no token is configured the endpoints are not registered.
+type AdminRESTServer struct {
+	config  *myconfig.ServerConfig
+	handler *handler.AdminHandler
+}
+
+// NewAdminRESTServer creates a new instance of the Admin REST Server.
+func NewAdminRESTServer(config *myconfig.ServerConfig, adminHandler *handler.AdminHandler) *AdminRESTServer {
+	return &AdminRESTServer{
+		config:  config,
+		handler: adminHandler,
+	}
+}
+
+// RegisterRoutes adds the admin endpoints to the gateway mux, unless no admin token is configured.
+func (as *AdminRESTServer) RegisterRoutes(mux *runtime.ServeMux) error {
+	if len(as.config.Admin.Token) == 0 {
+		return nil
+	}
+	routes := []struct {
+		method  string
+		path    string
+		handler runtime.HandlerFunc
+	}{
+		{http.MethodGet, "/v2/admin/caches", as.ListCaches},
+		{http.MethodPost, "/v2/admin/caches/refresh", as.RefreshCaches},
+		{http.MethodPost, "/v2/admin/caches/{name}/refresh", as.RefreshCaches},
+		{http.MethodGet, "/v2/admin/caches/{name}/entry", as.GetCacheEntry},
+		{http.MethodGet, "/v2/admin/audit", as.GetAuditLog},
+		{http.MethodGet, "/v2/admin/audit/verify", as.VerifyAuditLog},
+		{http.MethodGet, "/v2/admin/watchlist", as.GetWatchlist},
+		{http.MethodPost, "/v2/admin/watchlist", as.AddWatches},
+		{http.MethodDelete, "/v2/admin/watchlist", as.RemoveWatch},
+	}
+	for _, route := range routes {
+		if err := mux.HandlePath(route.method, route.path, as.authenticate(route.handler)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListCaches reports the size and last refresh of every cache.
+func (as *AdminRESTServer) ListCaches(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	response, code := as.handler.ListCaches(ctx)
+	writeJSON(ctx, w, code, response)
+}
+
+// RefreshCaches reloads the cache named in the path, or every cache. Changes to the policy and curations are
+// recorded in the audit log as made by the X-Audit-Actor header, for the reason in the X-Audit-Reason header.
+// The admin token identifies no one, so the actor is only what the caller claims to be.
+func (as *AdminRESTServer) RefreshCaches(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	ctx := requestContext(r)
+	actor := strings.TrimSpace(r.Header.Get("X-Audit-Actor"))
+	if len(actor) == 0 {
+		actor = audit.ActorAdminAPI
+	}
+	ctx = audit.WithActor(ctx, actor, strings.TrimSpace(r.Header.Get("X-Audit-Reason")))
+	response, code := as.handler.RefreshCaches(ctx, pathParams["name"])
+	writeJSON(ctx, w, code, response)
+}
+
+// GetCacheEntry dumps the entry cached under the "key" query parameter.
+func (as *AdminRESTServer) GetCacheEntry(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	ctx := requestContext(r)
+	response, code := as.handler.GetCacheEntry(ctx, pathParams["name"], r.URL.Query().Get("key"))
+	writeJSON(ctx, w, code, response)
+}
+
+// GetAuditLog returns the audit log entries selected by the query string.
+func (as *AdminRESTServer) GetAuditLog(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	response, code := as.handler.GetAuditLog(ctx, middleware.NewAuditLogMiddleware(r.URL.Query(), ctx))
+	writeJSON(ctx, w, code, response)
+}
+
+// VerifyAuditLog reads the audit log file back and checks its hash chain.
+func (as *AdminRESTServer) VerifyAuditLog(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	response, code := as.handler.VerifyAuditLog(ctx)
+	writeJSON(ctx, w, code, response)
+}
+
+// GetWatchlist lists the watched components with their last known licenses.
+func (as *AdminRESTServer) GetWatchlist(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	response, code := as.handler.GetWatchlist(ctx)
+	writeJSON(ctx, w, code, response)
+}
+
+// AddWatches adds the components in the JSON body to the watchlist.
+func (as *AdminRESTServer) AddWatches(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	body := http.MaxBytesReader(w, r.Body, maxRESTBodyBytes)
+	response, code := as.handler.AddWatches(ctx, middleware.NewWatchlistMiddleware(body, ctx))
+	writeJSON(ctx, w, code, response)
+}
+
+// RemoveWatch removes the component given by the "purl" and "requirement" query parameters from the watchlist.
+func (as *AdminRESTServer) RemoveWatch(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	query := r.URL.Query()
+	response, code := as.handler.RemoveWatch(ctx, strings.TrimSpace(query.Get("purl")), strings.TrimSpace(query.Get("requirement")))
+	writeJSON(ctx, w, code, response)
+}
+
+// authenticate rejects requests that don't carry the admin token as "Authorization: Bearer <token>".
+func (as *AdminRESTServer) authenticate(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(as.config.Admin.Token)) != 1 {
+			ctx := requestContext(r)
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSON(ctx, w, http.StatusUnauthorized, &dto.StatusDTO{
+				Status:  common.StatusCode_FAILED.String(),
+				Message: "Missing or invalid admin token",
+			})
+			return
+		}
+		next(w, r, pathParams)
+	}
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"scanoss.com/licenses/pkg/handler"
+)
+
+// HealthRESTServer serves the Kubernetes style liveness and readiness probes.
+type HealthRESTServer struct {
+	handler *handler.HealthHandler
+}
+
+// NewHealthRESTServer creates a new instance of the Health REST Server.
+func NewHealthRESTServer(healthHandler *handler.HealthHandler) *HealthRESTServer {
+	return &HealthRESTServer{handler: healthHandler}
+}
+
+// RegisterRoutes adds the probe endpoints to the gateway mux.
+func (hs *HealthRESTServer) RegisterRoutes(mux *runtime.ServeMux) error {
+	routes := []struct {
+		method  string
+		path    string
+		handler runtime.HandlerFunc
+	}{
+		{http.MethodGet, "/healthz", hs.Live},
+		{http.MethodGet, "/readyz", hs.Ready},
+	}
+	for _, route := range routes {
+		if err := mux.HandlePath(route.method, route.path, route.handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Live answers the liveness probe.
+func (hs *HealthRESTServer) Live(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	response, code := hs.handler.Live(ctx)
+	writeJSON(ctx, w, code, response)
+}
+
+// Ready answers the readiness probe, describing each check in the body.
+func (hs *HealthRESTServer) Ready(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	response, code := hs.handler.Ready(ctx)
+	writeJSON(ctx, w, code, response)
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	pb "github.com/scanoss/papi/api/licensesv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/handler"
+)
+
+// HealthServer serves the standard gRPC health service. Both the overall status ("") and that of the
+// License service follow the readiness checks, which are re-evaluated every Health.CheckIntervalSeconds.
+type HealthServer struct {
+	*health.Server
+	handler  *handler.HealthHandler
+	interval time.Duration
+}
+
+// NewHealthServer creates a gRPC health service reporting NOT_SERVING until Start evaluates readiness.
+func NewHealthServer(config *myconfig.ServerConfig, healthHandler *handler.HealthHandler) *HealthServer {
+	hs := &HealthServer{
+		Server:   health.NewServer(),
+		handler:  healthHandler,
+		interval: time.Duration(config.Health.CheckIntervalSeconds) * time.Second,
+	}
+	hs.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return hs
+}
+
+// Start evaluates readiness now and then in the background, until the returned function is called.
+// Stopping marks every service as NOT_SERVING, so clients stop routing to a server that is shutting down.
+func (hs *HealthServer) Start(ctx context.Context) func() {
+	ctx = ctxzap.ToContext(ctx, zlog.L)
+	hs.update(ctx)
+	done := make(chan struct{})
+	if hs.interval > 0 {
+		go func() {
+			ticker := time.NewTicker(hs.interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					hs.update(ctx)
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+	return func() {
+		close(done)
+		hs.Shutdown()
+	}
+}
+
+// update sets the serving status from the outcome of the readiness checks.
+func (hs *HealthServer) update(ctx context.Context) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if _, code := hs.handler.Ready(ctx); code == http.StatusOK {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	hs.setServingStatus(status)
+}
+
+// setServingStatus sets the overall status and that of the License service.
+func (hs *HealthServer) setServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	hs.SetServingStatus("", status)
+	hs.SetServingStatus(pb.License_ServiceDesc.ServiceName, status)
+}
@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pb "github.com/scanoss/papi/api/licensesv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/handler"
+	"scanoss.com/licenses/pkg/metrics"
+	"scanoss.com/licenses/pkg/middleware"
+)
+
+// maxRESTBodyBytes caps the size of request bodies accepted by the REST-only endpoints.
+const maxRESTBodyBytes = 10 << 20
+
+// LicenseRESTServer serves the endpoints that have no counterpart in the papi License gRPC service.
+// They are registered on the grpc-gateway mux, so they share the port, TLS and IP filtering of the gateway.
+type LicenseRESTServer struct {
+	config  *myconfig.ServerConfig
+	handler *handler.LicenseHandler
+}
+
+// NewLicenseRESTServer creates a new instance of the License REST Server.
+func NewLicenseRESTServer(config *myconfig.ServerConfig, licenseHandler *handler.LicenseHandler) *LicenseRESTServer {
+	return &LicenseRESTServer{
+		config:  config,
+		handler: licenseHandler,
+	}
+}
+
+// RegisterRoutes adds the REST-only endpoints to the gateway mux.
+func (ls *LicenseRESTServer) RegisterRoutes(mux *runtime.ServeMux) error {
+	routes := []struct {
+		method  string
+		path    string
+		handler runtime.HandlerFunc
+	}{
+		{http.MethodPost, "/v2/licenses/components/extended", ls.GetComponentsLicenseExtended},
+		{http.MethodPost, "/v2/licenses/details/batch", ls.GetDetailsBatch},
+		{http.MethodGet, "/v2/licenses/text/{id}", ls.GetLicenseText},
+		{http.MethodPost, "/v2/licenses/identify", ls.IdentifyLicense},
+		{http.MethodGet, "/v2/licenses/search", ls.SearchLicenses},
+		{http.MethodGet, "/v2/licenses/reverse-lookup", ls.GetComponentsByLicense},
+		{http.MethodGet, "/v2/licenses/stats", ls.GetLicenseStats},
+		{http.MethodGet, "/v2/licenses/changes", ls.GetLicenseChanges},
+		{http.MethodPost, "/v2/licenses/changes", ls.GetLicenseChangesForPurls},
+	}
+	for _, route := range routes {
+		if err := mux.HandlePath(route.method, route.path, instrumentRoute(route.method+" "+route.path, route.handler)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetComponentsLicenseExtended searches licenses for multiple components, including the REST-only extension fields.
+func (ls *LicenseRESTServer) GetComponentsLicenseExtended(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	body := http.MaxBytesReader(w, r.Body, maxRESTBodyBytes)
+	response, code := ls.handler.GetComponentsLicenseExtended(ctx, middleware.NewComponentsExtendedRequestMiddleware(body, ctx))
+	writeJSON(ctx, w, code, response)
+}
+
+// GetDetailsBatch retrieves the details of several licenses, given as a list of IDs and/or an SPDX expression.
+func (ls *LicenseRESTServer) GetDetailsBatch(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	body := http.MaxBytesReader(w, r.Body, maxRESTBodyBytes)
+	response, code := ls.handler.GetDetailsBatch(ctx, middleware.NewLicenseBatchMiddleware(body, ctx))
+	writeJSON(ctx, w, code, response)
+}
+
+// GetLicenseText retrieves the full text, standard header and template of an SPDX license or LicenseRef.
+func (ls *LicenseRESTServer) GetLicenseText(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	ctx := requestContext(r)
+	request := &pb.LicenseRequest{Id: pathParams["id"]}
+	response, code := ls.handler.GetLicenseText(ctx, middleware.NewLicenseDetailMiddleware(request, ctx))
+	writeJSON(ctx, w, code, response)
+}
+
+// IdentifyLicense ranks the known SPDX licenses by their similarity to a pasted license text.
+func (ls *LicenseRESTServer) IdentifyLicense(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	body := http.MaxBytesReader(w, r.Body, maxRESTBodyBytes)
+	response, code := ls.handler.IdentifyLicense(ctx, middleware.NewLicenseIdentifyMiddleware(body, ctx))
+	writeJSON(ctx, w, code, response)
+}
+
+// SearchLicenses lists and searches the license catalogue, with pagination and filters taken from the query string.
+func (ls *LicenseRESTServer) SearchLicenses(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	response, code := ls.handler.SearchLicenses(ctx, middleware.NewLicenseSearchMiddleware(r.URL.Query(), ctx))
+	writeJSON(ctx, w, code, response)
+}
+
+// GetComponentsByLicense lists the components carrying a license, with filters and the page cursor taken from the query string.
+func (ls *LicenseRESTServer) GetComponentsByLicense(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	response, code := ls.handler.GetComponentsByLicense(ctx, middleware.NewComponentsByLicenseMiddleware(r.URL.Query(), ctx))
+	writeJSON(ctx, w, code, response)
+}
+
+// GetLicenseChanges lists the components whose licenses changed, with filters and the page cursor taken from the query string.
+func (ls *LicenseRESTServer) GetLicenseChanges(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	response, code := ls.handler.GetLicenseChanges(ctx, middleware.NewLicenseChangesMiddleware(r.URL.Query(), ctx))
+	writeJSON(ctx, w, code, response)
+}
+
+// GetLicenseChangesForPurls lists the components whose licenses changed, taking the parameters, including
+// the list of watched purls, from the JSON body.
+func (ls *LicenseRESTServer) GetLicenseChangesForPurls(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	body := http.MaxBytesReader(w, r.Body, maxRESTBodyBytes)
+	response, code := ls.handler.GetLicenseChanges(ctx, middleware.NewLicenseChangesBodyMiddleware(body, ctx))
+	writeJSON(ctx, w, code, response)
+}
+
+// GetLicenseStats returns the precomputed knowledge base statistics.
+func (ls *LicenseRESTServer) GetLicenseStats(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx := requestContext(r)
+	response, code := ls.handler.GetLicenseStats(ctx)
+	writeJSON(ctx, w, code, response)
+}
+
+// requestContext attaches the application logger to the request context, as the gRPC
+// interceptors do for gRPC calls.
+func requestContext(r *http.Request) context.Context {
+	return ctxzap.ToContext(r.Context(), zlog.L)
+}
+
+// statusRecorder captures the status code written by a REST handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// instrumentRoute records the count, status codes and latency of the requests served by next under the route label.
+func instrumentRoute(route string, next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		next(recorder, r, pathParams)
+		metrics.ObserveHTTP(route, recorder.code, start)
+	}
+}
+
+// writeJSON writes the given response as JSON with the supplied HTTP status code.
+func writeJSON(ctx context.Context, w http.ResponseWriter, code int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		ctxzap.Extract(ctx).Sugar().Warnf("Failed to write REST response: %v", err)
+	}
+}
@@ -2,13 +2,14 @@ package server
 
 import (
 	"context"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/scanoss/papi/api/commonv2"
 	pb "github.com/scanoss/papi/api/licensesv2"
-	"scanoss.com/licenses/pkg/cache"
 	myconfig "scanoss.com/licenses/pkg/config"
 	"scanoss.com/licenses/pkg/handler"
+	"scanoss.com/licenses/pkg/metrics"
 	"scanoss.com/licenses/pkg/middleware"
 )
 
@@ -20,25 +21,43 @@ type LicenseServer struct {
 }
 
 // NewLicenseServer creates a new instance of Licenses Server.
-func NewLicenseServer(config *myconfig.ServerConfig, db *sqlx.DB, spdxCache cache.SPDXLicenseCacheInterface) pb.LicenseServer {
+// The handler is shared with the REST server so both protocols use the same use case state.
+func NewLicenseServer(config *myconfig.ServerConfig, db *sqlx.DB, licenseHandler *handler.LicenseHandler) pb.LicenseServer {
 	return &LicenseServer{
 		config:  config,
 		db:      db,
-		handler: handler.NewLicenseHandler(config, db, spdxCache),
+		handler: licenseHandler,
 	}
 }
 
 // GetComponentLicenses search licenses for one component.
 func (ls LicenseServer) GetComponentLicenses(ctx context.Context, req *commonv2.ComponentRequest) (*pb.ComponentLicenseResponse, error) {
-	return ls.handler.GetComponentLicense(ctx, middleware.NewComponentRequestMiddleware(req, ctx))
+	start := time.Now()
+	response, err := ls.handler.GetComponentLicense(ctx, middleware.NewComponentRequestMiddleware(req, ctx))
+	metrics.ObserveRPC("GetComponentLicenses", rpcStatus(response.GetStatus(), err), start)
+	return response, err
 }
 
 // GetComponentsLicenses search licenses for multiple components in a single request.
 func (ls LicenseServer) GetComponentsLicenses(ctx context.Context, request *commonv2.ComponentsRequest) (*pb.ComponentsLicenseResponse, error) {
-	return ls.handler.GetComponentsLicense(ctx, middleware.NewComponentsRequestMiddleware(request, ctx))
+	start := time.Now()
+	response, err := ls.handler.GetComponentsLicense(ctx, middleware.NewComponentsRequestMiddleware(request, ctx))
+	metrics.ObserveRPC("GetComponentsLicenses", rpcStatus(response.GetStatus(), err), start)
+	return response, err
 }
 
 // GetDetails searches for license information.
 func (ls LicenseServer) GetDetails(ctx context.Context, request *pb.LicenseRequest) (*pb.LicenseDetailsResponse, error) {
-	return ls.handler.GetDetails(ctx, middleware.NewLicenseDetailMiddleware(request, ctx))
+	start := time.Now()
+	response, err := ls.handler.GetDetails(ctx, middleware.NewLicenseDetailMiddleware(request, ctx))
+	metrics.ObserveRPC("GetDetails", rpcStatus(response.GetStatus(), err), start)
+	return response, err
+}
+
+// rpcStatus returns the status label of a gRPC response: its status code, or ERROR when the call failed outright.
+func rpcStatus(status *commonv2.StatusResponse, err error) string {
+	if err != nil {
+		return "ERROR"
+	}
+	return status.GetStatus().String()
 }
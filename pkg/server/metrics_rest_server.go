@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/metrics"
+)
+
+// MetricsRESTServer exposes the Prometheus metrics of the service on the REST gateway.
+type MetricsRESTServer struct {
+	config *myconfig.ServerConfig
+}
+
+// NewMetricsRESTServer creates a new instance of the Metrics REST Server.
+func NewMetricsRESTServer(config *myconfig.ServerConfig) *MetricsRESTServer {
+	return &MetricsRESTServer{config: config}
+}
+
+// RegisterRoutes adds the /metrics endpoint to the gateway mux, unless metrics are disabled.
+func (ms *MetricsRESTServer) RegisterRoutes(mux *runtime.ServeMux) error {
+	if !ms.config.Metrics.Enabled {
+		return nil
+	}
+	handler := metrics.Handler()
+	return mux.HandlePath(http.MethodGet, "/metrics", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		handler.ServeHTTP(w, r)
+	})
+}
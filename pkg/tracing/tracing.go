@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package tracing holds the OpenTelemetry spans the License service records below each RPC span.
+// Spans go to the global tracer provider, which is a no-op unless telemetry is enabled.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "scanoss.com/licenses"
+
+// Span attribute keys.
+const (
+	// PurlType is the type of the component's purl (e.g. "npm").
+	PurlType = attribute.Key("scanoss.purl.type")
+	// Source is the ID of the source the component's licenses were taken from.
+	Source = attribute.Key("scanoss.license.source_id")
+	// Curated reports that the component's licenses were set by a local curation override.
+	Curated = attribute.Key("scanoss.license.curated")
+	// Step is the license resolution step: exact_version, nearest_version or unversioned.
+	Step = attribute.Key("scanoss.resolution.step")
+	// CandidateVersions is the number of known versions a step could pick from.
+	CandidateVersions = attribute.Key("scanoss.resolution.candidate_versions")
+	// Found reports whether a step found licenses.
+	Found = attribute.Key("scanoss.resolution.found")
+	// ResultCode is the info code the component was answered with, SUCCESS when none was set.
+	ResultCode = attribute.Key("scanoss.result.code")
+	// LicenseIDs is the number of license records resolved to SPDX licenses.
+	LicenseIDs = attribute.Key("scanoss.license.ids")
+	// DBOperation is the model method running a database query.
+	DBOperation = attribute.Key("db.operation.name")
+)
+
+// Start starts a span called name as a child of the span in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// ResultCodeValue returns the ResultCode attribute for an info code; empty means success.
+func ResultCodeValue(infoCode string) attribute.KeyValue {
+	if len(infoCode) == 0 {
+		infoCode = "SUCCESS"
+	}
+	return ResultCode.String(infoCode)
+}
+
+// End records err on span, if set, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scanoss/go-component-helper/componenthelper"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/domain"
+	pb "github.com/scanoss/papi/api/licensesv2"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"scanoss.com/licenses/pkg/cache"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+// componentResultCache caches resolved component licenses, keyed by componentResultKey.
+type componentResultCache = cache.ResultCache[*componentLicenseResult]
+
+// newComponentResultCache creates a result cache holding up to size lookups for ttlMinutes each,
+// or returns nil when either is not positive (the cache is disabled).
+func newComponentResultCache(name string, size, ttlMinutes int) *componentResultCache {
+	if size <= 0 || ttlMinutes <= 0 {
+		return nil
+	}
+	return cache.NewResultCache[*componentLicenseResult](name, size, time.Duration(ttlMinutes)*time.Minute)
+}
+
+// componentResultKey identifies a lookup: the purl and requirement as requested, plus the source priority
+// and curations the result was resolved with, so a change of either never serves results picked under the old one.
+func (lu LicenseUseCase) componentResultKey(purl, requirement string) string {
+	priority := make([]string, len(lu.config.Lookup.SourcePriority))
+	for i, id := range lu.config.Lookup.SourcePriority {
+		priority[i] = strconv.Itoa(int(id))
+	}
+	return purl + "\x00" + requirement + "\x00" + strings.Join(priority, ",") + "\x00" + strconv.Itoa(lu.curations.Generation())
+}
+
+// cachedComponentResults splits the requested components into the results served by the result and negative
+// caches and the components still to be resolved. Cached results are cloned, so callers can't alter the cached copy.
+func (lu LicenseUseCase) cachedComponentResults(componentDTOs []componenthelper.ComponentDTO) (
+	hits []*componentLicenseResult, misses []componenthelper.ComponentDTO) {
+	if lu.resultCache == nil && lu.negativeCache == nil {
+		return nil, componentDTOs
+	}
+	for _, c := range componentDTOs {
+		key := lu.componentResultKey(c.Purl, c.Requirement)
+		if r, ok := getComponentResult(lu.resultCache, key); ok {
+			hits = append(hits, r.clone())
+			continue
+		}
+		if r, ok := getComponentResult(lu.negativeCache, key); ok {
+			hits = append(hits, r.clone())
+			continue
+		}
+		misses = append(misses, c)
+	}
+	return hits, misses
+}
+
+// getComponentResult looks key up in c, which may be nil (disabled).
+func getComponentResult(c *componentResultCache, key string) (*componentLicenseResult, bool) {
+	if c == nil {
+		return nil, false
+	}
+	return c.Get(key)
+}
+
+// cacheComponentResults stores the results that carry licenses in the result cache, and the "not found"
+// outcomes (unknown component, no license info) in the negative cache, which has a shorter TTL.
+// Results that met a lookup error are not cached, so a transient failure is not served as the answer.
+func (lu LicenseUseCase) cacheComponentResults(results []*componentLicenseResult) {
+	for _, r := range results {
+		if r.err != nil {
+			continue
+		}
+		target := lu.resultCache
+		if len(r.info.GetLicenses()) == 0 {
+			if !isNotFoundResult(r) {
+				continue
+			}
+			target = lu.negativeCache
+		}
+		if target != nil {
+			target.Add(lu.componentResultKey(r.info.Purl, r.info.Requirement), r.clone())
+		}
+	}
+}
+
+// isNotFoundResult reports whether the lookup found no component or no license info for it.
+func isNotFoundResult(r *componentLicenseResult) bool {
+	code := r.info.GetInfoCode()
+	return code == domain.NoInfo.String() || code == domain.ComponentNotFound.String()
+}
+
+// resolveComponentCoalesced resolves a component, sharing the work with any identical lookup already in flight:
+// concurrent requests for the same purl, requirement and source priority wait for a single resolution.
+// The resolution runs detached from ctx, so one caller going away doesn't fail the others, but for no longer
+// than Lookup.SharedTimeoutSeconds, so a hung query doesn't hold up every later caller; this caller stops waiting
+// (and gets no result) when ctx is done. Results are cached before being handed out.
+func (lu LicenseUseCase) resolveComponentCoalesced(ctx context.Context, s *zap.SugaredLogger,
+	componentDTO componenthelper.ComponentDTO, check *dbCheck) []*componentLicenseResult {
+	key := lu.componentResultKey(componentDTO.Purl, componentDTO.Requirement)
+	ch := lu.inflight.DoChan(key, func() (any, error) {
+		resolveCtx := context.WithoutCancel(ctx)
+		if timeout := lu.config.Lookup.SharedTimeoutSeconds; timeout > 0 {
+			var cancel context.CancelFunc
+			resolveCtx, cancel = context.WithTimeout(resolveCtx, time.Duration(timeout)*time.Second)
+			defer cancel()
+		}
+		results := lu.resolveComponent(resolveCtx, s, componentDTO, lookupOptions{dbCheck: check})
+		lu.cacheComponentResults(results)
+		return results, nil
+	})
+	select {
+	case <-ctx.Done():
+		return nil
+	case r := <-ch:
+		results := r.Val.([]*componentLicenseResult)
+		if !r.Shared {
+			return results
+		}
+		s.Debugf("Shared in-flight resolution of %s@%s", componentDTO.Purl, componentDTO.Requirement)
+		clones := make([]*componentLicenseResult, len(results))
+		for i, result := range results {
+			clones[i] = result.clone()
+		}
+		return clones
+	}
+}
+
+// clone returns a copy of the result whose info can be modified independently. The expression and source are immutable.
+func (r *componentLicenseResult) clone() *componentLicenseResult {
+	return &componentLicenseResult{
+		info:       proto.Clone(r.info).(*pb.ComponentLicenseInfo),
+		purl:       r.purl,
+		version:    r.version,
+		err:        r.err,
+		expression: r.expression,
+		source:     r.source,
+	}
+}
+
+// ResultCacheStats returns the hit/miss counters and size of the component result cache,
+// or false when the cache is disabled.
+func (lu LicenseUseCase) ResultCacheStats() (cache.ResultCacheStats, bool) {
+	if lu.resultCache == nil {
+		return cache.ResultCacheStats{}, false
+	}
+	return lu.resultCache.Stats(), true
+}
+
+// NegativeCacheStats returns the hit/miss counters and size of the "not found" result cache,
+// or false when negative caching is disabled.
+func (lu LicenseUseCase) NegativeCacheStats() (cache.ResultCacheStats, bool) {
+	if lu.negativeCache == nil {
+		return cache.ResultCacheStats{}, false
+	}
+	return lu.negativeCache.Stats(), true
+}
+
+// componentCacheView exposes a component result cache to the admin API. Entry keys are the purl, optionally
+// followed by a space and the requirement, as sent in the lookup; the source priority is the configured one.
+type componentCacheView struct {
+	*componentResultCache
+	lu *LicenseUseCase
+}
+
+// componentCacheEntry is a cached lookup, as dumped by the admin API.
+type componentCacheEntry struct {
+	Info       *pb.ComponentLicenseInfo `json:"info"`
+	Expression string                   `json:"expression,omitempty"`
+}
+
+// Entry returns the lookup cached for "<purl> [requirement]".
+func (v componentCacheView) Entry(key string) (any, bool) {
+	purl, requirement, _ := strings.Cut(key, " ")
+	value, ok := v.componentResultCache.Entry(v.lu.componentResultKey(purl, requirement))
+	if !ok {
+		return nil, false
+	}
+	r := value.(*componentLicenseResult)
+	entry := componentCacheEntry{Info: r.info}
+	if r.expression != nil {
+		entry.Expression = r.expression.String()
+	}
+	return entry, true
+}
+
+// Caches returns the component result caches and the license text directory, if any, for the admin API.
+// Disabled caches are left out.
+// The views key their entries with the curations set on lu when they are read, not when Caches is called.
+func (lu *LicenseUseCase) Caches() []cache.Reloadable {
+	var caches []cache.Reloadable
+	for _, c := range []*componentResultCache{lu.resultCache, lu.negativeCache} {
+		if c != nil {
+			caches = append(caches, componentCacheView{componentResultCache: c, lu: lu})
+		}
+	}
+	if dir, ok := lu.licenseTextModel.(*models.LicenseTextDirModel); ok {
+		caches = append(caches, &licenseTextsView{dir: dir, matcher: lu.textMatcher})
+	}
+	return caches
+}
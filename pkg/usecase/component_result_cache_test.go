@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-component-helper/componenthelper"
+	pb "github.com/scanoss/papi/api/licensesv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	myconfig "scanoss.com/licenses/pkg/config"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+func TestLicenseUseCase_ResultCache(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db, err := sqlx.Connect("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, ctx); err != nil {
+		t.Fatalf("Error loading test SQL data %v", err)
+	}
+	config := &myconfig.ServerConfig{}
+	config.Lookup.MaxWorkers = 5
+	config.Lookup.SourcePriority = []int16{31, 32, 33, 5}
+	config.Cache.ResultSize = 10
+	config.Cache.ResultTTLMinutes = 5
+	config.Cache.NegativeTTLMinutes = 1
+	uc := NewLicenseUseCase(config, db, nil, nil, nil)
+	components := []componenthelper.ComponentDTO{
+		{Purl: "pkg:gitlab/gpl/project", Requirement: "1.0.0"},
+		{Purl: "pkg:npm/does-not-exist", Requirement: "1.0.0"},
+	}
+
+	first, ucErr := uc.GetComponentsLicense(ctx, components)
+	assert.Nil(t, ucErr)
+	stats, enabled := uc.ResultCacheStats()
+	assert.True(t, enabled)
+	assert.Equal(t, uint64(0), stats.Hits)
+	assert.Equal(t, 1, stats.Size, "only results carrying licenses are cached")
+	negative, enabled := uc.NegativeCacheStats()
+	assert.True(t, enabled)
+	assert.Equal(t, 1, negative.Size, "unknown components are cached as not found")
+
+	second, ucErr := uc.GetComponentsLicense(ctx, components)
+	assert.Nil(t, ucErr)
+	stats, _ = uc.ResultCacheStats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(3), stats.Misses)
+	negative, _ = uc.NegativeCacheStats()
+	assert.Equal(t, uint64(1), negative.Hits)
+	assert.Len(t, second, len(first))
+	for _, r := range second {
+		if r.Purl == "pkg:gitlab/gpl/project" {
+			assert.NotEmpty(t, r.Licenses)
+			// Mutating a served result must not alter the cached copy.
+			r.Licenses = nil
+		}
+	}
+	third, _ := uc.GetComponentsLicense(ctx, components[:1])
+	assert.NotEmpty(t, third[0].Licenses)
+
+	t.Run("a different source priority is a different key", func(t *testing.T) {
+		other := *config
+		other.Lookup.SourcePriority = []int16{0}
+		uc.config = &other
+		key := uc.componentResultKey("pkg:gitlab/gpl/project", "1.0.0")
+		uc.config = config
+		assert.NotEqual(t, uc.componentResultKey("pkg:gitlab/gpl/project", "1.0.0"), key)
+	})
+
+	t.Run("admin view", func(t *testing.T) {
+		caches := uc.Caches()
+		if assert.Len(t, caches, 2) {
+			assert.Equal(t, "component-results", caches[0].Name())
+			assert.Equal(t, "component-not-found", caches[1].Name())
+		}
+		entry, ok := caches[0].Entry("pkg:gitlab/gpl/project 1.0.0")
+		if assert.True(t, ok) {
+			assert.Equal(t, "pkg:gitlab/gpl/project", entry.(componentCacheEntry).Info.GetPurl())
+		}
+		_, ok = caches[0].Entry("pkg:gitlab/gpl/project")
+		assert.False(t, ok, "the requirement is part of the key")
+		_, ok = caches[1].Entry("pkg:npm/does-not-exist 1.0.0")
+		assert.True(t, ok)
+
+		assert.NoError(t, caches[0].Refresh(ctx))
+		assert.Equal(t, 0, caches[0].Status().Size)
+		assert.False(t, caches[0].Status().LastRefresh.IsZero())
+	})
+
+	t.Run("lookup errors are not cached", func(t *testing.T) {
+		closed, err := sqlx.Connect("sqlite", "file:closed?mode=memory")
+		if err != nil {
+			t.Fatalf("Error connecting to DB %v", err)
+		}
+		models.CloseDB(closed)
+		failing := NewLicenseUseCase(config, closed, nil, nil, nil)
+		results, ucErr := failing.GetComponentsLicense(ctx, components)
+		assert.Nil(t, ucErr)
+		assert.Len(t, results, len(components))
+		stats, _ := failing.ResultCacheStats()
+		assert.Equal(t, 0, stats.Size)
+		negative, _ := failing.NegativeCacheStats()
+		assert.Equal(t, 0, negative.Size, "failed lookups are not cached as not found")
+	})
+
+	t.Run("the database is pinged once per lookup", func(t *testing.T) {
+		reachable, err := sqlx.Connect("sqlite", "file:pinged?mode=memory")
+		if err != nil {
+			t.Fatalf("Error connecting to DB %v", err)
+		}
+		check := &dbCheck{db: reachable}
+		assert.NoError(t, check.reachable(ctx))
+		models.CloseDB(reachable)
+		assert.NoError(t, check.reachable(ctx), "the outcome of the first ping is reused")
+		assert.Error(t, (&dbCheck{db: reachable}).reachable(ctx))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		disabled := *config
+		disabled.Cache.ResultSize = 0
+		_, enabled = NewLicenseUseCase(&disabled, db, nil, nil, nil).ResultCacheStats()
+		assert.False(t, enabled)
+		_, enabled = NewLicenseUseCase(&disabled, db, nil, nil, nil).NegativeCacheStats()
+		assert.False(t, enabled)
+		assert.Empty(t, NewLicenseUseCase(&disabled, db, nil, nil, nil).Caches())
+	})
+}
+
+func TestLicenseUseCase_CoalescesConcurrentLookups(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	config := &myconfig.ServerConfig{}
+	config.Lookup.MaxWorkers = 5
+	config.Lookup.SourcePriority = []int16{31, 32, 33, 5}
+	uc := NewLicenseUseCase(config, nil, nil, nil, nil)
+	component := componenthelper.ComponentDTO{Purl: "pkg:npm/popular", Requirement: "1.0.0"}
+
+	// Hold a resolution for the component in flight; identical lookups must wait for it instead of resolving again.
+	release := make(chan struct{})
+	code := "IN_FLIGHT"
+	leader := uc.inflight.DoChan(uc.componentResultKey(component.Purl, component.Requirement), func() (any, error) {
+		<-release
+		return []*componentLicenseResult{{info: &pb.ComponentLicenseInfo{Purl: component.Purl, InfoCode: &code}}}, nil
+	})
+
+	const callers = 20
+	var wg sync.WaitGroup
+	results := make(chan []*pb.ComponentLicenseInfo, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r, _ := uc.GetComponentsLicense(ctx, []componenthelper.ComponentDTO{component})
+			results <- r
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+	<-leader
+	var infos []*pb.ComponentLicenseInfo
+	for r := range results {
+		if assert.Len(t, r, 1) {
+			assert.Equal(t, code, r[0].GetInfoCode())
+			infos = append(infos, r[0])
+		}
+	}
+	assert.Len(t, infos, callers)
+	// Each caller gets its own copy of the shared result.
+	assert.NotSame(t, infos[0], infos[1])
+}
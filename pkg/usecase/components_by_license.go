@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/dto"
+	"scanoss.com/licenses/pkg/license"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+// GetComponentsByLicense returns the purl/versions in purl_licenses that carry the requested license,
+// one page at a time. A single license ID matches every licenses row that references it, alone or as
+// part of an expression or legacy list; an expression only matches rows holding that same expression.
+// Pages are ordered by (purl, version, source_id, license_id); nextCursor resumes after the last row.
+func (lu LicenseUseCase) GetComponentsByLicense(ctx context.Context, s *zap.SugaredLogger,
+	request dto.ComponentsByLicenseRequestDTO) (components []dto.LicensedComponentDTO, nextCursor string, ucErr *Error) {
+	after, err := decodePurlLicenseCursor(request.Cursor)
+	if err != nil {
+		return nil, "", &Error{Status: common.StatusCode_FAILED, Code: http.StatusBadRequest, Message: err.Error(), Error: err}
+	}
+	expr, err := license.ParseExpression(request.License)
+	if err != nil {
+		return nil, "", &Error{Status: common.StatusCode_FAILED, Code: http.StatusBadRequest, Message: err.Error(), Error: err}
+	}
+	records, err := lu.licenseRecordModel.GetLicenseRecordsContaining(ctx, s, expr.Licenses()[0])
+	if err != nil {
+		return nil, "", &Error{Status: common.StatusCode_FAILED, Code: http.StatusInternalServerError, Message: err.Error(), Error: err}
+	}
+	labels := make(map[int32]string)
+	var licenseIDs []int32
+	for _, r := range records {
+		if licenseRecordMatches(r, request.License, expr) {
+			labels[r.ID] = r.SPDX
+			if len(r.SPDX) == 0 {
+				labels[r.ID] = r.LicenseName
+			}
+			licenseIDs = append(licenseIDs, r.ID)
+		}
+	}
+	components = []dto.LicensedComponentDTO{}
+	if len(licenseIDs) == 0 {
+		s.Debugf("No licenses rows match %q", request.License)
+		return components, "", nil
+	}
+	// Fetch one extra row to find out whether there is a next page.
+	rows, err := lu.purlLicenseModel.GetPurlsByLicenseIDs(ctx, licenseIDs, request.PurlType, request.SourceIDs, after, request.Limit+1)
+	if err != nil {
+		return nil, "", &Error{Status: common.StatusCode_FAILED, Code: http.StatusInternalServerError, Message: err.Error(), Error: err}
+	}
+	if len(rows) > request.Limit {
+		rows = rows[:request.Limit]
+		last := rows[len(rows)-1]
+		nextCursor = encodePurlLicenseCursor(models.PurlLicenseCursor{Purl: last.Purl, Version: last.Version, SourceID: last.SourceID, LicenseID: last.LicenseID})
+	}
+	for _, r := range rows {
+		components = append(components, dto.LicensedComponentDTO{
+			Purl:     r.Purl,
+			Version:  r.Version,
+			Date:     r.Date,
+			SourceID: r.SourceID,
+			License:  labels[r.LicenseID],
+		})
+	}
+	s.Debugf("Found %d components for %q across %d licenses rows", len(components), request.License, len(licenseIDs))
+	return components, nextCursor, nil
+}
+
+// licenseRecordMatches reports whether a licenses row carries the requested license (see GetComponentsByLicense).
+func licenseRecordMatches(record models.LicenseRecord, requested string, expr *license.Expression) bool {
+	if strings.EqualFold(record.SPDX, requested) || strings.EqualFold(record.LicenseName, requested) {
+		return true
+	}
+	ids, _ := license.ParseLicenseExpression(record.SPDX)
+	if expr.IsLeaf() {
+		for _, id := range ids {
+			if strings.EqualFold(id, expr.License) {
+				return true
+			}
+		}
+		return false
+	}
+	recordExpr := license.ExpressionFromRecord(record.SPDX, ids)
+	return recordExpr != nil && strings.EqualFold(recordExpr.String(), expr.String())
+}
+
+// encodePurlLicenseCursor renders a keyset position as an opaque cursor.
+func encodePurlLicenseCursor(cursor models.PurlLicenseCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePurlLicenseCursor parses a cursor produced by encodePurlLicenseCursor. An empty cursor means the first page.
+func decodePurlLicenseCursor(cursor string) (*models.PurlLicenseCursor, error) {
+	if len(cursor) == 0 {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+	var result models.PurlLicenseCursor
+	if err = json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/dto"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+func TestLicenseUseCase_GetComponentsByLicense(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, ctx); err != nil {
+		t.Fatalf("Error loading test SQL data %v", err)
+	}
+	uc := NewLicenseUseCase(&myconfig.ServerConfig{}, db, nil, nil, nil)
+
+	t.Run("single license includes expressions referencing it", func(t *testing.T) {
+		components, _, ucErr := uc.GetComponentsByLicense(ctx, s, dto.ComponentsByLicenseRequestDTO{License: "mit", Limit: 100})
+		if ucErr != nil {
+			t.Fatalf("unexpected error: %v", ucErr)
+		}
+		found := map[string]string{}
+		for _, c := range components {
+			found[c.Purl] = c.License
+		}
+		if found["pkg:github/dual/licensed"] != "GPL-2.0-only OR MIT" || found["pkg:npm/lodash"] != "MIT" {
+			t.Errorf("unexpected components: %+v", components)
+		}
+	})
+
+	t.Run("expression only matches the same expression", func(t *testing.T) {
+		components, _, ucErr := uc.GetComponentsByLicense(ctx, s, dto.ComponentsByLicenseRequestDTO{License: "mit or gpl-2.0-only", Limit: 100})
+		if ucErr != nil {
+			t.Fatalf("unexpected error: %v", ucErr)
+		}
+		if len(components) != 0 {
+			t.Errorf("expected no components for a differently ordered expression, got %+v", components)
+		}
+		components, _, ucErr = uc.GetComponentsByLicense(ctx, s, dto.ComponentsByLicenseRequestDTO{License: "GPL-2.0-only or MIT", Limit: 100})
+		if ucErr != nil {
+			t.Fatalf("unexpected error: %v", ucErr)
+		}
+		if len(components) != 1 || components[0].Purl != "pkg:github/dual/licensed" {
+			t.Errorf("unexpected components: %+v", components)
+		}
+	})
+
+	t.Run("cursor walks all pages", func(t *testing.T) {
+		request := dto.ComponentsByLicenseRequestDTO{License: "Apache-2.0", Limit: 1}
+		var purls []string
+		for page := 0; page < 10; page++ {
+			components, next, ucErr := uc.GetComponentsByLicense(ctx, s, request)
+			if ucErr != nil {
+				t.Fatalf("unexpected error: %v", ucErr)
+			}
+			for _, c := range components {
+				purls = append(purls, c.Purl)
+			}
+			if next == "" {
+				break
+			}
+			request.Cursor = next
+		}
+		if len(purls) != 4 {
+			t.Errorf("expected 4 Apache-2.0 rows, got %v", purls)
+		}
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		components, _, ucErr := uc.GetComponentsByLicense(ctx, s,
+			dto.ComponentsByLicenseRequestDTO{License: "Apache-2.0", PurlType: "maven", SourceIDs: []int16{3}, Limit: 100})
+		if ucErr != nil {
+			t.Fatalf("unexpected error: %v", ucErr)
+		}
+		if len(components) != 1 || components[0].SourceID != 3 {
+			t.Errorf("unexpected components: %+v", components)
+		}
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		for _, request := range []dto.ComponentsByLicenseRequestDTO{
+			{License: "MIT", Cursor: "not a cursor!", Limit: 1},
+			{License: "MIT OR", Limit: 1},
+		} {
+			if _, _, ucErr := uc.GetComponentsByLicense(ctx, s, request); ucErr == nil || ucErr.Code != 400 {
+				t.Errorf("expected a bad request error for %+v, got %v", request, ucErr)
+			}
+		}
+	})
+}
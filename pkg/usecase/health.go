@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"scanoss.com/licenses/pkg/cache"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+// Health check outcomes.
+const (
+	HealthPass = "pass"
+	HealthFail = "fail"
+)
+
+// HealthUseCase evaluates whether the service is ready to answer lookups.
+type HealthUseCase struct {
+	config    *myconfig.ServerConfig
+	db        *sqlx.DB
+	spdxCache cache.Reloadable
+}
+
+// NewHealthUseCase creates a new instance of the Health UseCase.
+func NewHealthUseCase(config *myconfig.ServerConfig, db *sqlx.DB, spdxCache cache.Reloadable) *HealthUseCase {
+	return &HealthUseCase{config: config, db: db, spdxCache: spdxCache}
+}
+
+// Readiness runs the readiness checks: the database answers a ping, the SPDX license cache is loaded,
+// and its last successful refresh is no older than Health.MaxCacheAgeHours.
+// It returns the outcome of every check and whether they all passed.
+func (h HealthUseCase) Readiness(ctx context.Context) ([]dto.HealthCheckDTO, bool) {
+	checks := []dto.HealthCheckDTO{h.checkDatabase(ctx)}
+	checks = append(checks, h.checkSPDXCache()...)
+	ready := true
+	for _, c := range checks {
+		ready = ready && c.Status == HealthPass
+	}
+	return checks, ready
+}
+
+// checkDatabase pings the database, within Health.PingTimeoutSeconds.
+func (h HealthUseCase) checkDatabase(ctx context.Context) dto.HealthCheckDTO {
+	check := dto.HealthCheckDTO{Name: "database"}
+	if h.db == nil {
+		return failCheck(check, "no database connection")
+	}
+	if timeout := time.Duration(h.config.Health.PingTimeoutSeconds) * time.Second; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	if err := h.db.PingContext(ctx); err != nil {
+		return failCheck(check, fmt.Sprintf("ping failed: %v", err))
+	}
+	return passCheck(check, fmt.Sprintf("ping answered in %v", time.Since(start).Round(time.Millisecond)))
+}
+
+// checkSPDXCache checks that the SPDX license cache holds licenses, and that they are not stale.
+func (h HealthUseCase) checkSPDXCache() []dto.HealthCheckDTO {
+	loaded := dto.HealthCheckDTO{Name: "spdx_cache_loaded"}
+	age := dto.HealthCheckDTO{Name: "spdx_cache_age"}
+	if h.spdxCache == nil {
+		return []dto.HealthCheckDTO{failCheck(loaded, "no SPDX license cache"), failCheck(age, "no SPDX license cache")}
+	}
+	status := h.spdxCache.Status()
+	if status.Size == 0 || status.LastRefresh.IsZero() {
+		loaded = failCheck(loaded, withLastError("SPDX license cache is empty", status.LastError))
+	} else {
+		loaded = passCheck(loaded, fmt.Sprintf("%d licenses loaded", status.Size))
+	}
+	maxAge := time.Duration(h.config.Health.MaxCacheAgeHours) * time.Hour
+	switch {
+	case status.LastRefresh.IsZero():
+		age = failCheck(age, withLastError("SPDX license cache was never loaded", status.LastError))
+	case maxAge > 0 && status.Stale(maxAge):
+		age = failCheck(age, withLastError(fmt.Sprintf("last refreshed %v ago, more than %v", status.Age().Round(time.Second), maxAge), status.LastError))
+	default:
+		age = passCheck(age, fmt.Sprintf("last refreshed %v ago", status.Age().Round(time.Second)))
+	}
+	return []dto.HealthCheckDTO{loaded, age}
+}
+
+// withLastError appends the last refresh error of a cache, if any, to a check message.
+func withLastError(message, lastError string) string {
+	if len(lastError) == 0 {
+		return message
+	}
+	return message + ": " + lastError
+}
+
+func passCheck(check dto.HealthCheckDTO, message string) dto.HealthCheckDTO {
+	check.Status = HealthPass
+	check.Message = message
+	return check
+}
+
+func failCheck(check dto.HealthCheckDTO, message string) dto.HealthCheckDTO {
+	check.Status = HealthFail
+	check.Message = message
+	return check
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"scanoss.com/licenses/pkg/cache"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+// fakeStatusCache is a cache.Reloadable reporting a fixed status.
+type fakeStatusCache struct {
+	status cache.Status
+}
+
+func (f fakeStatusCache) Name() string                    { return "spdx-licenses" }
+func (f fakeStatusCache) Refresh(_ context.Context) error { return nil }
+func (f fakeStatusCache) Status() cache.Status            { return f.status }
+func (f fakeStatusCache) Entry(_ string) (any, bool)      { return nil, false }
+
+func TestHealthUseCase_Readiness(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	defer db.Close()
+	closedDB, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	_ = closedDB.Close()
+	config := &myconfig.ServerConfig{}
+	config.Health.PingTimeoutSeconds = 1
+	config.Health.MaxCacheAgeHours = 48
+	fresh := fakeStatusCache{cache.Status{Size: 650, LastRefresh: time.Now().Add(-time.Hour)}}
+
+	tests := []struct {
+		name      string
+		db        *sqlx.DB
+		spdxCache cache.Reloadable
+		ready     bool
+		failed    []string
+	}{
+		{name: "ready", db: db, spdxCache: fresh, ready: true},
+		{name: "database down", db: closedDB, spdxCache: fresh, failed: []string{"database"}},
+		{
+			name:      "cache never loaded",
+			db:        db,
+			spdxCache: fakeStatusCache{cache.Status{LastError: "connection refused"}},
+			failed:    []string{"spdx_cache_loaded", "spdx_cache_age"},
+		},
+		{
+			name:      "stale cache",
+			db:        db,
+			spdxCache: fakeStatusCache{cache.Status{Size: 650, LastRefresh: time.Now().Add(-72 * time.Hour), LastError: "timeout"}},
+			failed:    []string{"spdx_cache_age"},
+		},
+		{name: "no cache", db: db, failed: []string{"spdx_cache_loaded", "spdx_cache_age"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks, ready := NewHealthUseCase(config, tt.db, tt.spdxCache).Readiness(ctx)
+			assert.Equal(t, tt.ready, ready)
+			assert.Len(t, checks, 3)
+			var failed []string
+			for _, c := range checks {
+				assert.NotEmpty(t, c.Message)
+				if c.Status == HealthFail {
+					failed = append(failed, c.Name)
+				}
+			}
+			assert.Equal(t, tt.failed, failed)
+		})
+	}
+
+	t.Run("stale cache message carries the last error", func(t *testing.T) {
+		stale := fakeStatusCache{cache.Status{Size: 650, LastRefresh: time.Now().Add(-72 * time.Hour), LastError: "timeout"}}
+		checks, _ := NewHealthUseCase(config, db, stale).Readiness(ctx)
+		assert.Contains(t, checks, dto.HealthCheckDTO{Name: "spdx_cache_age", Status: HealthFail, Message: "last refreshed 72h0m0s ago, more than 48h0m0s: timeout"})
+	})
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-component-helper/componenthelper"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/domain"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/curation"
+	"scanoss.com/licenses/pkg/dto"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+func TestLicenseUseCase_AsOf(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db, err := sqlx.Connect("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, ctx); err != nil {
+		t.Fatalf("Error loading test SQL data %v", err)
+	}
+	// A later scan found an additional license for the component.
+	_, err = db.ExecContext(ctx, "INSERT INTO purl_licenses (purl, version, date, source_id, license_id) VALUES "+
+		"('pkg:gitlab/gpl/project', '1.0.0', '2024-05-01', 31, 5614)")
+	if err != nil {
+		t.Fatalf("Error inserting purl_licenses data %v", err)
+	}
+	config := &myconfig.ServerConfig{}
+	config.Lookup.MaxWorkers = 1
+	config.Lookup.SourcePriority = []int16{31, 32, 33, 5}
+	config.Cache.ResultSize = 100
+	config.Cache.ResultTTLMinutes = 60
+	uc := NewLicenseUseCase(config, db, nil, nil, nil)
+	components := []componenthelper.ComponentDTO{{Purl: "pkg:gitlab/gpl/project", Requirement: "1.0.0"}}
+
+	tests := []struct {
+		name      string
+		asOf      string
+		statement string
+		date      string
+	}{
+		{name: "latest", statement: "GPL-2.0-only AND MIT", date: "2024-05-01"},
+		{name: "before the later scan", asOf: "2023-12-31", statement: "GPL-2.0-only", date: "2023-01-06"},
+		{name: "on the day of the first scan", asOf: "2023-01-06", statement: "GPL-2.0-only", date: "2023-01-06"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, ucErr := uc.GetComponentsLicenseExtended(ctx, dto.ComponentsExtendedRequestDTO{Components: components, AsOf: tt.asOf})
+			assert.Nil(t, ucErr)
+			if !assert.Len(t, results, 1) || !assert.NotNil(t, results[0].Source) {
+				return
+			}
+			assert.ElementsMatch(t, strings.Split(tt.statement, " AND "), strings.Split(results[0].Statement, " AND "))
+			assert.Equal(t, tt.date, results[0].Source.Date)
+		})
+	}
+
+	t.Run("curations are not applied", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "curations.yaml")
+		if err := os.WriteFile(file, []byte("overrides:\n  - purl: pkg:gitlab/gpl/project\n    license: Apache-2.0\n"), 0o600); err != nil {
+			t.Fatalf("Error writing curation file %v", err)
+		}
+		store := curation.NewStore(file, zlog.S)
+		if err := store.Refresh(ctx); err != nil {
+			t.Fatalf("Error loading curations %v", err)
+		}
+		curated := *uc
+		curated.UseCurations(store)
+		results, ucErr := curated.GetComponentsLicenseExtended(ctx, dto.ComponentsExtendedRequestDTO{Components: components, AsOf: "2023-12-31"})
+		assert.Nil(t, ucErr)
+		if assert.Len(t, results, 1) && assert.NotNil(t, results[0].Source) {
+			assert.Equal(t, dto.LicenseSourceKnowledgeBase, results[0].Source.Type)
+			assert.Equal(t, "GPL-2.0-only", results[0].Statement)
+		}
+	})
+
+	t.Run("before any scan", func(t *testing.T) {
+		results, ucErr := uc.GetComponentsLicenseExtended(ctx, dto.ComponentsExtendedRequestDTO{Components: components, AsOf: "2022-12-31"})
+		assert.Nil(t, ucErr)
+		if !assert.Len(t, results, 1) {
+			return
+		}
+		assert.Nil(t, results[0].Source)
+		assert.Equal(t, domain.NoInfo.String(), results[0].GetInfoCode())
+	})
+}
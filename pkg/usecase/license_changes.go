@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/dto"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+// GetLicenseChanges returns the purl/versions whose purl_licenses rows, from the configured source priority, were
+// added or changed since the requested date or cursor, one page at a time. Pages are ordered by (latest date, purl,
+// version). While hasMore is set, nextCursor resumes after the last change; on the last page it restarts at the
+// latest date seen, as the knowledge base may still add rows to that day, so polling it never misses a change
+// (but may report that day's changes again).
+func (lu LicenseUseCase) GetLicenseChanges(ctx context.Context, s *zap.SugaredLogger,
+	request dto.LicenseChangesRequestDTO) (changes []dto.LicenseChangeDTO, nextCursor string, hasMore bool, ucErr *Error) {
+	after, err := decodeLicenseChangeCursor(request.Cursor)
+	if err != nil {
+		return nil, "", false, &Error{Status: common.StatusCode_FAILED, Code: http.StatusBadRequest, Message: err.Error(), Error: err}
+	}
+	// Fetch one extra row to find out whether there is a next page.
+	rows, err := lu.purlLicenseModel.GetLicenseChanges(ctx, request.Since, request.PurlType, request.Purls,
+		lu.config.Lookup.SourcePriority, after, request.Limit+1)
+	if err != nil {
+		return nil, "", false, &Error{Status: common.StatusCode_FAILED, Code: http.StatusInternalServerError, Message: err.Error(), Error: err}
+	}
+	hasMore = len(rows) > request.Limit
+	if hasMore {
+		rows = rows[:request.Limit]
+	}
+	changes = make([]dto.LicenseChangeDTO, 0, len(rows))
+	for _, r := range rows {
+		changes = append(changes, dto.LicenseChangeDTO{Purl: r.Purl, Version: r.Version, Date: r.Date})
+	}
+	next := models.PurlChangeCursor{Date: request.Since}
+	if after != nil {
+		next.Date = after.Date
+	}
+	if len(rows) > 0 {
+		last := rows[len(rows)-1]
+		next = models.PurlChangeCursor{Date: last.Date}
+		if hasMore {
+			next.Purl, next.Version = last.Purl, last.Version
+		}
+	}
+	s.Debugf("Found %d changed components since %v (more: %v)", len(changes), next.Date, hasMore)
+	return changes, encodeLicenseChangeCursor(next), hasMore, nil
+}
+
+// encodeLicenseChangeCursor renders a changes feed position as an opaque cursor.
+func encodeLicenseChangeCursor(cursor models.PurlChangeCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeLicenseChangeCursor parses a cursor produced by encodeLicenseChangeCursor. An empty cursor means the feed
+// starts at the requested date.
+func decodeLicenseChangeCursor(cursor string) (*models.PurlChangeCursor, error) {
+	if len(cursor) == 0 {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+	var result models.PurlChangeCursor
+	if err = json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+	if len(result.Date) == 0 {
+		return nil, errors.New("invalid cursor: missing date")
+	}
+	return &result, nil
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/dto"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+func TestLicenseUseCase_GetLicenseChanges(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, ctx); err != nil {
+		t.Fatalf("Error loading test SQL data %v", err)
+	}
+	uc := NewLicenseUseCase(&myconfig.ServerConfig{}, db, nil, nil, nil)
+
+	t.Run("cursor walks all pages and resumes at the latest date", func(t *testing.T) {
+		request := dto.LicenseChangesRequestDTO{Since: "2023-01-05", Limit: 3}
+		changes, next, hasMore, ucErr := uc.GetLicenseChanges(ctx, s, request)
+		assert.Nil(t, ucErr)
+		assert.True(t, hasMore)
+		assert.Len(t, changes, 3)
+		request = dto.LicenseChangesRequestDTO{Cursor: next, Limit: 3}
+		changes, next, hasMore, ucErr = uc.GetLicenseChanges(ctx, s, request)
+		assert.Nil(t, ucErr)
+		assert.False(t, hasMore)
+		assert.Equal(t, []dto.LicenseChangeDTO{{Purl: "pkg:github/dual/licensed", Version: "1.0.0", Date: "2023-01-07"}}, changes)
+
+		// Nothing new: polling the last cursor reports the latest day again.
+		changes, again, _, ucErr := uc.GetLicenseChanges(ctx, s, dto.LicenseChangesRequestDTO{Cursor: next, Limit: 3})
+		assert.Nil(t, ucErr)
+		assert.Len(t, changes, 1)
+		assert.Equal(t, next, again)
+
+		// A later scan is picked up from the last cursor.
+		_, err := db.ExecContext(ctx, "INSERT INTO purl_licenses (purl, version, date, source_id, license_id) VALUES "+
+			"('pkg:npm/express', '4.18.2', '2023-02-01', 3, 109)")
+		if err != nil {
+			t.Fatalf("Error inserting purl_licenses data %v", err)
+		}
+		changes, _, _, ucErr = uc.GetLicenseChanges(ctx, s, dto.LicenseChangesRequestDTO{Cursor: next, Limit: 3})
+		assert.Nil(t, ucErr)
+		assert.Equal(t, []dto.LicenseChangeDTO{
+			{Purl: "pkg:github/dual/licensed", Version: "1.0.0", Date: "2023-01-07"},
+			{Purl: "pkg:npm/express", Version: "4.18.2", Date: "2023-02-01"},
+		}, changes)
+	})
+
+	t.Run("watched purls and purl type", func(t *testing.T) {
+		changes, next, hasMore, ucErr := uc.GetLicenseChanges(ctx, s, dto.LicenseChangesRequestDTO{Since: "2022-01-01", PurlType: "npm",
+			Purls: []string{"pkg:npm/lodash", "pkg:gem/rails"}, Limit: 10})
+		assert.Nil(t, ucErr)
+		assert.False(t, hasMore)
+		assert.NotEmpty(t, next)
+		assert.Equal(t, []dto.LicenseChangeDTO{{Purl: "pkg:npm/lodash", Version: "4.17.21", Date: "2023-01-02"}}, changes)
+	})
+
+	t.Run("only sources of the priority list", func(t *testing.T) {
+		config := &myconfig.ServerConfig{}
+		config.Lookup.SourcePriority = []int16{31}
+		changes, _, _, ucErr := NewLicenseUseCase(config, db, nil, nil, nil).GetLicenseChanges(ctx, s,
+			dto.LicenseChangesRequestDTO{Since: "2022-01-01", Limit: 10})
+		assert.Nil(t, ucErr)
+		assert.Equal(t, []dto.LicenseChangeDTO{
+			{Purl: "pkg:gitlab/gpl/project", Version: "1.0.0", Date: "2023-01-06"},
+			{Purl: "pkg:github/dual/licensed", Version: "1.0.0", Date: "2023-01-07"},
+		}, changes)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, _, _, ucErr := uc.GetLicenseChanges(ctx, s, dto.LicenseChangesRequestDTO{Cursor: "not-a-cursor", Limit: 10})
+		if assert.NotNil(t, ucErr) {
+			assert.Equal(t, 400, ucErr.Code)
+		}
+	})
+}
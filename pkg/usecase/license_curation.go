@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/scanoss/go-component-helper/componenthelper"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/domain"
+	pb "github.com/scanoss/papi/api/licensesv2"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"scanoss.com/licenses/pkg/curation"
+	"scanoss.com/licenses/pkg/dto"
+	"scanoss.com/licenses/pkg/metrics"
+	"scanoss.com/licenses/pkg/tracing"
+)
+
+// UseCurations makes the given curation overrides take precedence over the knowledge base. A nil store disables them.
+func (lu *LicenseUseCase) UseCurations(store *curation.Store) {
+	lu.curations = store
+}
+
+// curatedResult returns the result of component c when a curation override covers it, or nil otherwise.
+// Overrides apply whatever the outcome of the version resolution, so components the knowledge base doesn't
+// know (e.g. internal forks) get their local licenses too.
+func (lu LicenseUseCase) curatedResult(ctx context.Context, c componenthelper.Component, trace *resolutionTrace) *componentLicenseResult {
+	if lu.curations == nil || c.Status.StatusCode == domain.InvalidPurl {
+		return nil
+	}
+	version := curationVersion(c)
+	override, ok := lu.curations.Lookup(c.Purl, version)
+	metrics.ObserveResolutionStep(stepCuration, ok)
+	if !ok {
+		return nil
+	}
+	result := &componentLicenseResult{
+		info:    &pb.ComponentLicenseInfo{Purl: c.OriginalPurl, Requirement: c.OriginalRequirement, Url: c.URL},
+		purl:    c.Purl,
+		version: version,
+		trace:   trace,
+	}
+	lu.applyCuration(ctx, version, override, result)
+	return result
+}
+
+// curationVersion returns the version of c that overrides are matched against: the resolved version or,
+// when none was resolved, the requirement if it names a single version.
+func curationVersion(c componenthelper.Component) string {
+	if len(c.Version) > 0 {
+		return c.Version
+	}
+	if _, err := semver.NewVersion(c.Requirement); err == nil {
+		return c.Requirement
+	}
+	return ""
+}
+
+// applyCuration fills in the result of a component at version with the licenses set by a curation override.
+func (lu LicenseUseCase) applyCuration(ctx context.Context, version string, override *curation.Override,
+	result *componentLicenseResult) {
+	metrics.ObserveCurationWin()
+	oteltrace.SpanFromContext(ctx).SetAttributes(tracing.Curated.Bool(true))
+	curated := curationDTO(override)
+	result.trace.curationApplied(curated)
+	result.source = &dto.LicenseSourceDTO{Type: dto.LicenseSourceCuration, Curation: curated}
+
+	expression := override.Expression()
+	licenses := expression.Licenses()
+	licenseInfos := make([]*pb.LicenseInfo, 0, len(licenses))
+	for _, l := range licenses {
+		licenseInfos = append(licenseInfos, lu.licenseInfo(l, !strings.HasPrefix(l, "LicenseRef-"), result.trace))
+	}
+	result.info.Version = version
+	result.info.Statement = expression.String()
+	result.info.Licenses = licenseInfos
+	result.expression = expression
+}
+
+// curationDTO converts a curation override for the response.
+func curationDTO(o *curation.Override) *dto.CurationDTO {
+	return &dto.CurationDTO{Purl: o.Purl, Versions: o.Versions, License: o.License, Comment: o.Comment}
+}
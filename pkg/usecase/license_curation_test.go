@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-component-helper/componenthelper"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/domain"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/curation"
+	"scanoss.com/licenses/pkg/dto"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+func TestLicenseUseCase_Curations(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db, err := sqlx.Connect("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, ctx); err != nil {
+		t.Fatalf("Error loading test SQL data %v", err)
+	}
+	file := filepath.Join(t.TempDir(), "curations.yaml")
+	content := "overrides:\n  - purl: pkg:gitlab/gpl/project\n    versions: \"<2.0.0\"\n    license: MIT OR Apache-2.0\n    comment: relicensed\n"
+	if err = os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("Error writing curation file %v", err)
+	}
+	store := curation.NewStore(file, zlog.S)
+	if err = store.Refresh(ctx); err != nil {
+		t.Fatalf("Error loading curations %v", err)
+	}
+	config := &myconfig.ServerConfig{}
+	config.Lookup.MaxWorkers = 1
+	config.Lookup.SourcePriority = []int16{31, 32, 33, 5}
+	config.Cache.ResultSize = 10
+	config.Cache.ResultTTLMinutes = 10
+	uc := NewLicenseUseCase(config, db, nil, nil, nil)
+	request := dto.ComponentsExtendedRequestDTO{Components: []componenthelper.ComponentDTO{
+		{Purl: "pkg:gitlab/gpl/project", Requirement: "1.0.0"},
+	}}
+
+	components, ucErr := uc.GetComponentsLicenseExtended(ctx, request)
+	assert.Nil(t, ucErr)
+	if assert.Len(t, components, 1) && assert.NotNil(t, components[0].Source) {
+		assert.Equal(t, dto.LicenseSourceKnowledgeBase, components[0].Source.Type)
+		assert.Equal(t, int16(31), *components[0].Source.ID)
+		assert.Equal(t, "GPL-2.0-only", components[0].Statement)
+	}
+
+	// The admin views are taken before the curations are set, as the application does.
+	caches := uc.Caches()
+	uc.UseCurations(store)
+	request.Explain = true
+	components, ucErr = uc.GetComponentsLicenseExtended(ctx, request)
+	assert.Nil(t, ucErr)
+	if assert.Len(t, components, 1) && assert.NotNil(t, components[0].Source) {
+		c := components[0]
+		assert.Equal(t, dto.LicenseSourceCuration, c.Source.Type)
+		assert.Nil(t, c.Source.ID)
+		if assert.NotNil(t, c.Source.Curation) {
+			assert.Equal(t, "relicensed", c.Source.Curation.Comment)
+		}
+		assert.Equal(t, "1.0.0", c.Version)
+		assert.Equal(t, "MIT OR Apache-2.0", c.Statement)
+		assert.Len(t, c.Licenses, 2)
+		assert.NotNil(t, c.ElectedLicense, "curated expressions are elected like any other")
+		if assert.NotNil(t, c.Trace) {
+			assert.NotNil(t, c.Trace.Curation)
+			assert.Empty(t, c.Trace.Steps, "the knowledge base is not consulted")
+		}
+	}
+
+	t.Run("components unknown to the knowledge base", func(t *testing.T) {
+		fork := content + "  - purl: pkg:npm/internal-fork\n    versions: \"<2.0.0\"\n    license: Apache-2.0\n    comment: internal\n"
+		if err := os.WriteFile(file, []byte(fork), 0o600); err != nil {
+			t.Fatalf("Error writing curation file %v", err)
+		}
+		assert.NoError(t, store.Refresh(ctx))
+		unknown := dto.ComponentsExtendedRequestDTO{Components: []componenthelper.ComponentDTO{
+			{Purl: "pkg:npm/internal-fork@1.2.0"},
+			{Purl: "pkg:npm/internal-fork", Requirement: "2.1.0"},
+		}}
+		components, ucErr := uc.GetComponentsLicenseExtended(ctx, unknown)
+		assert.Nil(t, ucErr)
+		for _, c := range components {
+			switch c.Requirement {
+			case "":
+				if assert.NotNil(t, c.Source) {
+					assert.Equal(t, dto.LicenseSourceCuration, c.Source.Type)
+				}
+				assert.Equal(t, "1.2.0", c.Version)
+				assert.Equal(t, "Apache-2.0", c.Statement)
+				assert.Empty(t, c.GetInfoCode())
+			case "2.1.0":
+				assert.Nil(t, c.Source, "the override doesn't cover this version")
+				assert.Equal(t, domain.ComponentNotFound.String(), c.GetInfoCode())
+			}
+		}
+		assert.Len(t, components, 2)
+	})
+
+	t.Run("reloading the curations skips cached results", func(t *testing.T) {
+		request.Explain = false
+		cached, _ := uc.GetComponentsLicenseExtended(ctx, request)
+		assert.Equal(t, dto.LicenseSourceCuration, cached[0].Source.Type)
+		if err := os.WriteFile(file, []byte("overrides: []\n"), 0o600); err != nil {
+			t.Fatalf("Error writing curation file %v", err)
+		}
+		assert.NoError(t, store.Refresh(ctx))
+		components, _ := uc.GetComponentsLicenseExtended(ctx, request)
+		assert.Equal(t, dto.LicenseSourceKnowledgeBase, components[0].Source.Type)
+	})
+
+	t.Run("admin view", func(t *testing.T) {
+		if assert.Len(t, caches, 1) {
+			assert.NoError(t, caches[0].Refresh(ctx))
+			_, _ = uc.GetComponentsLicenseExtended(ctx, request)
+			_, ok := caches[0].Entry("pkg:gitlab/gpl/project 1.0.0")
+			assert.True(t, ok, "entries are keyed with the current curations")
+		}
+	})
+}
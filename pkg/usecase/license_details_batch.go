@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/dto"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+// GetDetailsBatch retrieves detailed license information for several license IDs at once.
+// IDs found in the details cache are served from it; the licenses and osadl rows of the
+// remaining IDs are fetched with one set-based query each. IDs without a licenses row are
+// returned in notFound, in request order.
+func (lu LicenseUseCase) GetDetailsBatch(ctx context.Context, s *zap.SugaredLogger,
+	request dto.LicenseBatchRequestDTO) (licenses []dto.LicenseDetailsResultDTO, notFound []string, ucErr *Error) {
+	recordsByID := make(map[string]models.LicenseDetail, len(request.IDs))
+	osadlByID := make(map[string]models.OSADL, len(request.IDs))
+	var missing []string
+	for _, id := range request.IDs {
+		if lu.detailsCache == nil {
+			missing = append(missing, id)
+			continue
+		}
+		record, ok := lu.detailsCache.GetLicenseByID(id)
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		recordsByID[strings.ToUpper(id)] = record
+		if osadl, found := lu.detailsCache.GetOSADLByLicenseID(record.LicenseID); found {
+			osadlByID[strings.ToUpper(record.LicenseID)] = osadl
+		}
+	}
+	if len(missing) > 0 {
+		if err := lu.loadDetailsFromDB(ctx, s, missing, recordsByID, osadlByID); err != nil {
+			return nil, nil, &Error{Status: common.StatusCode_FAILED, Code: http.StatusInternalServerError, Message: err.Error(), Error: err}
+		}
+	}
+	licenses = make([]dto.LicenseDetailsResultDTO, 0, len(request.IDs))
+	notFound = []string{}
+	for _, id := range request.IDs {
+		record, ok := recordsByID[strings.ToUpper(id)]
+		if !ok {
+			notFound = append(notFound, id)
+			continue
+		}
+		details := newLicenseDetails(record, osadlByID[strings.ToUpper(record.LicenseID)])
+		licenses = append(licenses, dto.LicenseDetailsResultDTO{ID: id, LicenseDetails: &details})
+	}
+	s.Debugf("License details batch: %d found, %d not found (%d looked up in the database)", len(licenses), len(notFound), len(missing))
+	return licenses, notFound, nil
+}
+
+// loadDetailsFromDB queries the licenses and osadl rows of the given IDs, adding them to the supplied maps.
+// A failure to read the osadl table is only logged, matching GetDetails.
+func (lu LicenseUseCase) loadDetailsFromDB(ctx context.Context, s *zap.SugaredLogger, ids []string,
+	recordsByID map[string]models.LicenseDetail, osadlByID map[string]models.OSADL) error {
+	licenseRecords, err := lu.licenseDetailModel.GetLicensesByIDs(ctx, s, ids)
+	if err != nil {
+		return err
+	}
+	foundIDs := make([]string, 0, len(licenseRecords))
+	for _, r := range licenseRecords {
+		recordsByID[strings.ToUpper(r.LicenseID)] = r
+		foundIDs = append(foundIDs, r.LicenseID)
+	}
+	if len(foundIDs) == 0 {
+		return nil
+	}
+	osadls, err := lu.osadlModel.GetOSADLByLicenseIDs(ctx, s, foundIDs)
+	if err != nil {
+		s.Errorf("Error getting OSADL for licenses: %v, err: %v", foundIDs, err)
+	}
+	for _, o := range osadls {
+		osadlByID[strings.ToUpper(o.LicenseID)] = o
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/dto"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+func TestLicenseUseCase_GetDetailsBatch(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	config := &myconfig.ServerConfig{}
+
+	tests := []struct {
+		name             string
+		licModel         func() *MockLicenseModel
+		osadlModel       func() *MockOSADLModel
+		request          dto.LicenseBatchRequestDTO
+		expectedIDs      []string
+		expectedNotFound []string
+		expectErr        bool
+	}{
+		{
+			name: "all licenses found, request order preserved",
+			licModel: func() *MockLicenseModel {
+				m := new(MockLicenseModel)
+				m.On("GetLicensesByIDs", []string{"mit", "Apache-2.0"}).Return([]models.LicenseDetail{
+					{Name: "Apache License 2.0", LicenseID: "Apache-2.0"},
+					{Name: "MIT License", LicenseID: "MIT"},
+				}, nil)
+				return m
+			},
+			osadlModel: func() *MockOSADLModel {
+				m := new(MockOSADLModel)
+				m.On("GetOSADLByLicenseIDs", []string{"Apache-2.0", "MIT"}).Return([]models.OSADL{
+					{LicenseID: "MIT", Compatibilities: models.JSONStringSlice{"Apache-2.0"}},
+				}, nil)
+				return m
+			},
+			request:          dto.LicenseBatchRequestDTO{IDs: []string{"mit", "Apache-2.0"}},
+			expectedIDs:      []string{"mit", "Apache-2.0"},
+			expectedNotFound: []string{},
+		},
+		{
+			name: "some licenses not found and OSADL error",
+			licModel: func() *MockLicenseModel {
+				m := new(MockLicenseModel)
+				m.On("GetLicensesByIDs", []string{"MIT", "Unknown-1.0"}).Return([]models.LicenseDetail{
+					{Name: "MIT License", LicenseID: "MIT"},
+				}, nil)
+				return m
+			},
+			osadlModel: func() *MockOSADLModel {
+				m := new(MockOSADLModel)
+				m.On("GetOSADLByLicenseIDs", []string{"MIT"}).Return([]models.OSADL{}, errors.New("osadl failure"))
+				return m
+			},
+			request:          dto.LicenseBatchRequestDTO{IDs: []string{"MIT", "Unknown-1.0"}},
+			expectedIDs:      []string{"MIT"},
+			expectedNotFound: []string{"Unknown-1.0"},
+		},
+		{
+			name: "licenses query error",
+			licModel: func() *MockLicenseModel {
+				m := new(MockLicenseModel)
+				m.On("GetLicensesByIDs", []string{"MIT"}).Return([]models.LicenseDetail{}, errors.New("db failure"))
+				return m
+			},
+			osadlModel: func() *MockOSADLModel { return new(MockOSADLModel) },
+			request:    dto.LicenseBatchRequestDTO{IDs: []string{"MIT"}},
+			expectErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewLicenseUseCaseWithLicenseModel(config, tt.licModel(), tt.osadlModel())
+			licenses, notFound, ucErr := usecase.GetDetailsBatch(ctx, s, tt.request)
+			if tt.expectErr {
+				if ucErr == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if ucErr != nil {
+				t.Fatalf("unexpected error: %v", ucErr)
+			}
+			var ids []string
+			for _, l := range licenses {
+				if l.Spdx == nil || l.Osadl == nil {
+					t.Errorf("expected SPDX and OSADL details for %s", l.ID)
+				}
+				ids = append(ids, l.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.expectedIDs) {
+				t.Errorf("expected licenses %v, got %v", tt.expectedIDs, ids)
+			}
+			if !reflect.DeepEqual(notFound, tt.expectedNotFound) {
+				t.Errorf("expected not found %v, got %v", tt.expectedNotFound, notFound)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/dto"
+	"scanoss.com/licenses/pkg/license"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+// textMatcherIndex lazily builds the license text matcher from the license text store and
+// rebuilds it once it is older than ttl. A failed build is retried on the next request.
+type textMatcherIndex struct {
+	mu      sync.Mutex
+	matcher *license.TextMatcher
+	builtAt time.Time
+	ttl     time.Duration
+}
+
+func newTextMatcherIndex(ttl time.Duration) *textMatcherIndex {
+	return &textMatcherIndex{ttl: ttl}
+}
+
+// reset drops the current matcher, so the next request builds it again from the license text store.
+func (i *textMatcherIndex) reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.matcher = nil
+}
+
+// get returns the current matcher, building it first if needed.
+func (i *textMatcherIndex) get(ctx context.Context, s *zap.SugaredLogger, store models.LicenseTextModelInterface) (*license.TextMatcher, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.matcher != nil && (i.ttl <= 0 || time.Since(i.builtAt) < i.ttl) {
+		return i.matcher, nil
+	}
+	texts, err := store.GetAllLicenseTexts(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	references := make([]license.ReferenceText, 0, len(texts))
+	for _, t := range texts {
+		references = append(references, license.ReferenceText{ID: t.LicenseID, Name: t.Name, Text: t.Text, Template: t.StandardTemplate})
+	}
+	i.matcher = license.NewTextMatcher(references)
+	i.builtAt = time.Now()
+	s.Infof("License text matcher built: %d license texts", i.matcher.Size())
+	return i.matcher, nil
+}
+
+// IdentifyLicense ranks the licenses of the license text store by their similarity to the given text.
+// Only licenses known to the SPDX license cache are reported (no filtering happens without a cache).
+func (lu LicenseUseCase) IdentifyLicense(ctx context.Context, s *zap.SugaredLogger,
+	request dto.LicenseIdentifyRequestDTO) ([]dto.LicenseCandidateDTO, *Error) {
+	if lu.licenseTextModel == nil {
+		return nil, licenseTextsNotConfigured()
+	}
+	matcher, err := lu.textMatcher.get(ctx, s, lu.licenseTextModel)
+	if err != nil {
+		return nil, &Error{Status: common.StatusCode_FAILED, Code: http.StatusInternalServerError, Message: err.Error(), Error: err}
+	}
+	var include func(id string) bool
+	if lu.spdxLicenseCache != nil {
+		include = func(id string) bool {
+			_, ok := lu.spdxLicenseCache.GetLicenseByID(id)
+			return ok
+		}
+	}
+	matches := matcher.Match(request.Text, request.MinScore, include)
+	if len(matches) > request.Limit {
+		matches = matches[:request.Limit]
+	}
+	candidates := make([]dto.LicenseCandidateDTO, 0, len(matches))
+	for _, m := range matches {
+		candidates = append(candidates, dto.LicenseCandidateDTO{ID: m.ID, Name: m.Name, Score: math.Round(m.Score*10000) / 10000})
+	}
+	s.Debugf("License identification: %d candidates", len(candidates))
+	return candidates, nil
+}
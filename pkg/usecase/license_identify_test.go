@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	gomodels "github.com/scanoss/go-models/pkg/models"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/dto"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+// fakeSPDXCache serves a fixed set of SPDX license details.
+type fakeSPDXCache struct {
+	licenses []gomodels.SPDXLicenseDetail
+}
+
+// newFakeSPDXCache returns a fakeSPDXCache knowing the given license IDs.
+func newFakeSPDXCache(ids ...string) *fakeSPDXCache {
+	f := &fakeSPDXCache{}
+	for _, id := range ids {
+		f.licenses = append(f.licenses, gomodels.SPDXLicenseDetail{ID: id})
+	}
+	return f
+}
+
+func (f *fakeSPDXCache) GetLicenseByID(spdxID string) (*gomodels.SPDXLicenseDetail, bool) {
+	for i := range f.licenses {
+		if strings.EqualFold(f.licenses[i].ID, spdxID) {
+			return &f.licenses[i], true
+		}
+	}
+	return nil, false
+}
+
+func (f *fakeSPDXCache) GetAllLicenses() []*gomodels.SPDXLicenseDetail {
+	result := make([]*gomodels.SPDXLicenseDetail, 0, len(f.licenses))
+	for i := range f.licenses {
+		result = append(result, &f.licenses[i])
+	}
+	return result
+}
+
+func (f *fakeSPDXCache) Start(ctx context.Context) error { return nil }
+
+func (f *fakeSPDXCache) Stop() {}
+
+func TestLicenseUseCase_IdentifyLicense(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+
+	uc := NewLicenseUseCaseWithLicenseModel(&myconfig.ServerConfig{}, nil, nil)
+	uc.licenseTextModel = models.NewLicenseTextDirModel("../model/tests/license-list-data")
+
+	apacheText := "APACHE LICENSE\n   Version 2.0,   January 2004\n https://www.apache.org/licenses/"
+	tests := []struct {
+		name     string
+		spdx     *fakeSPDXCache
+		text     string
+		expected []string
+	}{
+		{name: "SPDX license is identified", spdx: newFakeSPDXCache("Apache-2.0"), text: apacheText, expected: []string{"Apache-2.0"}},
+		{name: "licenses unknown to the SPDX cache are excluded", spdx: newFakeSPDXCache("MIT"), text: apacheText},
+		{name: "LicenseRef texts are excluded", spdx: newFakeSPDXCache("Apache-2.0"), text: "Example proprietary license text."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc.spdxLicenseCache = tt.spdx
+			candidates, ucErr := uc.IdentifyLicense(ctx, s, dto.LicenseIdentifyRequestDTO{Text: tt.text, Limit: 5, MinScore: 0.5})
+			if ucErr != nil {
+				t.Fatalf("unexpected error: %v", ucErr)
+			}
+			var ids []string
+			for _, c := range candidates {
+				ids = append(ids, c.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected candidates %v, got %+v", tt.expected, candidates)
+			}
+		})
+	}
+}
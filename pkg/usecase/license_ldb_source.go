@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/license"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+// ldbSourceEnabled reports whether the ldb_component_licenses source is part of the configured source priority.
+func (lu LicenseUseCase) ldbSourceEnabled() bool {
+	return lu.ldbModel != nil && slices.Contains(lu.config.Lookup.SourcePriority, license.SourceLDBComponentLicenses)
+}
+
+// fetchLDBLicenses returns the ldb_component_licenses rows of purl at each of the given versions, as rows of the
+// SourceLDBComponentLicenses source, so they compete with the purl_licenses sources by priority.
+// It returns nil when the source is not enabled, and for as_of lookups: ldb_component_licenses rows are undated,
+// so they cannot be shown to predate asOf.
+func (lu LicenseUseCase) fetchLDBLicenses(ctx context.Context, s *zap.SugaredLogger, purl string, versions []string,
+	asOf string) ([]models.PurlLicense, error) {
+	if !lu.ldbSourceEnabled() || len(versions) == 0 || len(asOf) > 0 {
+		return nil, nil
+	}
+	versionByMD5 := make(map[string]string, len(versions))
+	purlMD5s := make([]string, 0, len(versions))
+	for _, v := range versions {
+		purlMD5 := lu.ldbModel.CalculateMD5FromPurlVersion(purl, v)
+		versionByMD5[purlMD5] = v
+		purlMD5s = append(purlMD5s, purlMD5)
+	}
+	rows, err := lu.ldbModel.GetLicensesByPurlMD5s(ctx, purlMD5s)
+	if err != nil {
+		s.Warnf("error when querying GetLicensesByPurlMD5s() for purl=%s: %v", purl, err)
+		return nil, err
+	}
+	purlLicenses := make([]models.PurlLicense, 0, len(rows))
+	for _, r := range rows {
+		purlLicenses = append(purlLicenses, models.PurlLicense{
+			Purl:      purl,
+			Version:   versionByMD5[r.PurlMD5],
+			SourceID:  license.SourceLDBComponentLicenses,
+			LicenseID: r.LicenseID,
+		})
+	}
+	return purlLicenses, nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-component-helper/componenthelper"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/dto"
+	"scanoss.com/licenses/pkg/license"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+func TestLicenseUseCase_LDBSource(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db, err := sqlx.Connect("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, ctx); err != nil {
+		t.Fatalf("Error loading test SQL data %v", err)
+	}
+	ldbModel := models.NewLDBComponentLicensesModel(db)
+	_, err = db.ExecContext(ctx, "DROP TABLE IF EXISTS ldb_component_licenses;"+
+		"CREATE TABLE ldb_component_licenses (purl_md5 TEXT NOT NULL, source TEXT, license_id INTEGER NOT NULL);"+
+		"INSERT INTO ldb_component_licenses VALUES ($1, 'gitlab.com/gpl/project', 5614)",
+		ldbModel.CalculateMD5FromPurlVersion("pkg:gitlab/gpl/project", "1.0.0"))
+	if err != nil {
+		t.Fatalf("Error loading ldb_component_licenses data %v", err)
+	}
+	request := dto.ComponentsExtendedRequestDTO{Components: []componenthelper.ComponentDTO{
+		{Purl: "pkg:gitlab/gpl/project", Requirement: "1.0.0"},
+	}, Explain: true}
+
+	tests := []struct {
+		name     string
+		priority []int16
+		source   int16
+		license  string
+	}{
+		{name: "disabled", priority: []int16{31, 32, 33, 5}, source: 31, license: "GPL-2.0-only"},
+		{name: "highest priority", priority: []int16{license.SourceLDBComponentLicenses, 31}, source: license.SourceLDBComponentLicenses, license: "MIT"},
+		{name: "lower priority", priority: []int16{31, license.SourceLDBComponentLicenses}, source: 31, license: "GPL-2.0-only"},
+		{name: "fills the gaps of other sources", priority: []int16{5, license.SourceLDBComponentLicenses}, source: license.SourceLDBComponentLicenses, license: "MIT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &myconfig.ServerConfig{}
+			config.Lookup.MaxWorkers = 1
+			config.Lookup.SourcePriority = tt.priority
+			uc := NewLicenseUseCase(config, db, nil, nil, nil)
+			components, ucErr := uc.GetComponentsLicenseExtended(ctx, request)
+			assert.Nil(t, ucErr)
+			if !assert.Len(t, components, 1) || !assert.NotNil(t, components[0].Source) {
+				return
+			}
+			c := components[0]
+			assert.Equal(t, tt.source, *c.Source.ID)
+			assert.Equal(t, license.SourceName(tt.source), c.Source.Name)
+			assert.Equal(t, tt.license, c.Statement)
+			assert.Equal(t, "1.0.0", c.Version)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/dto"
+	"scanoss.com/licenses/pkg/policy"
+)
+
+// UsePolicy evaluates the given license policy for each component of the extended lookups. A nil engine disables it.
+func (lu *LicenseUseCase) UsePolicy(engine *policy.Engine) {
+	lu.policy = engine
+}
+
+// evaluatePolicy decides on a resolved component with the license policy, or returns nil when there is none.
+func (lu LicenseUseCase) evaluatePolicy(r *componentLicenseResult, facts policy.FactsLookup) *dto.PolicyDecisionDTO {
+	component := policy.Component{Purl: r.purl, Version: r.version, Expression: r.expression}
+	result, ok := lu.policy.Evaluate(component, facts)
+	if !ok {
+		return nil
+	}
+	decision := &dto.PolicyDecisionDTO{
+		Decision:  result.Decision,
+		Rule:      result.Rule,
+		License:   result.License,
+		Exemption: exemptionDTO(result.Exemption),
+		Licenses:  make([]dto.PolicyLicenseDecisionDTO, 0, len(result.Licenses)),
+		Warnings:  result.Warnings,
+	}
+	for _, l := range result.Licenses {
+		decision.Licenses = append(decision.Licenses, dto.PolicyLicenseDecisionDTO{
+			License: l.License, Decision: l.Decision, Rule: l.Rule, Exemption: exemptionDTO(l.Exemption),
+		})
+	}
+	return decision
+}
+
+// exemptionDTO converts a policy exemption for the response, or returns nil when there is none.
+func exemptionDTO(e *policy.Exemption) *dto.PolicyExemptionDTO {
+	if e == nil {
+		return nil
+	}
+	return &dto.PolicyExemptionDTO{
+		Purl:          e.Purl,
+		Versions:      e.Versions,
+		Justification: e.Justification,
+		Approver:      e.Approver,
+		Expires:       e.Expires,
+	}
+}
+
+// policyFacts returns the OSADL flags of licenses for policy rules, read from the license details cache,
+// or from the osadl table when there is no cache. Database lookups are remembered for the life of the function.
+func (lu LicenseUseCase) policyFacts(ctx context.Context, s *zap.SugaredLogger) policy.FactsLookup {
+	if lu.detailsCache != nil {
+		return func(licenseID string) (policy.LicenseFacts, bool) {
+			osadl, ok := lu.detailsCache.GetOSADLByLicenseID(licenseID)
+			return policy.LicenseFacts{Copyleft: osadl.CopyleftClause, PatentHints: osadl.PatentHints}, ok
+		}
+	}
+	type lookup struct {
+		facts policy.LicenseFacts
+		found bool
+	}
+	seen := make(map[string]lookup)
+	return func(licenseID string) (policy.LicenseFacts, bool) {
+		if l, ok := seen[licenseID]; ok {
+			return l.facts, l.found
+		}
+		var l lookup
+		osadl, err := lu.osadlModel.GetOSADLByLicenseID(ctx, s, licenseID)
+		if err == nil && len(osadl.LicenseID) > 0 {
+			l = lookup{facts: policy.LicenseFacts{Copyleft: osadl.CopyleftClause, PatentHints: osadl.PatentHints}, found: true}
+		}
+		seen[licenseID] = l
+		return l.facts, l.found
+	}
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-component-helper/componenthelper"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/dto"
+	models "scanoss.com/licenses/pkg/model"
+	"scanoss.com/licenses/pkg/policy"
+)
+
+func TestLicenseUseCase_Policy(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db, err := sqlx.Connect("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, ctx); err != nil {
+		t.Fatalf("Error loading test SQL data %v", err)
+	}
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	content := "no_license: deny\nrules:\n  - name: no-gpl\n    decision: deny\n    match:\n      licenses: [GPL-2.0-only]\n"
+	if err = os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("Error writing policy file %v", err)
+	}
+	engine := policy.NewEngine(file, 0, zlog.S)
+	if err = engine.Start(ctx); err != nil {
+		t.Fatalf("Error loading policy %v", err)
+	}
+	config := &myconfig.ServerConfig{}
+	config.Lookup.MaxWorkers = 1
+	config.Lookup.SourcePriority = []int16{31, 32, 33, 5}
+	uc := NewLicenseUseCase(config, db, nil, nil, nil)
+	request := dto.ComponentsExtendedRequestDTO{Components: []componenthelper.ComponentDTO{
+		{Purl: "pkg:gitlab/gpl/project", Requirement: "1.0.0"},
+		{Purl: "pkg:npm/does-not-exist", Requirement: "1.0.0"},
+	}}
+
+	components, ucErr := uc.GetComponentsLicenseExtended(ctx, request)
+	assert.Nil(t, ucErr)
+	for _, c := range components {
+		assert.Nil(t, c.Policy, "no policy is configured")
+	}
+
+	uc.UsePolicy(engine)
+	components, ucErr = uc.GetComponentsLicenseExtended(ctx, request)
+	assert.Nil(t, ucErr)
+	for _, c := range components {
+		if !assert.NotNil(t, c.Policy) {
+			continue
+		}
+		switch c.Purl {
+		case "pkg:gitlab/gpl/project":
+			assert.Equal(t, policy.Deny, c.Policy.Decision)
+			assert.Equal(t, "no-gpl", c.Policy.Rule)
+			assert.Equal(t, "GPL-2.0-only", c.Policy.License)
+			assert.Len(t, c.Policy.Licenses, 1)
+		case "pkg:npm/does-not-exist":
+			assert.Equal(t, policy.Deny, c.Policy.Decision)
+			assert.Empty(t, c.Policy.Rule, "components without licenses get the no_license decision")
+		}
+	}
+
+	t.Run("OSADL flags from the osadl table", func(t *testing.T) {
+		osadlModel := new(MockOSADLModel)
+		osadlModel.On("GetOSADLByLicenseID", "AGPL-3.0-only").Return(models.OSADL{LicenseID: "AGPL-3.0-only", CopyleftClause: true}, nil)
+		osadlModel.On("GetOSADLByLicenseID", "LicenseRef-unknown").Return(models.OSADL{}, nil)
+		withModel := *uc
+		withModel.osadlModel = osadlModel
+		facts := withModel.policyFacts(ctx, zlog.S)
+		for i := 0; i < 2; i++ {
+			agpl, ok := facts("AGPL-3.0-only")
+			assert.True(t, ok)
+			assert.True(t, agpl.Copyleft)
+			_, ok = facts("LicenseRef-unknown")
+			assert.False(t, ok)
+		}
+		osadlModel.AssertNumberOfCalls(t, "GetOSADLByLicenseID", 2)
+	})
+
+	t.Run("versioned request purl", func(t *testing.T) {
+		rules := "rules:\n  - name: no-gitlab\n    decision: deny\n    match:\n      purls: [pkg:gitlab/gpl/project]\n"
+		if err := os.WriteFile(file, []byte(rules), 0o600); err != nil {
+			t.Fatalf("Error writing policy file %v", err)
+		}
+		if err := engine.Refresh(ctx); err != nil {
+			t.Fatalf("Error loading policy %v", err)
+		}
+		versioned := dto.ComponentsExtendedRequestDTO{Components: []componenthelper.ComponentDTO{{Purl: "pkg:gitlab/gpl/project@1.0.0"}}}
+		components, ucErr := uc.GetComponentsLicenseExtended(ctx, versioned)
+		assert.Nil(t, ucErr)
+		if assert.Len(t, components, 1) && assert.NotNil(t, components[0].Policy) {
+			assert.Equal(t, "pkg:gitlab/gpl/project@1.0.0", components[0].Purl, "the purl is reported as requested")
+			assert.Equal(t, policy.Deny, components[0].Policy.Decision)
+			assert.Equal(t, "no-gitlab", components[0].Policy.Rule, "purl patterns match without the version")
+		}
+	})
+
+	t.Run("exemptions", func(t *testing.T) {
+		exemptions := content + "exemptions:\n" +
+			"  - purl: pkg:gitlab/gpl/project\n    versions: \">=1.0.0\"\n    justification: j\n    approver: legal\n    expires: 2999-12-31\n" +
+			"  - purl: pkg:npm/does-not-exist\n    justification: j\n    approver: legal\n    expires: 2020-01-01\n"
+		if err := os.WriteFile(file, []byte(exemptions), 0o600); err != nil {
+			t.Fatalf("Error writing policy file %v", err)
+		}
+		if err := engine.Refresh(ctx); err != nil {
+			t.Fatalf("Error loading policy %v", err)
+		}
+		withVersioned := dto.ComponentsExtendedRequestDTO{Components: append(slices.Clone(request.Components),
+			componenthelper.ComponentDTO{Purl: "pkg:gitlab/gpl/project@1.0.0"})}
+		components, ucErr := uc.GetComponentsLicenseExtended(ctx, withVersioned)
+		assert.Nil(t, ucErr)
+		assert.Len(t, components, 3)
+		for _, c := range components {
+			if !assert.NotNil(t, c.Policy) {
+				continue
+			}
+			switch c.Purl {
+			case "pkg:gitlab/gpl/project", "pkg:gitlab/gpl/project@1.0.0":
+				assert.Equal(t, policy.Allow, c.Policy.Decision)
+				assert.Equal(t, "no-gpl", c.Policy.Rule)
+				if assert.NotNil(t, c.Policy.Exemption) {
+					assert.Equal(t, "legal", c.Policy.Exemption.Approver)
+				}
+				assert.Empty(t, c.Policy.Warnings)
+			case "pkg:npm/does-not-exist":
+				assert.Equal(t, policy.Deny, c.Policy.Decision)
+				assert.Nil(t, c.Policy.Exemption)
+				assert.Len(t, c.Policy.Warnings, 1, "expired exemptions are reported")
+			}
+		}
+	})
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/dto"
+	"scanoss.com/licenses/pkg/license"
+)
+
+// searchMinScore is the lowest fuzzy score (see license.FuzzyScore) accepted as a search match.
+const searchMinScore = 0.45
+
+// SearchLicenses lists the license catalogue (the SPDX license cache merged with the licenses table held
+// by the details cache), applying the request filters and fuzzy query, and returns the requested page
+// along with the total number of matching licenses. Matches are ranked by score, then by ID.
+func (lu LicenseUseCase) SearchLicenses(_ context.Context, s *zap.SugaredLogger,
+	request dto.LicenseSearchRequestDTO) (licenses []dto.LicenseCatalogueEntryDTO, total int) {
+	matches := make([]dto.LicenseCatalogueEntryDTO, 0)
+	for _, entry := range lu.licenseCatalogue() {
+		if !matchesCatalogueFilters(entry, request) {
+			continue
+		}
+		if len(request.Query) > 0 {
+			score := max(license.FuzzyScore(request.Query, entry.ID), license.FuzzyScore(request.Query, entry.Name))
+			if score < searchMinScore {
+				continue
+			}
+			rounded := math.Round(score*1000) / 1000
+			entry.Score = &rounded
+		}
+		matches = append(matches, entry)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != nil && *matches[i].Score != *matches[j].Score {
+			return *matches[i].Score > *matches[j].Score
+		}
+		return strings.ToLower(matches[i].ID) < strings.ToLower(matches[j].ID)
+	})
+	total = len(matches)
+	start := min((request.Page-1)*request.PageSize, total)
+	end := min(start+request.PageSize, total)
+	s.Debugf("License search %q: %d matches, returning %d-%d", request.Query, total, start, end)
+	return matches[start:end], total
+}
+
+// licenseCatalogue merges the SPDX license cache with the licenses table. SPDX data wins for the
+// fields both provide; licenses only found in the licenses table are reported as non-SPDX.
+func (lu LicenseUseCase) licenseCatalogue() []dto.LicenseCatalogueEntryDTO {
+	entries := make(map[string]*dto.LicenseCatalogueEntryDTO)
+	var order []string
+	if lu.spdxLicenseCache != nil {
+		for _, detail := range lu.spdxLicenseCache.GetAllLicenses() {
+			key := strings.ToUpper(detail.ID)
+			entries[key] = &dto.LicenseCatalogueEntryDTO{
+				ID:            detail.ID,
+				Name:          detail.Name,
+				IsSPDX:        true,
+				IsOSIApproved: detail.IsOsiApproved != nil && *detail.IsOsiApproved,
+				IsDeprecated:  detail.IsDeprecatedLicenseId != nil && *detail.IsDeprecatedLicenseId,
+			}
+			order = append(order, key)
+		}
+	}
+	if lu.detailsCache != nil {
+		for _, row := range lu.detailsCache.GetAllLicenses() {
+			key := strings.ToUpper(row.LicenseID)
+			entry, ok := entries[key]
+			if !ok {
+				entry = &dto.LicenseCatalogueEntryDTO{
+					ID:            row.LicenseID,
+					Name:          row.Name,
+					IsOSIApproved: row.IsOsiApproved,
+					IsDeprecated:  row.IsDeprecatedLicenseID,
+				}
+				entries[key] = entry
+				order = append(order, key)
+			}
+			entry.IsFSFLibre = row.IsFsfLibre
+		}
+		for _, entry := range entries {
+			if osadl, ok := lu.detailsCache.GetOSADLByLicenseID(entry.ID); ok {
+				copyleft := osadl.CopyleftClause
+				entry.Copyleft = &copyleft
+			}
+		}
+	}
+	result := make([]dto.LicenseCatalogueEntryDTO, 0, len(order))
+	for _, key := range order {
+		result = append(result, *entries[key])
+	}
+	return result
+}
+
+// matchesCatalogueFilters reports whether entry passes all the filters set in request.
+// A copyleft filter only matches licenses with OSADL data.
+func matchesCatalogueFilters(entry dto.LicenseCatalogueEntryDTO, request dto.LicenseSearchRequestDTO) bool {
+	checks := []struct {
+		filter *bool
+		value  bool
+	}{
+		{request.OSIApproved, entry.IsOSIApproved},
+		{request.FSFLibre, entry.IsFSFLibre},
+		{request.Deprecated, entry.IsDeprecated},
+		{request.SPDX, entry.IsSPDX},
+	}
+	for _, c := range checks {
+		if c.filter != nil && *c.filter != c.value {
+			return false
+		}
+	}
+	if request.Copyleft != nil && (entry.Copyleft == nil || *entry.Copyleft != *request.Copyleft) {
+		return false
+	}
+	return true
+}
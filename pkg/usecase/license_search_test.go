@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	gomodels "github.com/scanoss/go-models/pkg/models"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/dto"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+func TestLicenseUseCase_SearchLicenses(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+
+	yes, no := true, false
+	spdxCache := &fakeSPDXCache{licenses: []gomodels.SPDXLicenseDetail{
+		{ID: "MIT", Name: "MIT License", IsOsiApproved: &yes, IsDeprecatedLicenseId: &no},
+		{ID: "GPL-2.0-only", Name: "GNU General Public License v2.0 only", IsOsiApproved: &yes, IsDeprecatedLicenseId: &no},
+		{ID: "GPL-2.0", Name: "GNU General Public License v2.0 only", IsOsiApproved: &yes, IsDeprecatedLicenseId: &yes},
+		{ID: "Apache-2.0", Name: "Apache License 2.0", IsOsiApproved: &yes, IsDeprecatedLicenseId: &no},
+	}}
+	detailsCache := new(MockDetailsCache)
+	detailsCache.On("GetAllLicenses").Return([]models.LicenseDetail{
+		{LicenseID: "MIT", Name: "MIT License", IsFsfLibre: true},
+		{LicenseID: "GPL-2.0-only", IsFsfLibre: true},
+		{LicenseID: "LicenseRef-scancode-proprietary", Name: "Proprietary License"},
+	})
+	detailsCache.On("GetOSADLByLicenseID", "MIT").Return(models.OSADL{CopyleftClause: false}, true)
+	detailsCache.On("GetOSADLByLicenseID", "GPL-2.0-only").Return(models.OSADL{CopyleftClause: true}, true)
+	detailsCache.On("GetOSADLByLicenseID", "GPL-2.0").Return(models.OSADL{}, false)
+	detailsCache.On("GetOSADLByLicenseID", "Apache-2.0").Return(models.OSADL{CopyleftClause: false}, true)
+	detailsCache.On("GetOSADLByLicenseID", "LicenseRef-scancode-proprietary").Return(models.OSADL{}, false)
+
+	uc := NewLicenseUseCaseWithLicenseModel(&myconfig.ServerConfig{}, nil, nil)
+	uc.spdxLicenseCache = spdxCache
+	uc.detailsCache = detailsCache
+
+	tests := []struct {
+		name          string
+		request       dto.LicenseSearchRequestDTO
+		expected      []string
+		expectedTotal int
+	}{
+		{name: "full listing is sorted by ID", request: dto.LicenseSearchRequestDTO{},
+			expected: []string{"Apache-2.0", "GPL-2.0", "GPL-2.0-only", "LicenseRef-scancode-proprietary", "MIT"}, expectedTotal: 5},
+		{name: "pagination", request: dto.LicenseSearchRequestDTO{Page: 2, PageSize: 2},
+			expected: []string{"GPL-2.0-only", "LicenseRef-scancode-proprietary"}, expectedTotal: 5},
+		{name: "page past the end", request: dto.LicenseSearchRequestDTO{Page: 4, PageSize: 2}, expectedTotal: 5},
+		{name: "fuzzy query ranks exact matches first", request: dto.LicenseSearchRequestDTO{Query: "gpl-2.0"},
+			expected: []string{"GPL-2.0", "GPL-2.0-only"}, expectedTotal: 2},
+		{name: "query matches names", request: dto.LicenseSearchRequestDTO{Query: "proprietary"},
+			expected: []string{"LicenseRef-scancode-proprietary"}, expectedTotal: 1},
+		{name: "query with a typo", request: dto.LicenseSearchRequestDTO{Query: "apahce"},
+			expected: []string{"Apache-2.0"}, expectedTotal: 1},
+		{name: "non-SPDX filter", request: dto.LicenseSearchRequestDTO{SPDX: &no},
+			expected: []string{"LicenseRef-scancode-proprietary"}, expectedTotal: 1},
+		{name: "copyleft filter", request: dto.LicenseSearchRequestDTO{Copyleft: &yes},
+			expected: []string{"GPL-2.0-only"}, expectedTotal: 1},
+		{name: "FSF libre and not deprecated", request: dto.LicenseSearchRequestDTO{FSFLibre: &yes, Deprecated: &no},
+			expected: []string{"GPL-2.0-only", "MIT"}, expectedTotal: 2},
+		{name: "OSI approved and deprecated", request: dto.LicenseSearchRequestDTO{OSIApproved: &yes, Deprecated: &yes},
+			expected: []string{"GPL-2.0"}, expectedTotal: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.request.Page == 0 {
+				tt.request.Page, tt.request.PageSize = 1, 50
+			}
+			licenses, total := uc.SearchLicenses(ctx, s, tt.request)
+			var ids []string
+			for _, l := range licenses {
+				ids = append(ids, l.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, ids)
+			}
+			if total != tt.expectedTotal {
+				t.Errorf("expected total %d, got %d", tt.expectedTotal, total)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/cache"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+// GetLicenseStats returns the latest precomputed knowledge base statistics. Counts are sorted from the
+// most to the least common, ties by name. Until the statistics cache has completed its first run,
+// a 503 error is returned.
+func (lu LicenseUseCase) GetLicenseStats(_ context.Context, s *zap.SugaredLogger) (dto.LicenseStatsDTO, *Error) {
+	if lu.statsCache == nil {
+		err := errors.New("license statistics are not enabled")
+		return dto.LicenseStatsDTO{}, &Error{Status: common.StatusCode_FAILED, Code: http.StatusServiceUnavailable, Message: err.Error(), Error: err}
+	}
+	stats, ok := lu.statsCache.GetStats()
+	if !ok {
+		err := errors.New("license statistics are being computed, please retry later")
+		return dto.LicenseStatsDTO{}, &Error{Status: common.StatusCode_FAILED, Code: http.StatusServiceUnavailable, Message: err.Error(), Error: err}
+	}
+	s.Debugf("Serving license statistics generated at %v", stats.GeneratedAt)
+	return newLicenseStatsDTO(stats), nil
+}
+
+// newLicenseStatsDTO converts cached statistics into their sorted response form.
+func newLicenseStatsDTO(stats *cache.LicenseStats) dto.LicenseStatsDTO {
+	share := func(n int) float64 {
+		if stats.Components == 0 {
+			return 0
+		}
+		return math.Round(float64(n)/float64(stats.Components)*10000) / 10000
+	}
+	result := dto.LicenseStatsDTO{
+		GeneratedAt:          stats.GeneratedAt.UTC().Format(time.RFC3339),
+		Components:           stats.Components,
+		UnresolvedComponents: stats.Unresolved,
+		UnresolvedShare:      share(stats.Unresolved),
+		Licenses:             make([]dto.LicenseCountDTO, 0, len(stats.ByLicense)),
+		PurlTypes:            make([]dto.PurlTypeCountDTO, 0, len(stats.ByPurlType)),
+		Sources:              make([]dto.SourceCountDTO, 0, len(stats.BySource)),
+	}
+	for l, n := range stats.ByLicense {
+		result.Licenses = append(result.Licenses, dto.LicenseCountDTO{License: l, Components: n, Share: share(n)})
+	}
+	sort.Slice(result.Licenses, func(i, j int) bool {
+		a, b := result.Licenses[i], result.Licenses[j]
+		return a.Components > b.Components || (a.Components == b.Components && a.License < b.License)
+	})
+	for t, n := range stats.ByPurlType {
+		result.PurlTypes = append(result.PurlTypes, dto.PurlTypeCountDTO{PurlType: t, Components: n, Share: share(n)})
+	}
+	sort.Slice(result.PurlTypes, func(i, j int) bool {
+		a, b := result.PurlTypes[i], result.PurlTypes[j]
+		return a.Components > b.Components || (a.Components == b.Components && a.PurlType < b.PurlType)
+	})
+	for id, n := range stats.BySource {
+		result.Sources = append(result.Sources, dto.SourceCountDTO{SourceID: id, Components: n, Share: share(n)})
+	}
+	sort.Slice(result.Sources, func(i, j int) bool {
+		a, b := result.Sources[i], result.Sources[j]
+		return a.Components > b.Components || (a.Components == b.Components && a.SourceID < b.SourceID)
+	})
+	return result
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"scanoss.com/licenses/pkg/cache"
+	myconfig "scanoss.com/licenses/pkg/config"
+)
+
+// fakeStatsCache serves fixed statistics; nil stats means they have not been computed yet.
+type fakeStatsCache struct {
+	stats *cache.LicenseStats
+}
+
+func (f *fakeStatsCache) GetStats() (*cache.LicenseStats, bool) { return f.stats, f.stats != nil }
+func (f *fakeStatsCache) Refresh(_ context.Context) error       { return nil }
+func (f *fakeStatsCache) Start(_ context.Context) error         { return nil }
+func (f *fakeStatsCache) Stop()                                 {}
+
+func TestLicenseUseCase_GetLicenseStats(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+
+	t.Run("not computed yet", func(t *testing.T) {
+		for _, statsCache := range []cache.LicenseStatsCacheInterface{nil, &fakeStatsCache{}} {
+			uc := NewLicenseUseCase(&myconfig.ServerConfig{}, nil, nil, nil, statsCache)
+			_, ucErr := uc.GetLicenseStats(ctx, s)
+			if assert.NotNil(t, ucErr) {
+				assert.Equal(t, http.StatusServiceUnavailable, ucErr.Code)
+			}
+		}
+	})
+
+	t.Run("sorted counts and shares", func(t *testing.T) {
+		generated := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+		uc := NewLicenseUseCase(&myconfig.ServerConfig{}, nil, nil, nil, &fakeStatsCache{stats: &cache.LicenseStats{
+			GeneratedAt: generated,
+			Components:  3,
+			Unresolved:  1,
+			ByLicense:   map[string]int{"MIT": 2, "Apache-2.0": 1, "0BSD": 1},
+			ByPurlType:  map[string]int{"npm": 2, "maven": 1},
+			BySource:    map[int16]int{3: 1, 1: 3},
+		}})
+		stats, ucErr := uc.GetLicenseStats(ctx, s)
+		assert.Nil(t, ucErr)
+		assert.Equal(t, "2026-05-01T12:00:00Z", stats.GeneratedAt)
+		assert.Equal(t, 0.3333, stats.UnresolvedShare)
+		assert.Equal(t, []string{"MIT", "0BSD", "Apache-2.0"},
+			[]string{stats.Licenses[0].License, stats.Licenses[1].License, stats.Licenses[2].License})
+		assert.Equal(t, 0.6667, stats.Licenses[0].Share)
+		assert.Equal(t, "npm", stats.PurlTypes[0].PurlType)
+		assert.Equal(t, int16(1), stats.Sources[0].SourceID)
+		assert.Equal(t, 1.0, stats.Sources[0].Share)
+	})
+}
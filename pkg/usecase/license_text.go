@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/cache"
+	"scanoss.com/licenses/pkg/dto"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+// errLicenseTextsNotConfigured is returned by the license text lookups when no license text store is configured.
+var errLicenseTextsNotConfigured = errors.New("license texts are not configured: set LICENSE_TEXT_DIR or LICENSE_TEXT_TABLE")
+
+// licenseTextsNotConfigured is the error of the license text lookups when no license text store is configured.
+func licenseTextsNotConfigured() *Error {
+	return &Error{Status: common.StatusCode_FAILED, Code: http.StatusNotImplemented,
+		Message: errLicenseTextsNotConfigured.Error(), Error: errLicenseTextsNotConfigured}
+}
+
+// GetLicenseText retrieves the full text, standard header and template of an SPDX license or LicenseRef.
+func (lu LicenseUseCase) GetLicenseText(ctx context.Context, s *zap.SugaredLogger, lic dto.LicenseRequestDTO) (dto.LicenseTextDTO, *Error) {
+	if lu.licenseTextModel == nil {
+		return dto.LicenseTextDTO{}, licenseTextsNotConfigured()
+	}
+	text, err := lu.licenseTextModel.GetLicenseTextByID(ctx, s, lic.ID)
+	if err != nil {
+		return dto.LicenseTextDTO{}, &Error{Status: common.StatusCode_FAILED, Code: http.StatusInternalServerError, Message: err.Error(), Error: err}
+	}
+	if len(text.LicenseID) == 0 {
+		s.Warnf("License text not found: %s", lic.ID)
+		return dto.LicenseTextDTO{}, &Error{
+			Status: common.StatusCode_FAILED,
+			Code:   http.StatusNotFound, Message: "License text not found",
+			Error: errors.New("license text not found")}
+	}
+	return dto.LicenseTextDTO{
+		ID:               text.LicenseID,
+		Name:             text.Name,
+		Text:             text.Text,
+		StandardHeader:   text.StandardHeader,
+		StandardTemplate: text.StandardTemplate,
+	}, nil
+}
+
+// licenseTextsView exposes the license-list-data directory to the admin API, so files added to it are picked
+// up by a refresh. Entry keys are license IDs.
+type licenseTextsView struct {
+	dir         *models.LicenseTextDirModel
+	matcher     *textMatcherIndex
+	mu          sync.Mutex
+	lastRefresh time.Time
+	lastError   string
+}
+
+// Name returns the name of the license texts in the admin API.
+func (v *licenseTextsView) Name() string {
+	return "license-texts"
+}
+
+// Refresh indexes the directory again and rebuilds the license text matcher on its next use.
+// When the directory holds no license texts, the texts indexed before are kept.
+func (v *licenseTextsView) Refresh(_ context.Context) error {
+	err := v.dir.Reindex()
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if err != nil {
+		v.lastError = err.Error()
+		return err
+	}
+	v.matcher.reset()
+	v.lastRefresh = time.Now()
+	v.lastError = ""
+	return nil
+}
+
+// Status returns the number of license texts indexed and the outcome of the last refresh.
+func (v *licenseTextsView) Status() cache.Status {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return cache.Status{Name: v.Name(), Size: v.dir.Size(), LastRefresh: v.lastRefresh, LastError: v.lastError}
+}
+
+// Entry returns the text of the given license ID.
+func (v *licenseTextsView) Entry(licenseID string) (any, bool) {
+	text, err := v.dir.GetLicenseTextByID(context.Background(), zlog.S, licenseID)
+	if err != nil || len(text.LicenseID) == 0 {
+		return nil, false
+	}
+	return text, true
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/dto"
+)
+
+func TestLicenseUseCase_GetLicenseText(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+
+	t.Run("not configured", func(t *testing.T) {
+		uc := NewLicenseUseCase(&myconfig.ServerConfig{}, nil, nil, nil, nil)
+		_, ucErr := uc.GetLicenseText(ctx, zlog.S, dto.LicenseRequestDTO{ID: "MIT"})
+		if assert.NotNil(t, ucErr) {
+			assert.Equal(t, http.StatusNotImplemented, ucErr.Code)
+		}
+		_, ucErr = uc.IdentifyLicense(ctx, zlog.S, dto.LicenseIdentifyRequestDTO{Text: "MIT License"})
+		if assert.NotNil(t, ucErr) {
+			assert.Equal(t, http.StatusNotImplemented, ucErr.Code)
+		}
+		assert.Empty(t, uc.Caches())
+	})
+
+	t.Run("directory refreshed through the admin API", func(t *testing.T) {
+		dir := t.TempDir()
+		textDir := filepath.Join(dir, "text")
+		if err := os.MkdirAll(textDir, 0o755); err != nil {
+			t.Fatalf("Error creating text directory %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(textDir, "LicenseRef-first.txt"), []byte("first"), 0o600); err != nil {
+			t.Fatalf("Error writing license text %v", err)
+		}
+		config := &myconfig.ServerConfig{}
+		config.LicenseText.Dir = dir
+		uc := NewLicenseUseCase(config, nil, nil, nil, nil)
+		caches := uc.Caches()
+		if !assert.Len(t, caches, 1) {
+			return
+		}
+		assert.Equal(t, "license-texts", caches[0].Name())
+		_, ok := caches[0].Entry("licenseref-first")
+		assert.True(t, ok)
+
+		if err := os.WriteFile(filepath.Join(textDir, "LicenseRef-second.txt"), []byte("second"), 0o600); err != nil {
+			t.Fatalf("Error writing license text %v", err)
+		}
+		assert.NoError(t, caches[0].Refresh(ctx))
+		text, ucErr := uc.GetLicenseText(ctx, zlog.S, dto.LicenseRequestDTO{ID: "LicenseRef-second"})
+		assert.Nil(t, ucErr)
+		assert.Equal(t, "second", text.Text)
+		status := caches[0].Status()
+		assert.Equal(t, 2, status.Size)
+		assert.False(t, status.LastRefresh.IsZero())
+	})
+}
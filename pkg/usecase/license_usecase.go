@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
@@ -17,36 +19,84 @@ import (
 	"github.com/scanoss/go-models/pkg/scanoss"
 	common "github.com/scanoss/papi/api/commonv2"
 	pb "github.com/scanoss/papi/api/licensesv2"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 	"scanoss.com/licenses/pkg/cache"
 	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/curation"
 	"scanoss.com/licenses/pkg/dto"
 	"scanoss.com/licenses/pkg/license"
+	"scanoss.com/licenses/pkg/metrics"
 	models "scanoss.com/licenses/pkg/model"
+	"scanoss.com/licenses/pkg/policy"
+	"scanoss.com/licenses/pkg/tracing"
+)
+
+// License resolution steps, in the order they are tried.
+const (
+	stepExactVersion   = "exact_version"
+	stepNearestVersion = "nearest_version"
+	stepUnversioned    = "unversioned"
+	stepCuration       = "curation"
 )
 
 type LicenseUseCase struct {
 	config             *myconfig.ServerConfig
 	sc                 *scanoss.Client
 	purlLicenseModel   *models.PurlLicensesModel
+	licenseRecordModel *models.LicenseRecordModel
+	ldbModel           *models.LDBComponentLicensesModel
 	licenseDetailModel models.LicenseDetailModelInterface
 	osadlModel         models.OSADLModelInterface
 	spdxLicenseCache   cache.SPDXLicenseCacheInterface
+	detailsCache       cache.LicenseDetailsCacheInterface
+	statsCache         cache.LicenseStatsCacheInterface
+	resultCache        *componentResultCache
+	negativeCache      *componentResultCache
+	inflight           *singleflight.Group
+	licenseTextModel   models.LicenseTextModelInterface
+	textMatcher        *textMatcherIndex
+	policy             *policy.Engine
+	curations          *curation.Store
 	db                 *sqlx.DB
 }
 
-func NewLicenseUseCase(config *myconfig.ServerConfig, db *sqlx.DB, spdxCache cache.SPDXLicenseCacheInterface) *LicenseUseCase {
+func NewLicenseUseCase(config *myconfig.ServerConfig, db *sqlx.DB, spdxCache cache.SPDXLicenseCacheInterface,
+	detailsCache cache.LicenseDetailsCacheInterface, statsCache cache.LicenseStatsCacheInterface) *LicenseUseCase {
 	return &LicenseUseCase{
 		config:             config,
 		sc:                 scanoss.New(db),
 		licenseDetailModel: models.NewLicenseDetailModel(db),
 		purlLicenseModel:   models.NewPurlLicensesModel(db),
+		licenseRecordModel: models.NewLicenseRecordModel(db),
+		ldbModel:           models.NewLDBComponentLicensesModel(db),
 		osadlModel:         models.NewOSADLModel(db),
 		spdxLicenseCache:   spdxCache,
+		detailsCache:       detailsCache,
+		statsCache:         statsCache,
+		resultCache:        newComponentResultCache("component-results", config.Cache.ResultSize, config.Cache.ResultTTLMinutes),
+		negativeCache:      newComponentResultCache("component-not-found", config.Cache.ResultSize, config.Cache.NegativeTTLMinutes),
+		inflight:           &singleflight.Group{},
+		licenseTextModel:   newLicenseTextModel(config, db),
+		textMatcher:        newTextMatcherIndex(time.Duration(config.Cache.SPDXRefreshHours) * time.Hour),
 		db:                 db,
 	}
 }
 
+// newLicenseTextModel selects the license text store: the configured license-list-data directory, or the DB table
+// when enabled. It returns nil when neither is configured.
+func newLicenseTextModel(config *myconfig.ServerConfig, db *sqlx.DB) models.LicenseTextModelInterface {
+	if len(config.LicenseText.Dir) > 0 {
+		return models.NewLicenseTextDirModel(config.LicenseText.Dir)
+	}
+	if config.LicenseText.Table {
+		return models.NewLicenseTextModel(db)
+	}
+	return nil
+}
+
 // NewLicenseUseCaseWithLicenseModel option for dependency injection (mainly for testing).
 func NewLicenseUseCaseWithLicenseModel(config *myconfig.ServerConfig, licenseModel models.LicenseDetailModelInterface,
 	osadlModel models.OSADLModelInterface) *LicenseUseCase {
@@ -54,32 +104,100 @@ func NewLicenseUseCaseWithLicenseModel(config *myconfig.ServerConfig, licenseMod
 		config:             config,
 		licenseDetailModel: licenseModel,
 		osadlModel:         osadlModel,
+		textMatcher:        newTextMatcherIndex(0),
+		inflight:           &singleflight.Group{},
 	}
 }
 
-// GetComponentLicense retrieves license info for a single component.
-func (lu LicenseUseCase) GetComponentLicense(ctx context.Context, dto componenthelper.ComponentDTO) (*pb.ComponentLicenseInfo, *Error) {
-	// Reuse existing GetComponentsLicense logic with single-item array
-	results, err := lu.GetComponentsLicense(ctx, []componenthelper.ComponentDTO{dto})
+// GetComponentLicense retrieves license info for a single component, with its elected license, if any.
+func (lu LicenseUseCase) GetComponentLicense(ctx context.Context, componentDTO componenthelper.ComponentDTO) (*pb.ComponentLicenseInfo,
+	[]dto.ComponentElectionDTO, *Error) {
+	// Reuse existing GetComponentsLicenseElected logic with single-item array
+	results, elections, err := lu.GetComponentsLicenseElected(ctx, []componenthelper.ComponentDTO{componentDTO})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if len(results) == 0 {
-		return &pb.ComponentLicenseInfo{}, nil
+		return &pb.ComponentLicenseInfo{}, nil, nil
 	}
-	return results[0], nil
+	return results[0], elections, nil
+}
+
+// componentLicenseResult holds the outcome of resolving a single component, including details
+// that have no place in the papi ComponentLicenseInfo message.
+type componentLicenseResult struct {
+	info *pb.ComponentLicenseInfo
+	// purl and version are the component purl without its version and the version resolved for the request,
+	// which the info reports as requested.
+	purl    string
+	version string
+	// expression combines the SPDX expressions of the license records picked for the component.
+	expression *license.Expression
+	// trace records how the licenses were resolved; nil unless explain mode is on.
+	trace *resolutionTrace
+	// source is where the licenses were taken from; nil when none were found.
+	source *dto.LicenseSourceDTO
+	// err is the first lookup error met while resolving the component. The outcome may then be wrong
+	// (e.g. "not found" for a component the database could not be queried for), so it is never cached.
+	err error
+}
+
+// recordError keeps err as the lookup error of the result, unless one was already recorded.
+func (r *componentLicenseResult) recordError(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// lookupOptions are the per-request options of a component license lookup.
+type lookupOptions struct {
+	// explain records the resolution trace of each component.
+	explain bool
+	// asOf (YYYY-MM-DD) restricts the knowledge base rows to those dated on or before it.
+	asOf string
+	// fresh resolves each component from the database, for lookups that must see the latest data.
+	fresh bool
+	// dbCheck is shared by the components of a lookup, so the database is pinged at most once per lookup.
+	dbCheck *dbCheck
+}
+
+// dbCheck checks, at most once, that the database is still reachable.
+type dbCheck struct {
+	db   *sqlx.DB
+	once sync.Once
+	err  error
+}
+
+// reachable pings the database on the first call and returns the outcome of that ping on every call.
+func (d *dbCheck) reachable(ctx context.Context) error {
+	if d.db == nil {
+		return nil
+	}
+	d.once.Do(func() {
+		d.err = d.db.PingContext(ctx)
+	})
+	return d.err
+}
+
+// bypassCache reports whether the lookup must resolve every component itself, rather than
+// sharing results with the result cache and other in-flight lookups.
+func (o lookupOptions) bypassCache() bool {
+	return o.explain || len(o.asOf) > 0 || o.fresh
 }
 
 // componentsLicenseWorker resolves licenses for the given components concurrently
 // using a bounded worker pool (Lookup.MaxWorkers). Honors ctx cancellation.
-func (lu LicenseUseCase) componentsLicenseWorker(ctx context.Context, s *zap.SugaredLogger, components []componenthelper.Component) []*pb.ComponentLicenseInfo {
-	componentLicenses := make([]*pb.ComponentLicenseInfo, 0, len(components))
-	jobs := make(chan componenthelper.Component, len(components))
-	results := make(chan *pb.ComponentLicenseInfo, len(components))
+// Lookups bypassing the cache resolve each component on its own.
+func (lu LicenseUseCase) componentsLicenseWorker(ctx context.Context, s *zap.SugaredLogger,
+	components []componenthelper.ComponentDTO, opts lookupOptions) []*componentLicenseResult {
+	componentLicenses := make([]*componentLicenseResult, 0, len(components))
+	jobs := make(chan componenthelper.ComponentDTO, len(components))
+	results := make(chan []*componentLicenseResult, len(components))
 	for _, c := range components {
 		jobs <- c
 	}
 	close(jobs)
+	metrics.JobsQueued(len(components))
 	// Cap workers at len(components); fall back to 1 if MaxWorkers is unset.
 	workers := 1
 	if lu.config.Lookup.MaxWorkers > 0 && len(components) > 0 {
@@ -87,16 +205,26 @@ func (lu LicenseUseCase) componentsLicenseWorker(ctx context.Context, s *zap.Sug
 	}
 	for i := 0; i < workers; i++ {
 		go func() {
+			metrics.WorkerStarted()
+			defer metrics.WorkerStopped()
 			for c := range jobs {
 				// Skip queued jobs once the caller has gone away, so we don't
 				// run expensive license lookups for results that will be discarded.
+				// The queue is still drained, so the queued jobs gauge stays accurate.
 				if ctx.Err() != nil {
-					return
+					metrics.JobSkipped()
+					continue
+				}
+				done := metrics.JobStarted()
+				var result []*componentLicenseResult
+				if opts.bypassCache() {
+					result = lu.resolveComponent(ctx, s, c, opts)
+				} else {
+					result = lu.resolveComponentCoalesced(ctx, s, c, opts.dbCheck)
 				}
-				result := lu.processComponentLicenses(ctx, s, c)
+				done()
 				select {
 				case <-ctx.Done():
-					return
 				case results <- result:
 				}
 			}
@@ -108,7 +236,7 @@ func (lu LicenseUseCase) componentsLicenseWorker(ctx context.Context, s *zap.Sug
 			s.Warnf("componentsLicenseWorker cancelled after %d/%d results: %v", i, len(components), ctx.Err())
 			return componentLicenses
 		case r := <-results:
-			componentLicenses = append(componentLicenses, r)
+			componentLicenses = append(componentLicenses, r...)
 		}
 	}
 	return componentLicenses
@@ -116,54 +244,187 @@ func (lu LicenseUseCase) componentsLicenseWorker(ctx context.Context, s *zap.Sug
 
 // GetComponentsLicense retrieves license info for multiple components.
 func (lu LicenseUseCase) GetComponentsLicense(ctx context.Context, componentDTOs []componenthelper.ComponentDTO) ([]*pb.ComponentLicenseInfo, *Error) {
+	componentLicenses, _, err := lu.GetComponentsLicenseElected(ctx, componentDTOs)
+	return componentLicenses, err
+}
+
+// GetComponentsLicenseElected retrieves license info for multiple components, with the license elected using the
+// configured preferences for each component whose expression offers a choice.
+func (lu LicenseUseCase) GetComponentsLicenseElected(ctx context.Context, componentDTOs []componenthelper.ComponentDTO) ([]*pb.ComponentLicenseInfo,
+	[]dto.ComponentElectionDTO, *Error) {
+	results := lu.resolveComponents(ctx, componentDTOs, lookupOptions{})
+	componentLicenses := make([]*pb.ComponentLicenseInfo, 0, len(results))
+	var elections []dto.ComponentElectionDTO
+	for _, r := range results {
+		componentLicenses = append(componentLicenses, r.info)
+		if elected := electedLicense(r, lu.config.Lookup.ElectionPreferences); elected != nil {
+			elections = append(elections, dto.ComponentElectionDTO{
+				Purl:              r.info.GetPurl(),
+				Requirement:       r.info.GetRequirement(),
+				ElectedLicenseDTO: *elected,
+			})
+		}
+	}
+	return componentLicenses, elections, nil
+}
+
+// electedLicense elects one alternative of the expression of r for the given preferences. It returns nil when
+// the expression offers no choice.
+func electedLicense(r *componentLicenseResult, preferences []string) *dto.ElectedLicenseDTO {
+	if r.expression == nil || !r.expression.HasChoice() {
+		return nil
+	}
+	election := license.ElectLicense(r.expression, preferences)
+	return &dto.ElectedLicenseDTO{
+		Licenses:   election.Licenses,
+		Expression: election.Expression,
+		Reason:     election.Reason,
+	}
+}
+
+// GetComponentsLicenseExtended retrieves license info for multiple components, adding the
+// extension fields that are only available through the REST API (e.g. the elected license and policy decision).
+func (lu LicenseUseCase) GetComponentsLicenseExtended(ctx context.Context, request dto.ComponentsExtendedRequestDTO) ([]dto.ComponentLicenseExtendedDTO, *Error) {
+	preferences := request.Preferences
+	if len(preferences) == 0 {
+		preferences = lu.config.Lookup.ElectionPreferences
+	}
+	results := lu.resolveComponents(ctx, request.Components, lookupOptions{explain: request.Explain, asOf: request.AsOf})
+	facts := lu.policyFacts(ctx, ctxzap.Extract(ctx).Sugar())
+	componentLicenses := make([]dto.ComponentLicenseExtendedDTO, 0, len(results))
+	for _, r := range results {
+		component := dto.ComponentLicenseExtendedDTO{
+			ComponentLicenseInfo: r.info,
+			Source:               r.source,
+			ElectedLicense:       electedLicense(r, preferences),
+			Policy:               lu.evaluatePolicy(r, facts),
+			Trace:                r.trace.report(),
+		}
+		componentLicenses = append(componentLicenses, component)
+	}
+	return componentLicenses, nil
+}
+
+// resolveComponents resolves the versions of the requested components and then their licenses.
+// Components whose version resolution failed are returned with their info code set.
+// Components found in the result cache skip both steps, unless the options bypass the cache: explain mode
+// asks for their resolution trace, as_of for a past answer and fresh lookups for the latest one.
+func (lu LicenseUseCase) resolveComponents(ctx context.Context, componentDTOs []componenthelper.ComponentDTO,
+	opts lookupOptions) []*componentLicenseResult {
 	s := ctxzap.Extract(ctx).Sugar()
-	processedComponents := componenthelper.GetComponentsVersion(componenthelper.ComponentVersionCfg{
-		MaxWorkers: lu.config.Lookup.MaxWorkers,
-		DB:         lu.db,
-		Ctx:        ctx,
-		S:          s,
-		Input:      componentDTOs,
-	})
-	failedResults := make([]*pb.ComponentLicenseInfo, 0, len(processedComponents))
-	var toProcess []componenthelper.Component
+	opts.dbCheck = &dbCheck{db: lu.db}
+	var cached []*componentLicenseResult
+	if !opts.bypassCache() {
+		cached, componentDTOs = lu.cachedComponentResults(componentDTOs)
+	}
+	if len(cached) > 0 {
+		s.Debugf("Serving %d of %d components from the result cache", len(cached), len(cached)+len(componentDTOs))
+	}
+	results := cached
+	if len(componentDTOs) > 0 {
+		results = append(results, lu.componentsLicenseWorker(ctx, s, componentDTOs, opts)...)
+	}
+	for _, r := range results {
+		metrics.ObserveComponentOutcome(r.info.GetInfoCode())
+	}
+	return results
+}
+
+// resolveComponent resolves the version of a single requested component and then its licenses.
+// With explain set, each result carries the trace of its resolution.
+func (lu LicenseUseCase) resolveComponent(ctx context.Context, s *zap.SugaredLogger,
+	componentDTO componenthelper.ComponentDTO, opts lookupOptions) []*componentLicenseResult {
+	purlType := tracing.PurlType.String(license.PurlType(componentDTO.Purl))
+	ctx, span := tracing.Start(ctx, "LicenseUseCase.resolveComponent", purlType)
+	defer span.End()
+	processedComponents := lu.resolveComponentVersion(ctx, s, componentDTO)
+	results := make([]*componentLicenseResult, 0, len(processedComponents))
 	for _, c := range processedComponents {
+		trace := newResolutionTrace(opts.explain)
+		trace.versionResolved(c)
+		// A local curation override replaces the knowledge base licenses, including for components it doesn't know.
+		// Overrides are undated, so as_of lookups leave them out, like the other undated sources.
+		if len(opts.asOf) == 0 {
+			if curated := lu.curatedResult(ctx, c, trace); curated != nil {
+				results = append(results, curated)
+				continue
+			}
+		}
 		if c.Status.StatusCode != domain.Success && c.Status.StatusCode != domain.VersionNotFound {
 			msg := c.Status.Message
 			code := c.Status.StatusCode.String()
-			failedResults = append(failedResults, &pb.ComponentLicenseInfo{
+			results = append(results, &componentLicenseResult{info: &pb.ComponentLicenseInfo{
 				Purl:        c.OriginalPurl,
 				Requirement: c.OriginalRequirement,
 				Version:     c.Version,
 				Url:         c.URL,
 				InfoMessage: &msg,
 				InfoCode:    &code,
-			})
+			}, purl: c.Purl, version: c.Version, trace: trace, err: versionResolutionError(ctx, c, opts.dbCheck)})
 			continue
 		}
-		toProcess = append(toProcess, c)
+		results = append(results, lu.processComponentLicenses(ctx, s, c, opts.asOf, trace))
+	}
+	if len(results) > 0 {
+		span.SetAttributes(tracing.ResultCodeValue(results[0].info.GetInfoCode()))
+	}
+	return results
+}
+
+// versionResolutionError tells a component that is unknown from one whose version resolution failed:
+// componenthelper reports both as ComponentNotFound, so for those the database is checked to still be reachable,
+// once per lookup.
+func versionResolutionError(ctx context.Context, c componenthelper.Component, check *dbCheck) error {
+	if c.Status.StatusCode != domain.ComponentNotFound {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
 	}
-	results := make([]*pb.ComponentLicenseInfo, 0, len(componentDTOs))
-	results = append(results, failedResults...)
-	if len(toProcess) > 0 {
-		results = append(results, lu.componentsLicenseWorker(ctx, s, toProcess)...)
+	if check == nil {
+		return nil
 	}
-	return results, nil
+	return check.reachable(ctx)
 }
 
+// resolveComponentVersion resolves the version of a single requested component and lists its known versions.
+func (lu LicenseUseCase) resolveComponentVersion(ctx context.Context, s *zap.SugaredLogger,
+	componentDTO componenthelper.ComponentDTO) []componenthelper.Component {
+	ctx, span := tracing.Start(ctx, "componenthelper.GetComponentsVersion",
+		tracing.PurlType.String(license.PurlType(componentDTO.Purl)))
+	defer span.End()
+	processedComponents := componenthelper.GetComponentsVersion(componenthelper.ComponentVersionCfg{
+		MaxWorkers: 1,
+		DB:         lu.db,
+		Ctx:        ctx,
+		S:          s,
+		Input:      []componenthelper.ComponentDTO{componentDTO},
+	})
+	for _, c := range processedComponents {
+		span.SetAttributes(tracing.CandidateVersions.Int(len(c.Versions)), tracing.ResultCode.String(c.Status.StatusCode.String()))
+	}
+	return processedComponents
+}
+
+// processComponentLicenses resolves the licenses of a component whose version was resolved. A non-empty
+// asOf (YYYY-MM-DD) restricts the knowledge base rows to those dated on or before it.
 func (lu LicenseUseCase) processComponentLicenses(ctx context.Context, s *zap.SugaredLogger,
-	c componenthelper.Component) *pb.ComponentLicenseInfo {
+	c componenthelper.Component, asOf string, trace *resolutionTrace) *componentLicenseResult {
 	componentInfo := &pb.ComponentLicenseInfo{
 		Purl:        c.OriginalPurl,
 		Requirement: c.OriginalRequirement,
 		Url:         c.URL,
 	}
-
+	result := &componentLicenseResult{info: componentInfo, purl: c.Purl, version: c.Version, trace: trace}
 	version := c.Version
 	var purlLicenses []models.PurlLicense
 
 	// Step 1: Try to fetch licenses for the exact resolved version (e.g. "1.2.3").
 	if version != "" {
-		purlLicenses = lu.fetchLicensesByPurlAndVersion(ctx, s, c.Purl, version)
+		var err error
+		purlLicenses, err = lu.fetchLicensesByPurlAndVersion(ctx, s, c.Purl, version, asOf, trace)
+		result.recordError(err)
+		metrics.ObserveResolutionStep(stepExactVersion, len(purlLicenses) > 0)
 	}
 
 	// Step 2: If no licenses were found for the exact version and there are known versions available,
@@ -184,7 +445,9 @@ func (lu LicenseUseCase) processComponentLicenses(ctx context.Context, s *zap.Su
 			})
 		}
 		if len(candidateVersions) > 0 {
-			nearestLicenses, nearestVersion := lu.fetchLicensesByPurlAndVersions(ctx, s, c.Purl, requirement, candidateVersions)
+			nearestLicenses, nearestVersion, err := lu.fetchLicensesByPurlAndVersions(ctx, s, c.Purl, requirement, candidateVersions, asOf, trace)
+			result.recordError(err)
+			metrics.ObserveResolutionStep(stepNearestVersion, len(nearestLicenses) > 0)
 			if len(nearestLicenses) > 0 {
 				purlLicenses = nearestLicenses
 				version = nearestVersion
@@ -205,7 +468,10 @@ func (lu LicenseUseCase) processComponentLicenses(ctx context.Context, s *zap.Su
 	// Step 3: Last resort — try fetching licenses from the unversioned purl entry.
 	if len(purlLicenses) == 0 {
 		s.Infof("no purlLicenses data found for purl=%s version=%s. Trying unversioned purl", c.Purl, version)
-		purlLicenses = lu.fetchLicensesByPurl(ctx, s, c.Purl, lu.config.Lookup.SourcePriority)
+		var err error
+		purlLicenses, err = lu.fetchLicensesByPurl(ctx, s, c.Purl, lu.config.Lookup.SourcePriority, asOf, trace)
+		result.recordError(err)
+		metrics.ObserveResolutionStep(stepUnversioned, len(purlLicenses) > 0)
 		version = ""
 		code := domain.VersionNotFound.String()
 		message := "Retrieving licenses for unversioned component"
@@ -222,9 +488,17 @@ func (lu LicenseUseCase) processComponentLicenses(ctx context.Context, s *zap.Su
 		code := domain.NoInfo.String()
 		componentInfo.InfoMessage = &message
 		componentInfo.InfoCode = &code
-		return componentInfo
+		return result
 	}
 
+	// PickLicensesByPriority keeps the rows of a single source, the one that won for this component
+	metrics.ObserveSourceWin(purlLicenses[0].SourceID)
+	trace.sourcePicked(purlLicenses[0].SourceID)
+	oteltrace.SpanFromContext(ctx).SetAttributes(tracing.Source.Int(int(purlLicenses[0].SourceID)))
+	sourceID := purlLicenses[0].SourceID
+	result.source = &dto.LicenseSourceDTO{Type: dto.LicenseSourceKnowledgeBase, ID: &sourceID, Name: license.SourceName(sourceID),
+		Date: license.LatestDate(purlLicenses)}
+
 	// Retrieve all the unique license ids
 	dedupLicensesIDs := license.ExtractLicenseIDsFromPurlLicenses(purlLicenses)
 	if len(dedupLicensesIDs) == 0 {
@@ -233,11 +507,12 @@ func (lu LicenseUseCase) processComponentLicenses(ctx context.Context, s *zap.Su
 		code := domain.NoInfo.String()
 		componentInfo.InfoMessage = &message
 		componentInfo.InfoCode = &code
-		return componentInfo
+		return result
 	}
 
 	s.Debugf("Found %d unique license_ids from all sources for purl=%s version=%s", len(dedupLicensesIDs), c.Purl, version)
-	finalLicenses := lu.resolveSPDXLicenses(ctx, s, dedupLicensesIDs)
+	finalLicenses, expression, err := lu.resolveSPDXLicenses(ctx, s, dedupLicensesIDs, trace)
+	result.recordError(err)
 
 	// If no licenses could be processed, log and return
 	if len(finalLicenses) == 0 {
@@ -246,7 +521,7 @@ func (lu LicenseUseCase) processComponentLicenses(ctx context.Context, s *zap.Su
 		code := domain.NoInfo.String()
 		componentInfo.InfoMessage = &message
 		componentInfo.InfoCode = &code
-		return componentInfo
+		return result
 	}
 
 	// Build statement by joining all license IDs with " AND "
@@ -258,33 +533,60 @@ func (lu LicenseUseCase) processComponentLicenses(ctx context.Context, s *zap.Su
 	statement := strings.Join(licenseIDs, " AND ")
 	componentInfo.Statement = statement
 	componentInfo.Licenses = finalLicenses
-	return componentInfo
+	result.expression = expression
+	return result
 }
 
-// fetchLicensesByPurlAndVersion retrieves licenses for a specific purl and version,
-// returning rows from the highest-priority configured source that has data for that version.
+// fetchLicensesByPurlAndVersion retrieves licenses for a specific purl and version, from purl_licenses and,
+// when enabled, ldb_component_licenses, returning rows from the highest-priority configured source that has data for that version.
+// A query error is returned with the rows found by the other queries.
 func (lu LicenseUseCase) fetchLicensesByPurlAndVersion(ctx context.Context, s *zap.SugaredLogger,
-	purl, version string) []models.PurlLicense {
-	allLicenses, err := lu.purlLicenseModel.GetLicensesByPurlVersionAndSource(ctx, purl, version, lu.config.Lookup.SourcePriority)
+	purl, version, asOf string, trace *resolutionTrace) ([]models.PurlLicense, error) {
+	ctx, span := lu.startStep(ctx, "LicenseUseCase.fetchLicensesByPurlAndVersion", stepExactVersion, purl)
+	allLicenses, err := lu.purlLicenseModel.GetLicensesByPurlVersionAndSource(ctx, purl, version, lu.config.Lookup.SourcePriority, asOf)
 	if err != nil {
 		s.Warnf("error when querying GetLicensesByPurlVersionAndSource() for purl=%s version=%s: %v", purl, version, err)
-		return nil
+		trace.stepRun(stepExactVersion, []string{version}, nil, nil, "", err)
+		tracing.End(span, err)
+		return nil, err
 	}
-	return license.PickLicensesByPriority(allLicenses, lu.config.Lookup.SourcePriority)
+	ldbLicenses, err := lu.fetchLDBLicenses(ctx, s, purl, []string{version}, asOf)
+	allLicenses = append(allLicenses, ldbLicenses...)
+	picked := license.PickLicensesByPriority(allLicenses, lu.config.Lookup.SourcePriority)
+	trace.stepRun(stepExactVersion, []string{version}, allLicenses, picked, pickedVersion(picked, version), nil)
+	endStep(span, picked)
+	return picked, err
 }
 
-// fetchLicensesByPurlAndVersions retrieves licenses across multiple versions for a purl
-// and returns the licenses for the nearest version to the requirement. If multiple sources
-// have licenses for that version, the highest-priority source (per config) wins.
+// fetchLicensesByPurlAndVersions retrieves licenses across multiple versions for a purl (including
+// ldb_component_licenses, when enabled) and returns the licenses for the nearest version to the requirement. If multiple sources
+// have licenses for that version, the highest-priority source (per config) wins. A query error is returned with the
+// licenses found by the other queries.
 func (lu LicenseUseCase) fetchLicensesByPurlAndVersions(ctx context.Context, s *zap.SugaredLogger,
-	purl, requirement string, versions []string) ([]models.PurlLicense, string) {
-	allLicenses, err := lu.purlLicenseModel.GetLicensesByPurlVersionsAndSource(ctx, purl, versions, lu.config.Lookup.SourcePriority)
+	purl, requirement string, versions []string, asOf string, trace *resolutionTrace) ([]models.PurlLicense, string, error) {
+	ctx, span := lu.startStep(ctx, "LicenseUseCase.fetchLicensesByPurlAndVersions", stepNearestVersion, purl,
+		tracing.CandidateVersions.Int(len(versions)))
+	allLicenses, err := lu.purlLicenseModel.GetLicensesByPurlVersionsAndSource(ctx, purl, versions, lu.config.Lookup.SourcePriority, asOf)
 	if err != nil {
 		s.Warnf("error when querying GetLicensesByPurlVersionsAndSource() for purl=%s: %v", purl, err)
-		return nil, ""
+		trace.stepRun(stepNearestVersion, nil, nil, nil, "", err)
+		tracing.End(span, err)
+		return nil, "", err
 	}
+	ldbLicenses, err := lu.fetchLDBLicenses(ctx, s, purl, versions, asOf)
+	allLicenses = append(allLicenses, ldbLicenses...)
+	picked, nearestVersion, tried := lu.pickNearestVersion(requirement, versions, allLicenses)
+	trace.stepRun(stepNearestVersion, tried, allLicenses, picked, nearestVersion, nil)
+	endStep(span, picked)
+	return picked, nearestVersion, err
+}
+
+// pickNearestVersion returns the licenses of the version nearest to the requirement that has
+// license data in allLicenses, together with that version and the versions tried to find it.
+func (lu LicenseUseCase) pickNearestVersion(requirement string, versions []string,
+	allLicenses []models.PurlLicense) ([]models.PurlLicense, string, []string) {
 	if len(allLicenses) == 0 {
-		return nil, ""
+		return nil, "", nil
 	}
 	// Index licenses by version once, so each loop iteration is an O(1) lookup
 	// instead of a linear scan over allLicenses.
@@ -292,32 +594,64 @@ func (lu LicenseUseCase) fetchLicensesByPurlAndVersions(ctx context.Context, s *
 	for _, l := range allLicenses {
 		licensesByVersion[l.Version] = append(licensesByVersion[l.Version], l)
 	}
+	var tried []string
 	remainingVersions := slices.Clone(versions)
 	for len(remainingVersions) > 0 {
 		nearestVersion := comphelputils.FindNearestVersion(requirement, remainingVersions)
 		if nearestVersion == "" {
-			return nil, ""
+			return nil, "", tried
 		}
+		tried = append(tried, nearestVersion)
 		if picked := license.PickLicensesByPriority(licensesByVersion[nearestVersion], lu.config.Lookup.SourcePriority); picked != nil {
-			return picked, nearestVersion
+			return picked, nearestVersion, tried
 		}
 		// No licenses for this version — drop it and try the next nearest.
 		remainingVersions = slices.DeleteFunc(remainingVersions, func(v string) bool {
 			return v == nearestVersion
 		})
 	}
-	return nil, ""
+	return nil, "", tried
 }
 
 // fetchLicensesByPurl retrieves licenses for an unversioned purl.
 func (lu LicenseUseCase) fetchLicensesByPurl(ctx context.Context, s *zap.SugaredLogger,
-	purl string, sourceID []int16) []models.PurlLicense {
-	purlLicenses, err := lu.purlLicenseModel.GetLicensesByUnversionedPurlAndSource(ctx, purl, sourceID)
+	purl string, sourceID []int16, asOf string, trace *resolutionTrace) ([]models.PurlLicense, error) {
+	ctx, span := lu.startStep(ctx, "LicenseUseCase.fetchLicensesByPurl", stepUnversioned, purl)
+	purlLicenses, err := lu.purlLicenseModel.GetLicensesByUnversionedPurlAndSource(ctx, purl, sourceID, asOf)
 	if err != nil {
 		s.Warnf("error when querying GetLicensesByUnversionedPurlAndSource() for purl=%s: %v", purl, err)
-		return nil
+		trace.stepRun(stepUnversioned, nil, nil, nil, "", err)
+		tracing.End(span, err)
+		return nil, err
 	}
-	return license.PickLicensesByPriority(purlLicenses, lu.config.Lookup.SourcePriority)
+	picked := license.PickLicensesByPriority(purlLicenses, lu.config.Lookup.SourcePriority)
+	trace.stepRun(stepUnversioned, nil, purlLicenses, picked, "", nil)
+	endStep(span, picked)
+	return picked, nil
+}
+
+// pickedVersion returns version when licenses were picked for it, or "" otherwise.
+func pickedVersion(picked []models.PurlLicense, version string) string {
+	if len(picked) == 0 {
+		return ""
+	}
+	return version
+}
+
+// startStep starts the span of a license resolution step for purl.
+func (lu LicenseUseCase) startStep(ctx context.Context, name, step, purl string,
+	attrs ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	attrs = append(attrs, tracing.Step.String(step), tracing.PurlType.String(license.PurlType(purl)))
+	return tracing.Start(ctx, name, attrs...)
+}
+
+// endStep records whether a resolution step found licenses, and from which source, and ends its span.
+func endStep(span oteltrace.Span, picked []models.PurlLicense) {
+	span.SetAttributes(tracing.Found.Bool(len(picked) > 0))
+	if len(picked) > 0 {
+		span.SetAttributes(tracing.Source.Int(int(picked[0].SourceID)))
+	}
+	span.End()
 }
 
 // versionSatisfiesRequirement checks if a version satisfies a semver constraint/requirement.
@@ -333,52 +667,74 @@ func versionSatisfiesRequirement(version, requirement string) bool {
 	return c.Check(v)
 }
 
+// resolveSPDXLicenses looks up the given license record IDs and returns the SPDX licenses they
+// reference, together with the AND of the expressions stored on each record. Records that could not be read
+// are skipped; the first error is returned.
 func (lu LicenseUseCase) resolveSPDXLicenses(ctx context.Context, s *zap.SugaredLogger,
-	dedupLicensesIDs []int32) []*pb.LicenseInfo {
+	dedupLicensesIDs []int32, trace *resolutionTrace) ([]*pb.LicenseInfo, *license.Expression, error) {
+	ctx, span := tracing.Start(ctx, "LicenseUseCase.resolveSPDXLicenses", tracing.LicenseIDs.Int(len(dedupLicensesIDs)))
+	defer span.End()
 	var finalLicenses []*pb.LicenseInfo
+	var expressions []*license.Expression
+	var firstErr error
 	allSpdxLicenses := make(map[string]bool)
 
 	for _, licenseID := range dedupLicensesIDs {
 		licenseRecord, err := lu.sc.Models.Licenses.GetLicenseByID(ctx, licenseID)
 		if err != nil {
 			s.Warnf("error getting license by ID: %d. %v", licenseID, err)
+			trace.licenseRecordFailed(licenseID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
 			continue
 		}
 
-		spdx, err := license.ParseLicenseExpression(licenseRecord.SPDX)
-		if err != nil {
-			s.Warnf("error parsing license expression for license_id %d: %s. %v", licenseID, licenseRecord.SPDX, err)
-			continue
+		parsed := license.ParseLicense(licenseRecord.SPDX)
+		if parsed.Fallback {
+			s.Debugf("license_id %d: %q is not a valid SPDX expression, read as a %s", licenseID, licenseRecord.SPDX, parsed.Method)
 		}
+		trace.licenseRecord(licenseID, licenseRecord.SPDX, licenseRecord.IsSpdx, parsed)
+		spdx := parsed.Licenses
+		expressions = append(expressions, license.ExpressionFromRecord(licenseRecord.SPDX, spdx))
 
 		for _, l := range spdx {
 			if !allSpdxLicenses[l] {
 				allSpdxLicenses[l] = true
-				fullName := ""
-				url := ""
-				isSpdxApproved := false
-				if lu.spdxLicenseCache != nil && licenseRecord.IsSpdx {
-					if detail, ok := lu.spdxLicenseCache.GetLicenseByID(l); ok {
-						fullName = detail.Name
-						url = detail.DetailsURL
-						isSpdxApproved = true
-					}
-				}
-				finalLicenses = append(finalLicenses, &pb.LicenseInfo{
-					Id:             l,
-					FullName:       fullName,
-					Url:            url,
-					IsSpdxApproved: isSpdxApproved,
-				})
+				finalLicenses = append(finalLicenses, lu.licenseInfo(l, licenseRecord.IsSpdx, trace))
 			}
 		}
 	}
 
-	return finalLicenses
+	return finalLicenses, license.JoinExpressions(expressions), firstErr
+}
+
+// licenseInfo describes the license l of a component, with its SPDX name and URL when isSPDX is set
+// and the SPDX license cache knows it.
+func (lu LicenseUseCase) licenseInfo(l string, isSPDX bool, trace *resolutionTrace) *pb.LicenseInfo {
+	info := &pb.LicenseInfo{Id: l}
+	if lu.spdxLicenseCache != nil && isSPDX {
+		detail, ok := lu.spdxLicenseCache.GetLicenseByID(l)
+		trace.spdxCacheLookup(l, ok)
+		if ok {
+			info.FullName = detail.Name
+			info.Url = detail.DetailsURL
+			info.IsSpdxApproved = true
+		}
+	}
+	return info
 }
 
 // GetDetails retrieves detailed license information.
 func (lu LicenseUseCase) GetDetails(ctx context.Context, s *zap.SugaredLogger, lic dto.LicenseRequestDTO) (pb.LicenseDetails, *Error) {
+	if lu.detailsCache != nil {
+		if licenseRecord, ok := lu.detailsCache.GetLicenseByID(lic.ID); ok {
+			// The cache holds the whole osadl table, so a license without a cached entry has no OSADL data.
+			osadl, _ := lu.detailsCache.GetOSADLByLicenseID(licenseRecord.LicenseID)
+			return newLicenseDetails(licenseRecord, osadl), nil
+		}
+		s.Debugf("License details cache miss for %s, falling back to the database", lic.ID)
+	}
 	licenseRecord, err := lu.licenseDetailModel.GetLicenseByID(ctx, s, lic.ID)
 	if err != nil {
 		return pb.LicenseDetails{}, &Error{Status: common.StatusCode_FAILED, Code: http.StatusInternalServerError, Message: err.Error(), Error: err}
@@ -399,24 +755,39 @@ func (lu LicenseUseCase) GetDetails(ctx context.Context, s *zap.SugaredLogger, l
 
 	s.Debugf("OSADL: %v", osadl)
 
+	return newLicenseDetails(licenseRecord, osadl), nil
+}
+
+// newLicenseDetails builds the license details response from a licenses row and its osadl row.
+func newLicenseDetails(licenseRecord models.LicenseDetail, osadl models.OSADL) pb.LicenseDetails {
 	return pb.LicenseDetails{
 		FullName: licenseRecord.Name,
-		Spdx: &pb.SPDX{
-			FullName:      licenseRecord.Name,
-			Id:            licenseRecord.LicenseID,
-			DetailsUrl:    licenseRecord.DetailsURL,
-			ReferenceUrl:  licenseRecord.Reference,
-			IsDeprecated:  licenseRecord.IsDeprecatedLicenseID,
-			IsOsiApproved: licenseRecord.IsOsiApproved,
-			SeeAlso:       licenseRecord.SeeAlso,
-			IsFsfLibre:    licenseRecord.IsFsfLibre,
-		},
-		Osadl: &pb.OSADL{
-			Compatibility:          osadl.Compatibilities,
-			Incompatibility:        osadl.Incompatibilities,
-			CopyleftClause:         osadl.CopyleftClause,
-			DependingCompatibility: osadl.DependingCompatibilities,
-			PatentHints:            osadl.PatentHints,
-		},
-	}, nil
+		Spdx:     newSPDXDetails(licenseRecord),
+		Osadl:    newOSADLDetails(osadl),
+	}
+}
+
+// newSPDXDetails maps a licenses table record onto the SPDX section of the details response.
+func newSPDXDetails(licenseRecord models.LicenseDetail) *pb.SPDX {
+	return &pb.SPDX{
+		FullName:      licenseRecord.Name,
+		Id:            licenseRecord.LicenseID,
+		DetailsUrl:    licenseRecord.DetailsURL,
+		ReferenceUrl:  licenseRecord.Reference,
+		IsDeprecated:  licenseRecord.IsDeprecatedLicenseID,
+		IsOsiApproved: licenseRecord.IsOsiApproved,
+		SeeAlso:       licenseRecord.SeeAlso,
+		IsFsfLibre:    licenseRecord.IsFsfLibre,
+	}
+}
+
+// newOSADLDetails maps an osadl table record onto the OSADL section of the details response.
+func newOSADLDetails(osadl models.OSADL) *pb.OSADL {
+	return &pb.OSADL{
+		Compatibility:          osadl.Compatibilities,
+		Incompatibility:        osadl.Incompatibilities,
+		CopyleftClause:         osadl.CopyleftClause,
+		DependingCompatibility: osadl.DependingCompatibilities,
+		PatentHints:            osadl.PatentHints,
+	}
 }
@@ -36,6 +36,26 @@ func (m *MockOSADLModel) GetOSADLByLicenseID(ctx context.Context, s *zap.Sugared
 	return args.Get(0).(models.OSADL), args.Error(1)
 }
 
+func (m *MockLicenseModel) GetLicensesByIDs(ctx context.Context, s *zap.SugaredLogger, ids []string) ([]models.LicenseDetail, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.LicenseDetail), args.Error(1)
+}
+
+func (m *MockOSADLModel) GetOSADLByLicenseIDs(ctx context.Context, s *zap.SugaredLogger, ids []string) ([]models.OSADL, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.OSADL), args.Error(1)
+}
+
+func (m *MockLicenseModel) GetAllLicenses(ctx context.Context, s *zap.SugaredLogger) ([]models.LicenseDetail, error) {
+	args := m.Called()
+	return args.Get(0).([]models.LicenseDetail), args.Error(1)
+}
+
+func (m *MockOSADLModel) GetAllOSADL(ctx context.Context, s *zap.SugaredLogger) ([]models.OSADL, error) {
+	args := m.Called()
+	return args.Get(0).([]models.OSADL), args.Error(1)
+}
+
 func TestLicenseUseCase_GetDetails(t *testing.T) {
 	err := zlog.NewSugaredDevLogger()
 	if err != nil {
@@ -283,4 +303,71 @@ func TestLicenseUseCase_GetDetails(t *testing.T) {
 			fmt.Printf("Details: %#v\n", details)
 		})
 	}
-}
\ No newline at end of file
+}
+// MockDetailsCache implements cache.LicenseDetailsCacheInterface using testify/mock.
+type MockDetailsCache struct {
+	mock.Mock
+}
+
+func (m *MockDetailsCache) GetLicenseByID(licenseID string) (models.LicenseDetail, bool) {
+	args := m.Called(licenseID)
+	return args.Get(0).(models.LicenseDetail), args.Bool(1)
+}
+
+func (m *MockDetailsCache) GetOSADLByLicenseID(licenseID string) (models.OSADL, bool) {
+	args := m.Called(licenseID)
+	return args.Get(0).(models.OSADL), args.Bool(1)
+}
+
+func (m *MockDetailsCache) GetAllLicenses() []models.LicenseDetail {
+	args := m.Called()
+	return args.Get(0).([]models.LicenseDetail)
+}
+
+func (m *MockDetailsCache) Start(ctx context.Context) error { return nil }
+
+func (m *MockDetailsCache) Stop() {}
+
+func TestLicenseUseCase_GetDetails_DetailsCache(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+
+	detailsCache := new(MockDetailsCache)
+	detailsCache.On("GetLicenseByID", "mit").Return(models.LicenseDetail{ID: 1, Name: "MIT License", LicenseID: "MIT"}, true)
+	detailsCache.On("GetOSADLByLicenseID", "MIT").Return(models.OSADL{LicenseID: "MIT", PatentHints: true}, true)
+	detailsCache.On("GetLicenseByID", "Apache-2.0").Return(models.LicenseDetail{}, false)
+	licModel := new(MockLicenseModel)
+	licModel.On("GetLicenseByID", "Apache-2.0").Return(models.LicenseDetail{ID: 2, Name: "Apache License 2.0", LicenseID: "Apache-2.0"}, nil)
+	osadlModel := new(MockOSADLModel)
+	osadlModel.On("GetOSADLByLicenseID", "Apache-2.0").Return(models.OSADL{LicenseID: "Apache-2.0"}, nil)
+
+	uc := NewLicenseUseCaseWithLicenseModel(&myconfig.ServerConfig{}, licModel, osadlModel)
+	uc.detailsCache = detailsCache
+
+	t.Run("cache hit does not query the database", func(t *testing.T) {
+		details, ucErr := uc.GetDetails(ctx, s, dto.LicenseRequestDTO{ID: "mit"})
+		if ucErr != nil {
+			t.Fatalf("unexpected error: %v", ucErr)
+		}
+		if details.FullName != "MIT License" || !details.Osadl.PatentHints {
+			t.Errorf("unexpected details from cache: %v", details.FullName)
+		}
+		licModel.AssertNotCalled(t, "GetLicenseByID", "mit")
+	})
+
+	t.Run("cache miss falls back to the database", func(t *testing.T) {
+		details, ucErr := uc.GetDetails(ctx, s, dto.LicenseRequestDTO{ID: "Apache-2.0"})
+		if ucErr != nil {
+			t.Fatalf("unexpected error: %v", ucErr)
+		}
+		if details.FullName != "Apache License 2.0" {
+			t.Errorf("expected Apache License 2.0, got %v", details.FullName)
+		}
+		licModel.AssertCalled(t, "GetLicenseByID", "Apache-2.0")
+	})
+}
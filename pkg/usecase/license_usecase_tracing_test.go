@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-component-helper/componenthelper"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	myconfig "scanoss.com/licenses/pkg/config"
+	models "scanoss.com/licenses/pkg/model"
+	"scanoss.com/licenses/pkg/tracing"
+)
+
+func TestLicenseUseCase_Spans(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db, err := sqlx.Connect("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, ctx); err != nil {
+		t.Fatalf("Error loading test SQL data %v", err)
+	}
+	config := &myconfig.ServerConfig{}
+	config.Lookup.MaxWorkers = 1
+	config.Lookup.SourcePriority = []int16{31, 32, 33, 5}
+	uc := NewLicenseUseCase(config, db, nil, nil, nil)
+
+	ctx, root := tracing.Start(ctx, "test")
+	_, ucErr := uc.GetComponentsLicense(ctx, []componenthelper.ComponentDTO{{Purl: "pkg:gitlab/gpl/project", Requirement: "1.0.0"}})
+	root.End()
+	assert.Nil(t, ucErr)
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, s := range recorder.Ended() {
+		if _, ok := spans[s.Name()]; !ok {
+			spans[s.Name()] = s
+		}
+		assert.Equal(t, root.SpanContext().TraceID(), s.SpanContext().TraceID(), "%s belongs to the request trace", s.Name())
+	}
+	attrs := func(name string) map[attribute.Key]attribute.Value {
+		result := make(map[attribute.Key]attribute.Value)
+		if s, ok := spans[name]; assert.True(t, ok, "missing span %s", name) {
+			for _, kv := range s.Attributes() {
+				result[kv.Key] = kv.Value
+			}
+		}
+		return result
+	}
+
+	component := attrs("LicenseUseCase.resolveComponent")
+	assert.Equal(t, "gitlab", component[tracing.PurlType].AsString())
+	assert.Equal(t, "SUCCESS", component[tracing.ResultCode].AsString())
+	assert.Equal(t, int64(31), component[tracing.Source].AsInt64())
+
+	versions := attrs("componenthelper.GetComponentsVersion")
+	assert.Equal(t, "SUCCESS", versions[tracing.ResultCode].AsString())
+	assert.Positive(t, versions[tracing.CandidateVersions].AsInt64())
+
+	step := attrs("LicenseUseCase.fetchLicensesByPurlAndVersion")
+	assert.Equal(t, stepExactVersion, step[tracing.Step].AsString())
+	assert.True(t, step[tracing.Found].AsBool())
+	assert.Equal(t, int64(31), step[tracing.Source].AsInt64())
+
+	assert.Positive(t, attrs("LicenseUseCase.resolveSPDXLicenses")[tracing.LicenseIDs].AsInt64())
+	query := attrs("PurlLicensesModel.GetLicensesByPurlVersionAndSource")
+	assert.Equal(t, "PurlLicensesModel.GetLicensesByPurlVersionAndSource", query[tracing.DBOperation].AsString())
+	assert.Equal(t, spans["LicenseUseCase.fetchLicensesByPurlAndVersion"].SpanContext().SpanID(),
+		spans["PurlLicensesModel.GetLicensesByPurlVersionAndSource"].Parent().SpanID())
+}
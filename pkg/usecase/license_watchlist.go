@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-component-helper/componenthelper"
+	"scanoss.com/licenses/pkg/dto"
+	"scanoss.com/licenses/pkg/watchlist"
+)
+
+// ResolveWatches resolves the current licenses of watched components for the watchlist job, bypassing the
+// result cache. It fails when the database is unreachable, and leaves out the components whose lookup hit an error,
+// so a database failure is not reported as licenses being removed.
+func (lu LicenseUseCase) ResolveWatches(ctx context.Context, keys []watchlist.Key) (map[watchlist.Key]watchlist.Result, error) {
+	if lu.db != nil {
+		if err := lu.db.PingContext(ctx); err != nil {
+			return nil, fmt.Errorf("database unreachable: %w", err)
+		}
+	}
+	components := make([]componenthelper.ComponentDTO, 0, len(keys))
+	for _, k := range keys {
+		components = append(components, componenthelper.ComponentDTO{Purl: k.Purl, Requirement: k.Requirement})
+	}
+	results := make(map[watchlist.Key]watchlist.Result, len(keys))
+	for _, r := range lu.resolveComponents(ctx, components, lookupOptions{fresh: true}) {
+		key := watchlist.Key{Purl: r.info.GetPurl(), Requirement: r.info.GetRequirement()}
+		if r.err != nil {
+			ctxzap.Extract(ctx).Sugar().Warnf("Skipping watched component %s after a lookup error: %v", key, r.err)
+			continue
+		}
+		if _, ok := results[key]; !ok {
+			results[key] = watchResult(r)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// watchResult converts the result of a component lookup into the result kept for a watched component.
+func watchResult(r *componentLicenseResult) watchlist.Result {
+	result := watchlist.Result{
+		Version:   r.info.GetVersion(),
+		Statement: r.info.GetStatement(),
+		Licenses:  []string{},
+		InfoCode:  r.info.GetInfoCode(),
+	}
+	for _, l := range r.info.GetLicenses() {
+		result.Licenses = append(result.Licenses, l.GetId())
+	}
+	slices.Sort(result.Licenses)
+	result.Licenses = slices.Compact(result.Licenses)
+	if r.source != nil {
+		result.Source = r.source.Name
+		if r.source.Type == dto.LicenseSourceCuration {
+			result.Source = dto.LicenseSourceCuration
+		}
+	}
+	return result
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-component-helper/componenthelper"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/domain"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	myconfig "scanoss.com/licenses/pkg/config"
+	models "scanoss.com/licenses/pkg/model"
+	"scanoss.com/licenses/pkg/watchlist"
+)
+
+func TestLicenseUseCase_ResolveWatches(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db, err := sqlx.Connect("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, ctx); err != nil {
+		t.Fatalf("Error loading test SQL data %v", err)
+	}
+	config := &myconfig.ServerConfig{}
+	config.Lookup.MaxWorkers = 2
+	config.Lookup.SourcePriority = []int16{31, 32, 33, 5}
+	config.Cache.ResultSize = 100
+	config.Cache.ResultTTLMinutes = 60
+	uc := NewLicenseUseCase(config, db, nil, nil, nil)
+	gpl := watchlist.Key{Purl: "pkg:gitlab/gpl/project", Requirement: "1.0.0"}
+	unknown := watchlist.Key{Purl: "pkg:npm/does-not-exist"}
+
+	results, err := uc.ResolveWatches(ctx, []watchlist.Key{gpl, unknown})
+	assert.NoError(t, err)
+	assert.Equal(t, watchlist.Result{Version: "1.0.0", Statement: "GPL-2.0-only", Licenses: []string{"GPL-2.0-only"}, Source: "license_file"},
+		results[gpl])
+	if assert.Contains(t, results, unknown) {
+		assert.Empty(t, results[unknown].Licenses)
+		assert.Equal(t, domain.ComponentNotFound.String(), results[unknown].InfoCode)
+	}
+
+	// Watched components are resolved from the database, so changes show up despite the result cache.
+	_, ucErr := uc.GetComponentsLicense(ctx, []componenthelper.ComponentDTO{{Purl: gpl.Purl, Requirement: gpl.Requirement}})
+	assert.Nil(t, ucErr)
+	_, err = db.ExecContext(ctx, "INSERT INTO purl_licenses (purl, version, date, source_id, license_id) VALUES "+
+		"('pkg:gitlab/gpl/project', '1.0.0', '2024-05-01', 31, 5614)")
+	if err != nil {
+		t.Fatalf("Error inserting purl_licenses data %v", err)
+	}
+	results, err = uc.ResolveWatches(ctx, []watchlist.Key{gpl})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GPL-2.0-only", "MIT"}, results[gpl].Licenses)
+
+	// A query failing mid-check leaves the component out, rather than reporting its licenses as removed.
+	closed, err := sqlx.Connect("sqlite", "file:closed?mode=memory")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	models.CloseDB(closed)
+	failing := *uc
+	failing.purlLicenseModel = models.NewPurlLicensesModel(closed)
+	results, err = failing.ResolveWatches(ctx, []watchlist.Key{gpl, unknown})
+	assert.NoError(t, err)
+	assert.NotContains(t, results, gpl)
+	assert.Contains(t, results, unknown)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = uc.ResolveWatches(cancelled, []watchlist.Key{gpl})
+	assert.Error(t, err)
+}
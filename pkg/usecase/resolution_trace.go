@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"github.com/scanoss/go-component-helper/componenthelper"
+	"scanoss.com/licenses/pkg/dto"
+	"scanoss.com/licenses/pkg/license"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+// resolutionTrace records how a component's licenses were resolved, for explain mode.
+// A nil trace records nothing, so resolution code can call it unconditionally.
+type resolutionTrace struct {
+	dto.ResolutionTraceDTO
+}
+
+// newResolutionTrace returns an empty trace, or nil when explain mode is off.
+func newResolutionTrace(explain bool) *resolutionTrace {
+	if !explain {
+		return nil
+	}
+	return &resolutionTrace{ResolutionTraceDTO: dto.ResolutionTraceDTO{
+		Steps:     []dto.ResolutionStepTraceDTO{},
+		Licenses:  []dto.LicenseRecordTraceDTO{},
+		SPDXCache: []dto.SPDXCacheLookupTraceDTO{},
+	}}
+}
+
+// versionResolved records the outcome of resolving the component's version.
+func (t *resolutionTrace) versionResolved(c componenthelper.Component) {
+	if t == nil {
+		return
+	}
+	knownVersions := c.Versions
+	if knownVersions == nil {
+		knownVersions = []string{}
+	}
+	t.VersionResolution = dto.VersionResolutionTraceDTO{
+		Requirement:     c.OriginalRequirement,
+		ResolvedVersion: c.Version,
+		Status:          c.Status.StatusCode.String(),
+		Message:         c.Status.Message,
+		KnownVersions:   knownVersions,
+	}
+}
+
+// stepRun records a resolution step: the versions it tried, the rows it found, and the rows it picked.
+func (t *resolutionTrace) stepRun(step string, versionsTried []string, rows, picked []models.PurlLicense,
+	pickedVersion string, err error) {
+	if t == nil {
+		return
+	}
+	trace := dto.ResolutionStepTraceDTO{
+		Step:          step,
+		VersionsTried: versionsTried,
+		Rows:          groupSourceRows(rows),
+		PickedVersion: pickedVersion,
+	}
+	if len(picked) > 0 {
+		trace.PickedSource = &picked[0].SourceID
+	}
+	if err != nil {
+		trace.Error = err.Error()
+	}
+	t.Steps = append(t.Steps, trace)
+}
+
+// sourcePicked records the source the component's licenses were taken from.
+func (t *resolutionTrace) sourcePicked(sourceID int16) {
+	if t == nil {
+		return
+	}
+	t.PickedSource = &sourceID
+}
+
+// curationApplied records the curation override that set the component's licenses.
+func (t *resolutionTrace) curationApplied(curated *dto.CurationDTO) {
+	if t == nil {
+		return
+	}
+	t.Curation = curated
+}
+
+// licenseRecord records a license record looked up for the component, and how its SPDX string was parsed.
+func (t *resolutionTrace) licenseRecord(licenseID int32, spdx string, isSPDX bool, parsed license.ParsedLicense) {
+	if t == nil {
+		return
+	}
+	licenses := parsed.Licenses
+	if licenses == nil {
+		licenses = []string{}
+	}
+	t.Licenses = append(t.Licenses, dto.LicenseRecordTraceDTO{
+		LicenseID:     licenseID,
+		SPDX:          spdx,
+		IsSPDX:        isSPDX,
+		Licenses:      licenses,
+		ParseMethod:   parsed.Method,
+		ParseFallback: parsed.Fallback,
+	})
+}
+
+// licenseRecordFailed records a license record that could not be looked up.
+func (t *resolutionTrace) licenseRecordFailed(licenseID int32, err error) {
+	if t == nil {
+		return
+	}
+	t.Licenses = append(t.Licenses, dto.LicenseRecordTraceDTO{LicenseID: licenseID, Licenses: []string{}, Error: err.Error()})
+}
+
+// spdxCacheLookup records a lookup of lic in the SPDX license cache.
+func (t *resolutionTrace) spdxCacheLookup(lic string, hit bool) {
+	if t == nil {
+		return
+	}
+	t.SPDXCache = append(t.SPDXCache, dto.SPDXCacheLookupTraceDTO{License: lic, Hit: hit})
+}
+
+// report returns the recorded trace, or nil when explain mode is off.
+func (t *resolutionTrace) report() *dto.ResolutionTraceDTO {
+	if t == nil {
+		return nil
+	}
+	return &t.ResolutionTraceDTO
+}
+
+// groupSourceRows groups purl_licenses rows by source and version, keeping the order in which they were found.
+func groupSourceRows(rows []models.PurlLicense) []dto.SourceRowsTraceDTO {
+	type sourceVersion struct {
+		sourceID int16
+		version  string
+	}
+	result := []dto.SourceRowsTraceDTO{}
+	index := make(map[sourceVersion]int)
+	for _, r := range rows {
+		key := sourceVersion{sourceID: r.SourceID, version: r.Version}
+		i, ok := index[key]
+		if !ok {
+			i = len(result)
+			index[key] = i
+			result = append(result, dto.SourceRowsTraceDTO{SourceID: r.SourceID, SourceName: license.SourceName(r.SourceID), Version: r.Version})
+		}
+		result[i].LicenseIDs = append(result[i].LicenseIDs, r.LicenseID)
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-component-helper/componenthelper"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	myconfig "scanoss.com/licenses/pkg/config"
+	"scanoss.com/licenses/pkg/dto"
+	models "scanoss.com/licenses/pkg/model"
+)
+
+func TestLicenseUseCase_Explain(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db, err := sqlx.Connect("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("Error connecting to DB %v", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, ctx); err != nil {
+		t.Fatalf("Error loading test SQL data %v", err)
+	}
+	config := &myconfig.ServerConfig{}
+	config.Lookup.MaxWorkers = 2
+	config.Lookup.SourcePriority = []int16{31, 32, 33, 5}
+	config.Cache.ResultSize = 10
+	config.Cache.ResultTTLMinutes = 5
+	config.Cache.NegativeTTLMinutes = 1
+	uc := NewLicenseUseCase(config, db, nil, nil, nil)
+	components := []componenthelper.ComponentDTO{
+		{Purl: "pkg:gitlab/gpl/project", Requirement: "1.0.0"},
+		{Purl: "pkg:npm/does-not-exist", Requirement: "1.0.0"},
+	}
+
+	plain, ucErr := uc.GetComponentsLicenseExtended(ctx, dto.ComponentsExtendedRequestDTO{Components: components})
+	assert.Nil(t, ucErr)
+	for _, c := range plain {
+		assert.Nil(t, c.Trace, "traces are only attached in explain mode")
+	}
+
+	explained, ucErr := uc.GetComponentsLicenseExtended(ctx, dto.ComponentsExtendedRequestDTO{Components: components, Explain: true})
+	assert.Nil(t, ucErr)
+	stats, _ := uc.ResultCacheStats()
+	assert.Equal(t, uint64(0), stats.Hits, "explained lookups bypass the result cache")
+	assert.Len(t, explained, len(components))
+	for _, c := range explained {
+		if !assert.NotNil(t, c.Trace) {
+			continue
+		}
+		trace := c.Trace
+		switch c.Purl {
+		case "pkg:gitlab/gpl/project":
+			assert.Equal(t, "1.0.0", trace.VersionResolution.ResolvedVersion)
+			assert.Equal(t, "SUCCESS", trace.VersionResolution.Status)
+			assert.Contains(t, trace.VersionResolution.KnownVersions, "1.0.0")
+			if assert.Len(t, trace.Steps, 1, "the exact version had licenses") {
+				step := trace.Steps[0]
+				assert.Equal(t, stepExactVersion, step.Step)
+				assert.Equal(t, []string{"1.0.0"}, step.VersionsTried)
+				assert.NotEmpty(t, step.Rows)
+				assert.Equal(t, int16(31), *step.PickedSource)
+				assert.Equal(t, "1.0.0", step.PickedVersion)
+			}
+			if assert.NotNil(t, trace.PickedSource) {
+				assert.Equal(t, int16(31), *trace.PickedSource)
+			}
+			if assert.NotEmpty(t, trace.Licenses) {
+				assert.NotEmpty(t, trace.Licenses[0].SPDX)
+				assert.NotEmpty(t, trace.Licenses[0].ParseMethod)
+			}
+			assert.Empty(t, trace.SPDXCache, "no SPDX cache was configured")
+		case "pkg:npm/does-not-exist":
+			assert.Equal(t, "COMPONENT_NOT_FOUND", trace.VersionResolution.Status)
+			assert.Empty(t, trace.Steps)
+			assert.Nil(t, trace.PickedSource)
+		}
+	}
+
+	t.Run("steps and records", func(t *testing.T) {
+		trace := newResolutionTrace(true)
+		rows := []models.PurlLicense{
+			{SourceID: 5, Version: "1.0.0", LicenseID: 1},
+			{SourceID: 31, Version: "1.0.0", LicenseID: 2},
+			{SourceID: 5, Version: "1.0.0", LicenseID: 3},
+		}
+		trace.stepRun(stepNearestVersion, []string{"1.0.0"}, rows, rows[1:2], "1.0.0", nil)
+		assert.Equal(t, []dto.SourceRowsTraceDTO{
+			{SourceID: 5, SourceName: "scancode", Version: "1.0.0", LicenseIDs: []int32{1, 3}},
+			{SourceID: 31, SourceName: "license_file", Version: "1.0.0", LicenseIDs: []int32{2}},
+		}, trace.Steps[0].Rows)
+		assert.Equal(t, int16(31), *trace.Steps[0].PickedSource)
+
+		var disabled *resolutionTrace
+		disabled.stepRun(stepUnversioned, nil, rows, rows, "", nil)
+		disabled.spdxCacheLookup("MIT", true)
+		assert.Nil(t, disabled.report())
+	})
+}
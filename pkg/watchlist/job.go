@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package watchlist
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"scanoss.com/licenses/pkg/cache"
+)
+
+// checkBatchSize is the number of watched components resolved at once.
+const checkBatchSize = 100
+
+// Resolver resolves the current licenses of watched components. Components it could not resolve
+// are left out of the results.
+type Resolver interface {
+	ResolveWatches(ctx context.Context, keys []Key) (map[Key]Result, error)
+}
+
+// Job re-resolves the watched components periodically, notifying the changes of their licenses.
+// A component's first resolution only records its licenses. When a notification fails, the component
+// keeps its previous result, so the change is notified again on the next check.
+type Job struct {
+	store    *Store
+	resolver Resolver
+	notifier Notifier
+	interval time.Duration
+	logger   *zap.SugaredLogger
+
+	// running serializes the checks started by the timer, the admin API and SIGHUP.
+	running sync.Mutex
+
+	mu          sync.RWMutex
+	lastRefresh time.Time
+	lastError   string
+
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewJob creates a job checking the components of store every interval, through resolver. Changes are
+// posted to notifier, or only logged when it is nil. It returns nil when store is nil (no watchlist).
+// An interval of zero disables the periodic checks, leaving them to Refresh.
+func NewJob(store *Store, resolver Resolver, notifier Notifier, interval time.Duration, logger *zap.SugaredLogger) *Job {
+	if store == nil {
+		return nil
+	}
+	return &Job{store: store, resolver: resolver, notifier: notifier, interval: interval, logger: logger, done: make(chan struct{})}
+}
+
+// Start runs a first check in the background and then one every interval. It does nothing when the interval is zero.
+func (j *Job) Start() {
+	if j.interval > 0 {
+		go j.watch()
+	}
+}
+
+// Stop stops the periodic checks.
+func (j *Job) Stop() {
+	j.stopOnce.Do(func() { close(j.done) })
+}
+
+// Name returns the name of the job in the admin API.
+func (j *Job) Name() string {
+	return "watchlist"
+}
+
+// Refresh checks every watched component now.
+func (j *Job) Refresh(ctx context.Context) error {
+	err := j.check(ctx)
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err != nil {
+		j.lastError = err.Error()
+		return err
+	}
+	j.lastRefresh = time.Now()
+	j.lastError = ""
+	return nil
+}
+
+// Status returns the number of watched components and the outcome of the last check.
+func (j *Job) Status() cache.Status {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return cache.Status{Name: j.Name(), Size: len(j.store.List()), LastRefresh: j.lastRefresh, LastError: j.lastError}
+}
+
+// Entry returns the watches of the given purl.
+func (j *Job) Entry(purl string) (any, bool) {
+	watches := j.store.Get(purl)
+	return watches, len(watches) > 0
+}
+
+// check resolves the watched components, notifies the changes of their licenses and saves the results.
+func (j *Job) check(ctx context.Context) error {
+	j.running.Lock()
+	defer j.running.Unlock()
+	watches := j.store.List()
+	checked := make([]Watch, 0, len(watches))
+	var failed int
+	var notifyErr error
+	for start := 0; start < len(watches); start += checkBatchSize {
+		batch := watches[start:min(start+checkBatchSize, len(watches))]
+		keys := make([]Key, 0, len(batch))
+		for _, w := range batch {
+			keys = append(keys, w.Key)
+		}
+		results, err := j.resolver.ResolveWatches(ctx, keys)
+		if err != nil {
+			// Keep the results of the batches already checked.
+			if saveErr := j.store.update(checked); saveErr != nil {
+				j.logger.Errorf("Failed to save the watchlist: %v", saveErr)
+			}
+			return fmt.Errorf("failed to resolve watched components: %w", err)
+		}
+		now := time.Now().UTC()
+		for _, w := range batch {
+			r, ok := results[w.Key]
+			if !ok {
+				continue
+			}
+			w.CheckedAt = now
+			if w.Last != nil && w.Last.licensesChanged(r) {
+				if err = j.notify(ctx, w, r, now); err != nil {
+					j.logger.Warnf("Failed to notify the license change of %s, retrying on the next check: %v", w.Key, err)
+					failed++
+					notifyErr = err
+					checked = append(checked, w)
+					continue
+				}
+				w.ChangedAt = now
+			}
+			w.Last = &r
+			checked = append(checked, w)
+		}
+	}
+	if err := j.store.update(checked); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to notify %d license changes: %w", failed, notifyErr)
+	}
+	return nil
+}
+
+// notify reports the change of the licenses of w to r.
+func (j *Job) notify(ctx context.Context, w Watch, r Result, now time.Time) error {
+	j.logger.Infof("Licenses of watched component %s changed: %q -> %q", w.Key, w.Last.Statement, r.Statement)
+	if j.notifier == nil {
+		return nil
+	}
+	return j.notifier.Notify(ctx, Notification{
+		Event:       EventLicenseChanged,
+		Time:        now,
+		Purl:        w.Purl,
+		Requirement: w.Requirement,
+		Before:      *w.Last,
+		After:       r,
+		Diff:        newDiff(*w.Last, r),
+	})
+}
+
+// watch checks the watched components now and then every interval, until Stop is called.
+func (j *Job) watch() {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		if err := j.Refresh(context.Background()); err != nil {
+			j.logger.Errorf("Watchlist check failed: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-j.done:
+			return
+		}
+	}
+}
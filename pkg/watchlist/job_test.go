@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package watchlist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// stubResolver returns the results it holds, or err.
+type stubResolver struct {
+	mu      sync.Mutex
+	results map[Key]Result
+	err     error
+}
+
+func (r *stubResolver) ResolveWatches(_ context.Context, keys []Key) (map[Key]Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	results := make(map[Key]Result)
+	for _, k := range keys {
+		if res, ok := r.results[k]; ok {
+			results[k] = res
+		}
+	}
+	return results, nil
+}
+
+// webhookReceiver is a local stand-in for a webhook endpoint, recording the notifications it receives.
+type webhookReceiver struct {
+	mu            sync.Mutex
+	status        int
+	notifications []Notification
+	signatures    []string
+}
+
+func (wr *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+	if wr.status != http.StatusOK {
+		w.WriteHeader(wr.status)
+		return
+	}
+	var n Notification
+	if err := json.Unmarshal(body, &n); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	wr.notifications = append(wr.notifications, n)
+	wr.signatures = append(wr.signatures, r.Header.Get(SignatureHeader))
+	if r.Header.Get(SignatureHeader) != Sign([]byte("s3cret"), body) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+}
+
+func TestJob(t *testing.T) {
+	receiver := &webhookReceiver{status: http.StatusOK}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	st, err := Open(filepath.Join(t.TempDir(), "watchlist.json"))
+	if err != nil {
+		t.Fatalf("failed to open the watchlist: %v", err)
+	}
+	gpl := Key{Purl: "pkg:gitlab/gpl/project", Requirement: "1.0.0"}
+	unknown := Key{Purl: "pkg:npm/unknown"}
+	_, err = st.Add([]Key{gpl, unknown})
+	assert.NoError(t, err)
+	resolver := &stubResolver{results: map[Key]Result{
+		gpl: {Version: "1.0.0", Statement: "GPL-2.0-only", Licenses: []string{"GPL-2.0-only"}, Source: "license_file"},
+	}}
+	job := NewJob(st, resolver, NewWebhook(server.URL, "s3cret", time.Second), 0, zap.NewNop().Sugar())
+	ctx := context.Background()
+
+	// The first check records the licenses, without notifying.
+	assert.NoError(t, job.Refresh(ctx))
+	assert.Empty(t, receiver.notifications)
+	watches := st.Get(gpl.Purl)
+	if assert.Len(t, watches, 1) && assert.NotNil(t, watches[0].Last) {
+		assert.Equal(t, "GPL-2.0-only", watches[0].Last.Statement)
+		assert.False(t, watches[0].CheckedAt.IsZero())
+	}
+	assert.Nil(t, st.Get(unknown.Purl)[0].Last, "components not resolved keep no result")
+
+	// A relicensing is notified with the before/after diff.
+	resolver.results[gpl] = Result{Version: "1.0.0", Statement: "GPL-2.0-only OR MIT", Licenses: []string{"GPL-2.0-only", "MIT"},
+		Source: "license_file"}
+	assert.NoError(t, job.Refresh(ctx))
+	if assert.Len(t, receiver.notifications, 1) {
+		n := receiver.notifications[0]
+		assert.Equal(t, EventLicenseChanged, n.Event)
+		assert.Equal(t, gpl.Purl, n.Purl)
+		assert.Equal(t, gpl.Requirement, n.Requirement)
+		assert.Equal(t, "GPL-2.0-only", n.Before.Statement)
+		assert.Equal(t, "GPL-2.0-only OR MIT", n.After.Statement)
+		assert.Equal(t, Diff{Added: []string{"MIT"}, Removed: []string{}}, n.Diff)
+	}
+	assert.False(t, st.Get(gpl.Purl)[0].ChangedAt.IsZero())
+
+	// Unchanged licenses are not notified again.
+	assert.NoError(t, job.Refresh(ctx))
+	assert.Len(t, receiver.notifications, 1)
+
+	// A failed delivery keeps the previous result, so the change is notified on the next check.
+	receiver.status = http.StatusServiceUnavailable
+	resolver.results[gpl] = Result{Version: "1.0.0", Statement: "MIT", Licenses: []string{"MIT"}, Source: "license_file"}
+	assert.Error(t, job.Refresh(ctx))
+	assert.Equal(t, "GPL-2.0-only OR MIT", st.Get(gpl.Purl)[0].Last.Statement)
+	assert.NotEmpty(t, job.Status().LastError)
+	receiver.status = http.StatusOK
+	assert.NoError(t, job.Refresh(ctx))
+	if assert.Len(t, receiver.notifications, 2) {
+		assert.Equal(t, Diff{Added: []string{}, Removed: []string{"GPL-2.0-only"}}, receiver.notifications[1].Diff)
+	}
+	assert.Equal(t, "MIT", st.Get(gpl.Purl)[0].Last.Statement)
+	assert.Empty(t, job.Status().LastError)
+	assert.Equal(t, 2, job.Status().Size)
+
+	// A resolver failure changes nothing.
+	resolver.err = errors.New("database unreachable")
+	assert.Error(t, job.Refresh(ctx))
+	assert.Equal(t, "MIT", st.Get(gpl.Purl)[0].Last.Statement)
+
+	entry, ok := job.Entry(gpl.Purl)
+	assert.True(t, ok)
+	assert.Len(t, entry, 1)
+}
+
+func TestJob_NoWebhook(t *testing.T) {
+	st, err := Open(filepath.Join(t.TempDir(), "watchlist.json"))
+	if err != nil {
+		t.Fatalf("failed to open the watchlist: %v", err)
+	}
+	key := Key{Purl: "pkg:npm/express"}
+	_, err = st.Add([]Key{key})
+	assert.NoError(t, err)
+	resolver := &stubResolver{results: map[Key]Result{key: {Statement: "MIT", Licenses: []string{"MIT"}}}}
+	job := NewJob(st, resolver, nil, 0, zap.NewNop().Sugar())
+	assert.NoError(t, job.Refresh(context.Background()))
+	resolver.results[key] = Result{Statement: "Apache-2.0", Licenses: []string{"Apache-2.0"}}
+	assert.NoError(t, job.Refresh(context.Background()), "changes are only logged")
+	assert.Equal(t, "Apache-2.0", st.Get(key.Purl)[0].Last.Statement)
+	assert.Nil(t, NewJob(nil, resolver, nil, 0, zap.NewNop().Sugar()), "no store disables the job")
+}
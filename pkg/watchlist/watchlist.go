@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package watchlist keeps the components whose licenses are watched, with their last known licenses, and
+// reports license changes to a webhook.
+package watchlist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Key identifies a watched component: a purl, without a version, and an optional version requirement.
+type Key struct {
+	Purl        string `json:"purl"`
+	Requirement string `json:"requirement,omitempty"`
+}
+
+// String returns the key as the purl and requirement separated by a space, which a purl cannot contain,
+// or the purl alone when there is no requirement.
+func (k Key) String() string {
+	if len(k.Requirement) == 0 {
+		return k.Purl
+	}
+	return k.Purl + " " + k.Requirement
+}
+
+// Validate checks that the key names a purl without a version.
+func (k Key) Validate() error {
+	if !strings.HasPrefix(k.Purl, "pkg:") {
+		return fmt.Errorf("invalid purl %q", k.Purl)
+	}
+	if purlHasVersion(k.Purl) {
+		return fmt.Errorf("purl %q must not carry a version, use requirement instead", k.Purl)
+	}
+	return nil
+}
+
+// purlHasVersion reports whether purl carries a version: an "@" in its name, the segment after the last "/".
+// An "@" before it is part of a namespace, such as the scope of pkg:npm/@angular/core.
+func purlHasVersion(purl string) bool {
+	purl, _, _ = strings.Cut(purl, "#")
+	purl, _, _ = strings.Cut(purl, "?")
+	return strings.Contains(purl[strings.LastIndex(purl, "/")+1:], "@")
+}
+
+// Result is the outcome of resolving the licenses of a watched component.
+type Result struct {
+	Version   string `json:"version,omitempty"`
+	Statement string `json:"statement,omitempty"`
+	// Licenses are the SPDX IDs of the component's licenses, sorted.
+	Licenses []string `json:"licenses"`
+	// Source names where the licenses were taken from: a knowledge base source or "curation".
+	Source   string `json:"source,omitempty"`
+	InfoCode string `json:"info_code,omitempty"`
+}
+
+// licensesChanged reports whether after reports different licenses than r.
+func (r Result) licensesChanged(after Result) bool {
+	return r.Statement != after.Statement || !slices.Equal(r.Licenses, after.Licenses)
+}
+
+// Watch is a watched component with the last result resolved for it.
+type Watch struct {
+	Key
+	AddedAt time.Time `json:"added_at"`
+	// Last is the last known result; nil until the component is first resolved.
+	Last      *Result   `json:"last,omitempty"`
+	CheckedAt time.Time `json:"checked_at,omitzero"`
+	// ChangedAt is when a license change of the component was last reported.
+	ChangedAt time.Time `json:"changed_at,omitzero"`
+}
+
+// file is the on-disk form of a watchlist.
+type file struct {
+	Watches []Watch `json:"watches"`
+}
+
+// Store is a watchlist saved as a JSON file, rewritten on each change.
+type Store struct {
+	file string
+
+	mu      sync.RWMutex
+	watches []Watch
+}
+
+// Open reads the watchlist in file, which may not exist yet. It returns nil when file is empty (no watchlist).
+func Open(path string) (*Store, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+	st := &Store{file: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f file
+	if err = json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("watchlist %s: %w", path, err)
+	}
+	for _, w := range f.Watches {
+		if err = w.Validate(); err != nil {
+			return nil, fmt.Errorf("watchlist %s: %w", path, err)
+		}
+	}
+	st.watches = f.Watches
+	return st, nil
+}
+
+// List returns the watched components, in the order they were added.
+func (st *Store) List() []Watch {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return slices.Clone(st.watches)
+}
+
+// Get returns the watches of purl, whatever their requirement.
+func (st *Store) Get(purl string) []Watch {
+	var watches []Watch
+	for _, w := range st.List() {
+		if w.Purl == purl {
+			watches = append(watches, w)
+		}
+	}
+	return watches
+}
+
+// Add starts watching the given components, skipping those already watched. It returns the watches added.
+func (st *Store) Add(keys []Key) ([]Watch, error) {
+	for _, k := range keys {
+		if err := k.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	watches := slices.Clone(st.watches)
+	var added []Watch
+	now := time.Now().UTC()
+	for _, k := range keys {
+		if slices.ContainsFunc(watches, func(w Watch) bool { return w.Key == k }) {
+			continue
+		}
+		w := Watch{Key: k, AddedAt: now}
+		watches = append(watches, w)
+		added = append(added, w)
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+	if err := st.save(watches); err != nil {
+		return nil, err
+	}
+	st.watches = watches
+	return added, nil
+}
+
+// Remove stops watching the given component. It returns false when it was not watched.
+func (st *Store) Remove(key Key) (bool, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	i := slices.IndexFunc(st.watches, func(w Watch) bool { return w.Key == key })
+	if i < 0 {
+		return false, nil
+	}
+	watches := slices.Delete(slices.Clone(st.watches), i, i+1)
+	if err := st.save(watches); err != nil {
+		return false, err
+	}
+	st.watches = watches
+	return true, nil
+}
+
+// update records the results of a check of the watched components. Components removed while they were
+// being checked are ignored.
+func (st *Store) update(checked []Watch) error {
+	byKey := make(map[Key]Watch, len(checked))
+	for _, w := range checked {
+		byKey[w.Key] = w
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	watches := slices.Clone(st.watches)
+	for i, w := range watches {
+		if c, ok := byKey[w.Key]; ok {
+			watches[i] = c
+		}
+	}
+	if err := st.save(watches); err != nil {
+		return err
+	}
+	st.watches = watches
+	return nil
+}
+
+// save writes the watches to a temporary file and renames it over the watchlist file, so a crash
+// never leaves a partly written watchlist behind.
+func (st *Store) save(watches []Watch) error {
+	data, err := json.MarshalIndent(file{Watches: watches}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(st.file), filepath.Base(st.file)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to save watchlist %s: %w", st.file, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), st.file)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save watchlist %s: %w", st.file, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package watchlist
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "watchlist.json")
+	st, err := Open(file)
+	if err != nil {
+		t.Fatalf("failed to open the watchlist: %v", err)
+	}
+	assert.Empty(t, st.List())
+
+	express := Key{Purl: "pkg:npm/express", Requirement: "^4.18"}
+	lodash := Key{Purl: "pkg:npm/lodash"}
+	added, err := st.Add([]Key{express, lodash})
+	assert.NoError(t, err)
+	assert.Len(t, added, 2)
+	added, err = st.Add([]Key{lodash, {Purl: "pkg:npm/express"}})
+	assert.NoError(t, err)
+	if assert.Len(t, added, 1, "watched components are skipped") {
+		assert.Equal(t, Key{Purl: "pkg:npm/express"}, added[0].Key)
+	}
+	_, err = st.Add([]Key{{Purl: "pkg:npm/express@4.18.2"}})
+	assert.Error(t, err, "purls must not carry a version")
+	_, err = st.Add([]Key{{Purl: "express"}})
+	assert.Error(t, err)
+	scoped := Key{Purl: "pkg:npm/@angular/core", Requirement: "^17"}
+	added, err = st.Add([]Key{scoped})
+	assert.NoError(t, err, "the scope of an npm purl is not a version")
+	assert.Len(t, added, 1)
+	assert.Equal(t, "pkg:npm/@angular/core ^17", scoped.String())
+	_, err = st.Add([]Key{{Purl: "pkg:npm/@angular/core@17.0.0"}})
+	assert.Error(t, err)
+	removed, err := st.Remove(scoped)
+	assert.NoError(t, err)
+	assert.True(t, removed)
+
+	assert.NoError(t, st.update([]Watch{{Key: lodash, Last: &Result{Statement: "MIT", Licenses: []string{"MIT"}}}}))
+	assert.Len(t, st.Get("pkg:npm/express"), 2)
+
+	removed, err = st.Remove(express)
+	assert.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = st.Remove(express)
+	assert.NoError(t, err)
+	assert.False(t, removed)
+
+	reopened, err := Open(file)
+	if err != nil {
+		t.Fatalf("failed to reopen the watchlist: %v", err)
+	}
+	assert.Equal(t, st.List(), reopened.List())
+	if watches := reopened.Get("pkg:npm/lodash"); assert.Len(t, watches, 1) && assert.NotNil(t, watches[0].Last) {
+		assert.Equal(t, "MIT", watches[0].Last.Statement)
+	}
+	matches, _ := filepath.Glob(filepath.Join(filepath.Dir(file), "*.tmp"))
+	assert.Empty(t, matches, "temporary files are cleaned up")
+}
+
+func TestOpen(t *testing.T) {
+	st, err := Open("")
+	assert.NoError(t, err)
+	assert.Nil(t, st, "no file disables the watchlist")
+
+	file := filepath.Join(t.TempDir(), "watchlist.json")
+	assert.NoError(t, os.WriteFile(file, []byte(`{"watches":[{"purl":"pkg:npm/express@4.18.2"}]}`), 0o600))
+	_, err = Open(file)
+	assert.Error(t, err)
+	assert.NoError(t, os.WriteFile(file, []byte(`{"watches":`), 0o600))
+	_, err = Open(file)
+	assert.Error(t, err)
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package watchlist
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// EventLicenseChanged is the event of the notifications sent when the licenses of a watched component change.
+const EventLicenseChanged = "license.changed"
+
+// SignatureHeader carries the HMAC-SHA256 of the notification body, as "sha256=<hex>", when a secret is configured.
+const SignatureHeader = "X-Signature-256"
+
+// Notification is the body of a webhook POST reporting a license change of a watched component.
+type Notification struct {
+	Event       string    `json:"event"`
+	Time        time.Time `json:"time"`
+	Purl        string    `json:"purl"`
+	Requirement string    `json:"requirement,omitempty"`
+	Before      Result    `json:"before"`
+	After       Result    `json:"after"`
+	Diff        Diff      `json:"diff"`
+}
+
+// Diff lists the licenses a change added and removed.
+type Diff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// newDiff compares the licenses of two results.
+func newDiff(before, after Result) Diff {
+	d := Diff{Added: []string{}, Removed: []string{}}
+	for _, l := range after.Licenses {
+		if !slices.Contains(before.Licenses, l) {
+			d.Added = append(d.Added, l)
+		}
+	}
+	for _, l := range before.Licenses {
+		if !slices.Contains(after.Licenses, l) {
+			d.Removed = append(d.Removed, l)
+		}
+	}
+	return d
+}
+
+// Notifier delivers license change notifications.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// Webhook posts notifications as JSON to a URL. Any 2xx response is a successful delivery.
+type Webhook struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+// NewWebhook creates a notifier posting to url, signing each body with secret when it is not empty.
+// It returns nil when url is empty (no webhook).
+func NewWebhook(url, secret string, timeout time.Duration) *Webhook {
+	if len(url) == 0 {
+		return nil
+	}
+	return &Webhook{url: url, secret: []byte(secret), client: &http.Client{Timeout: timeout}}
+}
+
+// Notify posts n to the webhook URL.
+func (wh *Webhook) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(wh.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(wh.secret, body))
+	}
+	resp, err := wh.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the signature of body sent in the SignatureHeader, for receivers to check.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}